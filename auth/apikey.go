@@ -38,17 +38,33 @@ type APIKeyInfo struct {
 	// Roles are the roles granted to this key.
 	Roles []string
 
-	// ExpiresAt is when this key expires (zero = never).
+	// ExpiresAt is when this key expires (zero = never). Expired keys are
+	// still returned by stores so the authenticator can fail with
+	// ErrKeyExpired rather than ErrInvalidCredentials.
 	ExpiresAt time.Time
 
+	// RateLimit is the request rate allowed for this key (nil = no limit).
+	// The authenticator does not enforce it; it is returned on
+	// AuthResult.APIKey so downstream middleware can select a limiter.
+	RateLimit *APIKeyRateLimit
+
 	// Metadata contains additional key metadata.
 	Metadata map[string]any
 }
 
+// APIKeyRateLimit describes a token bucket rate limit for an API key.
+type APIKeyRateLimit struct {
+	// RequestsPerSecond is the sustained request rate.
+	RequestsPerSecond float64
+
+	// Burst is the maximum number of requests allowed at once.
+	Burst int
+}
+
 // APIKeyStore provides storage for API keys.
 type APIKeyStore interface {
 	// Lookup retrieves an API key by its hash.
-	// Returns nil if not found.
+	// Returns nil if not found. Expired keys must still be returned.
 	Lookup(ctx context.Context, keyHash string) (*APIKeyInfo, error)
 }
 
@@ -109,7 +125,7 @@ func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, req *AuthRequest
 
 	// Check expiration
 	if !info.ExpiresAt.IsZero() && time.Now().After(info.ExpiresAt) {
-		return AuthFailure(ErrTokenExpired, "api_key"), nil
+		return AuthFailure(ErrKeyExpired, "api_key"), nil
 	}
 
 	// Build identity
@@ -130,7 +146,9 @@ func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, req *AuthRequest
 	}
 	identity.Claims["key_id"] = info.ID
 
-	return AuthSuccess(identity), nil
+	result := AuthSuccess(identity)
+	result.APIKey = info
+	return result, nil
 }
 
 func (a *APIKeyAuthenticator) hashKey(key string) string {
@@ -158,7 +176,20 @@ func ConstantTimeCompare(a, b string) bool {
 	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
 }
 
+// dummyAPIKeyInfo is compared against on lookup misses so that a miss does
+// the same hash comparison work as a hit.
+var dummyAPIKeyInfo = &APIKeyInfo{KeyHash: HashAPIKey("toolops-dummy-api-key")}
+
 // MemoryAPIKeyStore is an in-memory API key store.
+//
+// Lookup is timing-resistant: it always performs one constant-time
+// comparison of the presented hash against a stored hash, using a dummy
+// entry when no key matches. This addresses an attacker who measures
+// response latency to learn whether a key (or key prefix) is registered;
+// hits and misses do equivalent work and the comparison does not exit
+// early on the first differing byte. Hashes of equal length are assumed,
+// as produced by HashAPIKey; the "plain" algorithm does not get this
+// guarantee.
 type MemoryAPIKeyStore struct {
 	mu   sync.RWMutex
 	keys map[string]*APIKeyInfo // keyed by hash
@@ -172,10 +203,20 @@ func NewMemoryAPIKeyStore() *MemoryAPIKeyStore {
 }
 
 // Lookup retrieves an API key by its hash.
+// Hits and misses perform the same constant-time comparison.
 func (s *MemoryAPIKeyStore) Lookup(_ context.Context, keyHash string) (*APIKeyInfo, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.keys[keyHash], nil
+	info, found := s.keys[keyHash]
+	s.mu.RUnlock()
+
+	if !found {
+		info = dummyAPIKeyInfo
+	}
+	match := ConstantTimeCompare(keyHash, info.KeyHash)
+	if !found || !match {
+		return nil, nil
+	}
+	return info, nil
 }
 
 // Add adds an API key to the store.
@@ -2,7 +2,9 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestNewAPIKeyAuthenticator(t *testing.T) {
@@ -131,6 +133,144 @@ func TestAPIKeyAuthenticator_Authenticate(t *testing.T) {
 	})
 }
 
+func TestAPIKeyAuthenticator_Expiration(t *testing.T) {
+	store := NewMemoryAPIKeyStore()
+	_ = store.Add(&APIKeyInfo{
+		ID:        "no-expiry",
+		KeyHash:   HashAPIKey("no-expiry-key"),
+		Principal: "user1",
+	})
+	_ = store.Add(&APIKeyInfo{
+		ID:        "valid",
+		KeyHash:   HashAPIKey("valid-key"),
+		Principal: "user2",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	_ = store.Add(&APIKeyInfo{
+		ID:        "expired",
+		KeyHash:   HashAPIKey("expired-key"),
+		Principal: "user3",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+
+	auth := NewAPIKeyAuthenticator(APIKeyConfig{}, store)
+
+	tests := []struct {
+		name    string
+		key     string
+		wantOK  bool
+		wantErr error
+	}{
+		{"no expiry", "no-expiry-key", true, nil},
+		{"not yet expired", "valid-key", true, nil},
+		{"expired", "expired-key", false, ErrKeyExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &AuthRequest{
+				Headers: map[string][]string{"X-API-Key": {tt.key}},
+			}
+
+			result, err := auth.Authenticate(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Errorf("Authenticated = %v, want %v", result.Authenticated, tt.wantOK)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("expired key is found by store", func(t *testing.T) {
+		info, err := store.Lookup(context.Background(), HashAPIKey("expired-key"))
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+		if info == nil || info.ID != "expired" {
+			t.Errorf("Lookup() = %v, want expired key info", info)
+		}
+	})
+
+	t.Run("ErrKeyExpired matches ErrTokenExpired", func(t *testing.T) {
+		if !errors.Is(ErrKeyExpired, ErrTokenExpired) {
+			t.Error("errors.Is(ErrKeyExpired, ErrTokenExpired) = false, want true")
+		}
+	})
+}
+
+func TestAPIKeyAuthenticator_RateLimitMetadata(t *testing.T) {
+	store := NewMemoryAPIKeyStore()
+	_ = store.Add(&APIKeyInfo{
+		ID:        "gold",
+		KeyHash:   HashAPIKey("gold-key"),
+		Principal: "user1",
+		RateLimit: &APIKeyRateLimit{RequestsPerSecond: 50, Burst: 100},
+	})
+	_ = store.Add(&APIKeyInfo{
+		ID:        "unlimited",
+		KeyHash:   HashAPIKey("unlimited-key"),
+		Principal: "user2",
+	})
+
+	auth := NewAPIKeyAuthenticator(APIKeyConfig{}, store)
+
+	t.Run("rate limit flows into result", func(t *testing.T) {
+		req := &AuthRequest{
+			Headers: map[string][]string{"X-API-Key": {"gold-key"}},
+		}
+
+		result, err := auth.Authenticate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if result.APIKey == nil {
+			t.Fatal("APIKey = nil")
+		}
+		if result.APIKey.ID != "gold" {
+			t.Errorf("APIKey.ID = %v, want gold", result.APIKey.ID)
+		}
+		rl := result.APIKey.RateLimit
+		if rl == nil || rl.RequestsPerSecond != 50 || rl.Burst != 100 {
+			t.Errorf("APIKey.RateLimit = %+v, want {50 100}", rl)
+		}
+	})
+
+	t.Run("no rate limit", func(t *testing.T) {
+		req := &AuthRequest{
+			Headers: map[string][]string{"X-API-Key": {"unlimited-key"}},
+		}
+
+		result, err := auth.Authenticate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if result.APIKey == nil {
+			t.Fatal("APIKey = nil")
+		}
+		if result.APIKey.RateLimit != nil {
+			t.Errorf("APIKey.RateLimit = %+v, want nil", result.APIKey.RateLimit)
+		}
+	})
+
+	t.Run("failure carries no key", func(t *testing.T) {
+		req := &AuthRequest{
+			Headers: map[string][]string{"X-API-Key": {"wrong-key"}},
+		}
+
+		result, err := auth.Authenticate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if result.APIKey != nil {
+			t.Errorf("APIKey = %+v, want nil on failure", result.APIKey)
+		}
+	})
+}
+
 func TestMemoryAPIKeyStore(t *testing.T) {
 	store := NewMemoryAPIKeyStore()
 
@@ -168,6 +308,16 @@ func TestMemoryAPIKeyStore(t *testing.T) {
 		}
 	})
 
+	t.Run("dummy hash never matches", func(t *testing.T) {
+		got, err := store.Lookup(context.Background(), dummyAPIKeyInfo.KeyHash)
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+		if got != nil {
+			t.Errorf("Lookup() = %v, want nil", got)
+		}
+	})
+
 	t.Run("remove", func(t *testing.T) {
 		err := store.Remove("hash123")
 		if err != nil {
@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 // Authenticator validates credentials and returns an identity.
 //
@@ -20,7 +23,8 @@ import "context"
 //   - Authentication failures (invalid token, expired, etc.) return (*AuthResult, nil)
 //     with result.Authenticated=false and result.Error set to the auth error.
 //   - Use sentinel errors from this package for auth failures: [ErrInvalidCredentials],
-//     [ErrTokenExpired], [ErrTokenMalformed], [ErrMissingCredentials].
+//     [ErrTokenExpired], [ErrKeyExpired], [ErrTokenRevoked], [ErrTokenMalformed],
+//     [ErrMissingCredentials].
 //
 // Ownership:
 //   - The caller owns the AuthRequest; implementations must not modify it.
@@ -47,16 +51,25 @@ type AuthRequest struct {
 	// Resource is the target resource (optional, for context).
 	Resource string
 
+	// Body is the raw request body (optional). Authenticators that verify
+	// body signatures, such as WebhookAuthenticator, read it.
+	Body []byte
+
 	// Metadata contains additional request metadata.
 	Metadata map[string]any
 }
 
 // GetHeader returns the first value for a header, or empty string.
+// If the exact key is absent, the canonical MIME header form is tried
+// (e.g., "X-API-Key" matches a stored "X-Api-Key").
 func (r *AuthRequest) GetHeader(key string) string {
 	if r.Headers == nil {
 		return ""
 	}
-	values := r.Headers[key]
+	values, ok := r.Headers[key]
+	if !ok {
+		values = r.Headers[http.CanonicalHeaderKey(key)]
+	}
 	if len(values) == 0 {
 		return ""
 	}
@@ -76,6 +89,11 @@ type AuthResult struct {
 
 	// Method indicates which authenticator method was used.
 	Method string
+
+	// APIKey is the matched key when APIKeyAuthenticator succeeded, so
+	// callers can read per-key settings such as RateLimit without a second
+	// lookup. It is shared with the store and must not be modified.
+	APIKey *APIKeyInfo
 }
 
 // AuthSuccess creates a successful authentication result.
@@ -42,6 +42,12 @@ func TestAuthRequest_GetHeader(t *testing.T) {
 			key:     "X-Empty",
 			want:    "",
 		},
+		{
+			name:    "canonical form fallback",
+			headers: map[string][]string{"X-Api-Key": {"secret"}},
+			key:     "X-API-Key",
+			want:    "secret",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+)
+
+// BasicAuthConfig configures the HTTP Basic authenticator.
+type BasicAuthConfig struct {
+	// HeaderName is the header containing the credentials.
+	// Default: "Authorization"
+	HeaderName string
+
+	// HashAlgorithm is the algorithm used to hash stored passwords, as for
+	// APIKeyConfig: "sha256" (default, see HashAPIKey) or "plain" (not
+	// recommended). Ignored when VerifyPassword is set.
+	HashAlgorithm string
+
+	// VerifyPassword reports whether password matches a stored hash. Set it
+	// to use a slow password hash such as bcrypt, which is preferable to
+	// unsalted SHA-256 for human-chosen passwords.
+	// Default: constant-time comparison of the HashAlgorithm hash
+	VerifyPassword func(hash, password string) bool
+
+	// DummyHash is verified against when the user is unknown, so a miss
+	// costs as much as a wrong password. When VerifyPassword is set, set
+	// this to a hash in its format (e.g., a bcrypt hash of a random
+	// password); otherwise unknown users fail without the slow hash and
+	// response time reveals which user names exist.
+	// Default: SHA-256 hash of a fixed password, matching the default verifier
+	DummyHash string
+}
+
+// BasicCredentialInfo contains information about a registered user.
+type BasicCredentialInfo struct {
+	// Username is the Basic auth user name.
+	Username string
+
+	// PasswordHash is the hashed password (SHA-256 hex by default).
+	PasswordHash string
+
+	// Principal is the identity associated with this user.
+	// Default: Username
+	Principal string
+
+	// TenantID is the tenant this user belongs to.
+	TenantID string
+
+	// Roles are the roles granted to this user.
+	Roles []string
+
+	// Metadata contains additional user metadata.
+	Metadata map[string]any
+}
+
+// BasicCredentialStore provides storage for Basic auth credentials.
+type BasicCredentialStore interface {
+	// Lookup retrieves credentials by user name.
+	// Returns nil if not found.
+	Lookup(ctx context.Context, username string) (*BasicCredentialInfo, error)
+}
+
+// BasicAuthAuthenticator validates HTTP Basic credentials
+// ("Authorization: Basic base64(user:password)").
+//
+// Unknown users are verified against BasicAuthConfig.DummyHash, so response
+// time does not reveal which user names exist.
+type BasicAuthAuthenticator struct {
+	config BasicAuthConfig
+	store  BasicCredentialStore
+}
+
+// NewBasicAuthAuthenticator creates a new HTTP Basic authenticator.
+func NewBasicAuthAuthenticator(config BasicAuthConfig, store BasicCredentialStore) *BasicAuthAuthenticator {
+	// Apply defaults
+	if config.HeaderName == "" {
+		config.HeaderName = "Authorization"
+	}
+	if config.HashAlgorithm == "" {
+		config.HashAlgorithm = "sha256"
+	}
+	if config.DummyHash == "" {
+		config.DummyHash = HashAPIKey("toolops-dummy-password")
+	}
+
+	a := &BasicAuthAuthenticator{
+		config: config,
+		store:  store,
+	}
+	if a.config.VerifyPassword == nil {
+		a.config.VerifyPassword = a.verifyHash
+	}
+	return a
+}
+
+// Name returns "basic".
+func (a *BasicAuthAuthenticator) Name() string {
+	return "basic"
+}
+
+// Supports returns true if the header has a "Basic " prefix.
+func (a *BasicAuthAuthenticator) Supports(_ context.Context, req *AuthRequest) bool {
+	_, ok := basicCredentials(req.GetHeader(a.config.HeaderName))
+	return ok
+}
+
+// Authenticate decodes and validates the Basic credentials.
+func (a *BasicAuthAuthenticator) Authenticate(ctx context.Context, req *AuthRequest) (*AuthResult, error) {
+	encoded, ok := basicCredentials(req.GetHeader(a.config.HeaderName))
+	if !ok || encoded == "" {
+		return AuthFailure(ErrMissingCredentials, "basic"), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return AuthFailure(ErrTokenMalformed, "basic"), nil
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || username == "" {
+		return AuthFailure(ErrTokenMalformed, "basic"), nil
+	}
+
+	info, err := a.store.Lookup(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify even when the user is unknown to keep timing uniform
+	hash := a.config.DummyHash
+	if info != nil {
+		hash = info.PasswordHash
+	}
+	if !a.config.VerifyPassword(hash, password) || info == nil {
+		return AuthFailure(ErrInvalidCredentials, "basic"), nil
+	}
+
+	principal := info.Principal
+	if principal == "" {
+		principal = info.Username
+	}
+	identity := &Identity{
+		Principal: principal,
+		TenantID:  info.TenantID,
+		Roles:     info.Roles,
+		Method:    AuthMethodBasic,
+		Claims:    make(map[string]any),
+	}
+	for k, v := range info.Metadata {
+		identity.Claims[k] = v
+	}
+	identity.Claims["username"] = info.Username
+
+	return AuthSuccess(identity), nil
+}
+
+// verifyHash hashes password with the configured algorithm and compares it
+// to hash in constant time.
+func (a *BasicAuthAuthenticator) verifyHash(hash, password string) bool {
+	if a.config.HashAlgorithm == "plain" {
+		return ConstantTimeCompare(hash, password)
+	}
+	return ConstantTimeCompare(hash, HashAPIKey(password))
+}
+
+// basicCredentials returns the encoded credentials after a case-insensitive
+// "Basic " scheme prefix.
+func basicCredentials(header string) (string, bool) {
+	const prefix = "Basic "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
+
+// MemoryBasicCredentialStore is an in-memory Basic credential store.
+type MemoryBasicCredentialStore struct {
+	mu    sync.RWMutex
+	users map[string]*BasicCredentialInfo // keyed by username
+}
+
+// NewMemoryBasicCredentialStore creates a new in-memory credential store.
+func NewMemoryBasicCredentialStore() *MemoryBasicCredentialStore {
+	return &MemoryBasicCredentialStore{
+		users: make(map[string]*BasicCredentialInfo),
+	}
+}
+
+// Lookup retrieves credentials by user name.
+func (s *MemoryBasicCredentialStore) Lookup(_ context.Context, username string) (*BasicCredentialInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.users[username], nil
+}
+
+// Add adds credentials to the store, replacing any with the same user name.
+func (s *MemoryBasicCredentialStore) Add(info *BasicCredentialInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[info.Username] = info
+	return nil
+}
+
+// Remove removes a user's credentials from the store.
+func (s *MemoryBasicCredentialStore) Remove(username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.users, username)
+	return nil
+}
+
+// Ensure BasicAuthAuthenticator implements Authenticator
+var _ Authenticator = (*BasicAuthAuthenticator)(nil)
+
+// Ensure MemoryBasicCredentialStore implements BasicCredentialStore
+var _ BasicCredentialStore = (*MemoryBasicCredentialStore)(nil)
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func basicHeader(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestNewBasicAuthAuthenticator(t *testing.T) {
+	auth := NewBasicAuthAuthenticator(BasicAuthConfig{}, NewMemoryBasicCredentialStore())
+
+	if auth.Name() != "basic" {
+		t.Errorf("Name() = %v, want basic", auth.Name())
+	}
+	if auth.config.HeaderName != "Authorization" {
+		t.Errorf("HeaderName = %v, want Authorization", auth.config.HeaderName)
+	}
+	if auth.config.HashAlgorithm != "sha256" {
+		t.Errorf("HashAlgorithm = %v, want sha256", auth.config.HashAlgorithm)
+	}
+}
+
+func TestBasicAuthAuthenticator_Supports(t *testing.T) {
+	auth := NewBasicAuthAuthenticator(BasicAuthConfig{}, NewMemoryBasicCredentialStore())
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"basic prefix", basicHeader("alice", "pw"), true},
+		{"lowercase scheme", "basic YWxpY2U6cHc=", true},
+		{"bearer token", "Bearer abc", false},
+		{"no header", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &AuthRequest{Headers: map[string][]string{"Authorization": {tt.header}}}
+			if got := auth.Supports(ctx, req); got != tt.want {
+				t.Errorf("Supports() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasicAuthAuthenticator_Authenticate(t *testing.T) {
+	store := NewMemoryBasicCredentialStore()
+	_ = store.Add(&BasicCredentialInfo{
+		Username:     "alice",
+		PasswordHash: HashAPIKey("correct horse"),
+		TenantID:     "tenant1",
+		Roles:        []string{"admin"},
+	})
+
+	auth := NewBasicAuthAuthenticator(BasicAuthConfig{}, store)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		header  string
+		wantOK  bool
+		wantErr error
+	}{
+		{"valid credentials", basicHeader("alice", "correct horse"), true, nil},
+		{"wrong password", basicHeader("alice", "battery staple"), false, ErrInvalidCredentials},
+		{"unknown user", basicHeader("mallory", "correct horse"), false, ErrInvalidCredentials},
+		{"malformed base64", "Basic not*base64", false, ErrTokenMalformed},
+		{"missing colon", "Basic " + base64.StdEncoding.EncodeToString([]byte("alice")), false, ErrTokenMalformed},
+		{"empty credentials", "Basic ", false, ErrMissingCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &AuthRequest{Headers: map[string][]string{"Authorization": {tt.header}}}
+
+			result, err := auth.Authenticate(ctx, req)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v (error %v)", result.Authenticated, tt.wantOK, result.Error)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+			if tt.wantOK {
+				if result.Identity.Principal != "alice" {
+					t.Errorf("Principal = %v, want alice", result.Identity.Principal)
+				}
+				if result.Identity.TenantID != "tenant1" {
+					t.Errorf("TenantID = %v, want tenant1", result.Identity.TenantID)
+				}
+				if result.Identity.Method != AuthMethodBasic {
+					t.Errorf("Method = %v, want %v", result.Identity.Method, AuthMethodBasic)
+				}
+				if !result.Identity.HasRole("admin") {
+					t.Error("Identity should have admin role")
+				}
+			}
+		})
+	}
+}
+
+func TestBasicAuthAuthenticator_VerifyPassword(t *testing.T) {
+	store := NewMemoryBasicCredentialStore()
+	_ = store.Add(&BasicCredentialInfo{
+		Username:     "svc",
+		PasswordHash: "$custom$pw",
+		Principal:    "service-account",
+	})
+
+	var verified []string
+	auth := NewBasicAuthAuthenticator(BasicAuthConfig{
+		VerifyPassword: func(hash, password string) bool {
+			verified = append(verified, hash)
+			return hash == "$custom$"+password
+		},
+		DummyHash: "$custom$dummy",
+	}, store)
+	ctx := context.Background()
+
+	result, err := auth.Authenticate(ctx, &AuthRequest{
+		Headers: map[string][]string{"Authorization": {basicHeader("svc", "pw")}},
+	})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !result.Authenticated {
+		t.Fatalf("Authenticated = false, error = %v", result.Error)
+	}
+	if result.Identity.Principal != "service-account" {
+		t.Errorf("Principal = %v, want service-account", result.Identity.Principal)
+	}
+
+	// Unknown users are still verified, against the dummy hash
+	result, err = auth.Authenticate(ctx, &AuthRequest{
+		Headers: map[string][]string{"Authorization": {basicHeader("nobody", "pw")}},
+	})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if result.Authenticated {
+		t.Error("Authenticated = true for unknown user")
+	}
+	if len(verified) != 2 || verified[1] != "$custom$dummy" {
+		t.Errorf("VerifyPassword hashes = %v, want the dummy hash for the unknown user", verified)
+	}
+}
+
+func TestMemoryBasicCredentialStore(t *testing.T) {
+	store := NewMemoryBasicCredentialStore()
+	ctx := context.Background()
+
+	_ = store.Add(&BasicCredentialInfo{Username: "alice", PasswordHash: HashAPIKey("pw")})
+
+	info, err := store.Lookup(ctx, "alice")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if info == nil {
+		t.Fatal("Lookup() = nil, want alice")
+	}
+
+	_ = store.Remove("alice")
+	info, _ = store.Lookup(ctx, "alice")
+	if info != nil {
+		t.Error("Lookup() after Remove should return nil")
+	}
+}
@@ -33,6 +33,34 @@ func BenchmarkAPIKeyAuthenticator_Authenticate(b *testing.B) {
 	}
 }
 
+// BenchmarkMemoryAPIKeyStore_LookupHitVsMiss compares hit and miss timing.
+// The two sub-benchmarks should report comparable ns/op.
+func BenchmarkMemoryAPIKeyStore_LookupHitVsMiss(b *testing.B) {
+	store := NewMemoryAPIKeyStore()
+	for i := 0; i < 1000; i++ {
+		_ = store.Add(&APIKeyInfo{
+			ID:      fmt.Sprintf("key-%d", i),
+			KeyHash: HashAPIKey(fmt.Sprintf("api-key-%d", i)),
+		})
+	}
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		keyHash string
+	}{
+		{"hit", HashAPIKey("api-key-500")},
+		{"miss", HashAPIKey("unknown-api-key")},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = store.Lookup(ctx, tc.keyHash)
+			}
+		})
+	}
+}
+
 // BenchmarkAPIKeyAuthenticator_Supports measures support check.
 func BenchmarkAPIKeyAuthenticator_Supports(b *testing.B) {
 	store := NewMemoryAPIKeyStore()
@@ -1,6 +1,8 @@
 // Package auth provides authentication and authorization primitives for tools.
 //
-// It supports multiple authentication methods (JWT, API key, OAuth2 introspection)
-// and role-based access control (RBAC). The package is protocol-agnostic and can
-// be used with any transport layer.
+// It supports multiple authentication methods (JWT, API key, HTTP Basic, OAuth2
+// introspection, HMAC-signed webhooks and requests) and role-based access
+// control (RBAC). The package is protocol-agnostic and can be used with any transport layer. [MethodAuthorizer] and
+// [UnaryServerInterceptor] extend authorization to RPC method paths such as
+// gRPC full methods and GraphQL fields.
 package auth
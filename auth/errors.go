@@ -1,6 +1,9 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for authentication and authorization.
 var (
@@ -10,9 +13,14 @@ var (
 	ErrTokenExpired        = errors.New("auth: token expired")
 	ErrTokenMalformed      = errors.New("auth: token malformed")
 	ErrTokenInactive       = errors.New("auth: token inactive")
+	ErrTokenRevoked        = errors.New("auth: token revoked")
 	ErrIntrospectionFailed = errors.New("auth: introspection failed")
 	ErrKeyNotFound         = errors.New("auth: signing key not found")
 
+	// ErrKeyExpired indicates an API key is past its APIKeyInfo.ExpiresAt.
+	// It wraps ErrTokenExpired, so errors.Is(err, ErrTokenExpired) also matches.
+	ErrKeyExpired = fmt.Errorf("%w: api key", ErrTokenExpired)
+
 	// Authorization errors
 	ErrForbidden = errors.New("auth: access denied")
 )
@@ -14,7 +14,9 @@ func TestSentinelErrors(t *testing.T) {
 		{"ErrInvalidCredentials", ErrInvalidCredentials},
 		{"ErrTokenExpired", ErrTokenExpired},
 		{"ErrTokenInactive", ErrTokenInactive},
+		{"ErrTokenRevoked", ErrTokenRevoked},
 		{"ErrKeyNotFound", ErrKeyNotFound},
+		{"ErrKeyExpired", ErrKeyExpired},
 		{"ErrForbidden", ErrForbidden},
 		{"ErrIntrospectionFailed", ErrIntrospectionFailed},
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jonwraymond/toolops/auth"
+	"github.com/jonwraymond/toolops/resilience"
 )
 
 func ExampleNewJWTAuthenticator() {
@@ -64,6 +65,40 @@ func ExampleNewAPIKeyAuthenticator() {
 	// Tenant: tenant-1
 }
 
+func ExampleAPIKeyInfo_rateLimit() {
+	store := auth.NewMemoryAPIKeyStore()
+	_ = store.Add(&auth.APIKeyInfo{
+		ID:        "key-free-tier",
+		KeyHash:   auth.HashAPIKey("free-tier-key"),
+		Principal: "user@example.com",
+		RateLimit: &auth.APIKeyRateLimit{RequestsPerSecond: 1, Burst: 2},
+	})
+	authenticator := auth.NewAPIKeyAuthenticator(auth.APIKeyConfig{}, store)
+
+	// One limiter per key ID, created from the key's own limit
+	limiters := resilience.NewPerToolRateLimiter(resilience.PerToolRateLimiterConfig{})
+
+	req := &auth.AuthRequest{
+		Headers: map[string][]string{"X-API-Key": {"free-tier-key"}},
+	}
+	for i := 0; i < 3; i++ {
+		result, err := authenticator.Authenticate(context.Background(), req)
+		if err != nil || !result.Authenticated {
+			return
+		}
+		key := result.APIKey
+		limiter := limiters.LimiterWithConfig(key.ID, resilience.RateLimiterConfig{
+			Rate:  key.RateLimit.RequestsPerSecond,
+			Burst: key.RateLimit.Burst,
+		})
+		fmt.Println("Request", i+1, "allowed:", limiter.Allow())
+	}
+	// Output:
+	// Request 1 allowed: true
+	// Request 2 allowed: true
+	// Request 3 allowed: false
+}
+
 func ExampleHashAPIKey() {
 	// Hash an API key for storage
 	value := "example-value-abc123"
@@ -129,6 +129,13 @@ func init() {
 		if audience, ok := cfg["audience"].(string); ok {
 			config.Audience = audience
 		}
+		if audiences, ok := cfg["audiences"].([]any); ok {
+			for _, a := range audiences {
+				if s, ok := a.(string); ok {
+					config.Audiences = append(config.Audiences, s)
+				}
+			}
+		}
 		if headerName, ok := cfg["header_name"].(string); ok {
 			config.HeaderName = headerName
 		}
@@ -211,6 +218,139 @@ func init() {
 		return NewAPIKeyAuthenticator(config, store), nil
 	})
 
+	// Register HTTP Basic authenticator
+	_ = DefaultRegistry.RegisterAuthenticator("basic", func(cfg map[string]any) (Authenticator, error) {
+		config := BasicAuthConfig{}
+
+		if headerName, ok := cfg["header_name"].(string); ok {
+			config.HeaderName = headerName
+		}
+		if algorithm, ok := cfg["hash_algorithm"].(string); ok {
+			config.HashAlgorithm = algorithm
+		}
+
+		store := NewMemoryBasicCredentialStore()
+
+		// Pre-populate users if provided
+		if users, ok := cfg["users"].([]any); ok {
+			for _, u := range users {
+				if userMap, ok := u.(map[string]any); ok {
+					info := &BasicCredentialInfo{}
+					if username, ok := userMap["username"].(string); ok {
+						info.Username = username
+					}
+					if hash, ok := userMap["password_hash"].(string); ok {
+						info.PasswordHash = hash
+					}
+					if principal, ok := userMap["principal"].(string); ok {
+						info.Principal = principal
+					}
+					if tenantID, ok := userMap["tenant_id"].(string); ok {
+						info.TenantID = tenantID
+					}
+					if roles, ok := userMap["roles"].([]any); ok {
+						for _, r := range roles {
+							if s, ok := r.(string); ok {
+								info.Roles = append(info.Roles, s)
+							}
+						}
+					}
+					_ = store.Add(info)
+				}
+			}
+		}
+
+		return NewBasicAuthAuthenticator(config, store), nil
+	})
+
+	// Register webhook signature authenticator
+	_ = DefaultRegistry.RegisterAuthenticator("webhook", func(cfg map[string]any) (Authenticator, error) {
+		config := WebhookConfig{}
+
+		secret, ok := cfg["secret"].(string)
+		if !ok || secret == "" {
+			return nil, errWebhookSecretMissing
+		}
+		config.Secret = []byte(secret)
+
+		if header, ok := cfg["signature_header"].(string); ok {
+			config.SignatureHeader = header
+		}
+		if algorithm, ok := cfg["algorithm"].(string); ok {
+			config.Algorithm = algorithm
+		}
+		if format, ok := cfg["format"].(string); ok {
+			config.Format = format
+		}
+		if tolerance, ok := cfg["tolerance"].(string); ok {
+			d, err := time.ParseDuration(tolerance)
+			if err != nil {
+				return nil, fmt.Errorf("auth: invalid webhook tolerance %q: %w", tolerance, err)
+			}
+			config.Tolerance = d
+		}
+		if principal, ok := cfg["principal"].(string); ok {
+			config.Principal = principal
+		}
+		if roles, ok := cfg["roles"].([]any); ok {
+			for _, r := range roles {
+				if s, ok := r.(string); ok {
+					config.Roles = append(config.Roles, s)
+				}
+			}
+		}
+
+		auth, err := NewWebhookAuthenticator(config)
+		if err != nil {
+			return nil, err
+		}
+		return auth, nil
+	})
+
+	// Register HMAC request-signature authenticator
+	_ = DefaultRegistry.RegisterAuthenticator("hmac", func(cfg map[string]any) (Authenticator, error) {
+		config := HMACConfig{}
+
+		secret, ok := cfg["secret"].(string)
+		if !ok || secret == "" {
+			return nil, errHMACSecretMissing
+		}
+		config.Secret = []byte(secret)
+
+		if header, ok := cfg["signature_header"].(string); ok {
+			config.SignatureHeader = header
+		}
+		if header, ok := cfg["timestamp_header"].(string); ok {
+			config.TimestampHeader = header
+		}
+		if skew, ok := cfg["max_skew"].(string); ok {
+			if d, err := time.ParseDuration(skew); err == nil {
+				config.MaxSkew = d
+			}
+		}
+		if algorithm, ok := cfg["algorithm"].(string); ok {
+			config.Algorithm = algorithm
+		}
+		if prefix, ok := cfg["signature_prefix"].(string); ok {
+			config.SignaturePrefix = prefix
+		}
+		if encoding, ok := cfg["encoding"].(string); ok {
+			config.Encoding = encoding
+		}
+		if principal, ok := cfg["principal"].(string); ok {
+			config.Principal = principal
+		}
+		if roles, ok := cfg["roles"].([]any); ok {
+			for _, r := range roles {
+				if s, ok := r.(string); ok {
+					config.Roles = append(config.Roles, s)
+				}
+			}
+		}
+
+		return NewHMACAuthenticator(config), nil
+	})
+
 	// Register simple RBAC authorizer
 	_ = DefaultRegistry.RegisterAuthorizer("simple_rbac", func(cfg map[string]any) (Authorizer, error) {
 		config := RBACConfig{
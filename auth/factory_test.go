@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"testing"
 )
 
@@ -230,6 +231,78 @@ func TestDefaultRegistry_BuiltInFactories(t *testing.T) {
 		}
 	})
 
+	t.Run("webhook authenticator", func(t *testing.T) {
+		auth, err := DefaultRegistry.CreateAuthenticator("webhook", map[string]any{
+			"secret": "webhook-secret",
+			"format": "stripe",
+		})
+		if err != nil {
+			t.Fatalf("CreateAuthenticator(webhook) error = %v", err)
+		}
+		if auth.Name() != "webhook" {
+			t.Errorf("Name() = %v, want webhook", auth.Name())
+		}
+
+		if _, err := DefaultRegistry.CreateAuthenticator("webhook", map[string]any{}); err == nil {
+			t.Error("CreateAuthenticator(webhook) without secret should error")
+		}
+		for _, cfg := range []map[string]any{
+			{"secret": "s", "tolerance": "five minutes"},
+			{"secret": "s", "format": "gitlab"},
+			{"secret": "s", "algorithm": "md5"},
+		} {
+			if auth, err := DefaultRegistry.CreateAuthenticator("webhook", cfg); err == nil || auth != nil {
+				t.Errorf("CreateAuthenticator(webhook, %v) = %v, %v; want error", cfg, auth, err)
+			}
+		}
+	})
+
+	t.Run("basic authenticator", func(t *testing.T) {
+		auth, err := DefaultRegistry.CreateAuthenticator("basic", map[string]any{
+			"users": []any{
+				map[string]any{
+					"username":      "alice",
+					"password_hash": HashAPIKey("s3cret"),
+					"roles":         []any{"admin"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateAuthenticator(basic) error = %v", err)
+		}
+		if auth.Name() != "basic" {
+			t.Errorf("Name() = %v, want basic", auth.Name())
+		}
+
+		result, err := auth.Authenticate(context.Background(), &AuthRequest{
+			Headers: map[string][]string{"Authorization": {"Basic YWxpY2U6czNjcmV0"}},
+		})
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if !result.Authenticated || !result.Identity.HasRole("admin") {
+			t.Errorf("Authenticate() = %+v, want authenticated admin", result)
+		}
+	})
+
+	t.Run("hmac authenticator", func(t *testing.T) {
+		auth, err := DefaultRegistry.CreateAuthenticator("hmac", map[string]any{
+			"secret":           "hmac-secret",
+			"timestamp_header": "X-Request-Timestamp",
+			"max_skew":         "1m",
+		})
+		if err != nil {
+			t.Fatalf("CreateAuthenticator(hmac) error = %v", err)
+		}
+		if auth.Name() != "hmac" {
+			t.Errorf("Name() = %v, want hmac", auth.Name())
+		}
+
+		if _, err := DefaultRegistry.CreateAuthenticator("hmac", map[string]any{}); err == nil {
+			t.Error("CreateAuthenticator(hmac) without secret should error")
+		}
+	})
+
 	t.Run("simple_rbac authorizer", func(t *testing.T) {
 		authz, err := DefaultRegistry.CreateAuthorizer("simple_rbac", map[string]any{
 			"default_role": "user",
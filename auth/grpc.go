@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor that authenticates the
+// caller from incoming metadata and authorizes the full method.
+//
+// Metadata keys are canonicalized to HTTP header form (e.g., "authorization"
+// becomes "Authorization") so existing authenticators work unchanged. On
+// success the identity and headers are attached to the handler context.
+//
+// Errors are mapped to gRPC status codes:
+//   - codes.Unauthenticated: missing or invalid credentials
+//   - codes.PermissionDenied: authorizer denied the method
+//   - codes.Internal: authenticator or authorizer infrastructure failure
+//
+// Calls without credentials are rejected unless authz implements
+// PublicMethodMatcher and marks the method public (as MethodAuthorizer does
+// for MethodRule.Public); the handler context then carries no identity.
+// Calls with invalid credentials are always rejected. If authz is nil, only
+// authentication is enforced.
+func UnaryServerInterceptor(authn Authenticator, authz Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		headers := headersFromMetadata(ctx)
+		authReq := &AuthRequest{
+			Headers:  headers,
+			Resource: info.FullMethod,
+		}
+
+		if !authn.Supports(ctx, authReq) {
+			return unauthenticatedCall(ctx, req, info, handler, authz, headers)
+		}
+
+		result, err := authn.Authenticate(ctx, authReq)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "authentication unavailable")
+		}
+		if !result.Authenticated && errors.Is(result.Error, ErrMissingCredentials) {
+			return unauthenticatedCall(ctx, req, info, handler, authz, headers)
+		}
+		if !result.Authenticated {
+			msg := ErrInvalidCredentials.Error()
+			if result.Error != nil {
+				msg = result.Error.Error()
+			}
+			return nil, status.Error(codes.Unauthenticated, msg)
+		}
+
+		if authz != nil {
+			if err := authz.Authorize(ctx, GRPCMethodRequest(result.Identity, info.FullMethod)); err != nil {
+				if errors.Is(err, ErrForbidden) {
+					return nil, status.Error(codes.PermissionDenied, err.Error())
+				}
+				return nil, status.Error(codes.Internal, "authorization unavailable")
+			}
+		}
+
+		ctx = WithHeaders(ctx, headers)
+		ctx = WithIdentity(ctx, result.Identity)
+		return handler(ctx, req)
+	}
+}
+
+// unauthenticatedCall runs handler for a call without credentials only if
+// authz explicitly marks the method public.
+func unauthenticatedCall(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, authz Authorizer, headers map[string][]string) (any, error) {
+	matcher, ok := authz.(PublicMethodMatcher)
+	if !ok || !matcher.IsPublicMethod(info.FullMethod) {
+		return nil, status.Error(codes.Unauthenticated, ErrMissingCredentials.Error())
+	}
+
+	return handler(WithHeaders(ctx, headers), req)
+}
+
+// headersFromMetadata converts incoming gRPC metadata to canonical HTTP headers.
+func headersFromMetadata(ctx context.Context) map[string][]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return map[string][]string{}
+	}
+
+	headers := make(map[string][]string, len(md))
+	for k, v := range md {
+		key := http.CanonicalHeaderKey(k)
+		headers[key] = append(headers[key], v...)
+	}
+	return headers
+}
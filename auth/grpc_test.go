@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestInterceptor(t *testing.T) grpc.UnaryServerInterceptor {
+	t.Helper()
+
+	store := NewMemoryAPIKeyStore()
+	_ = store.Add(&APIKeyInfo{ID: "k1", KeyHash: HashAPIKey("admin-key"), Principal: "alice", Roles: []string{"admin"}})
+	_ = store.Add(&APIKeyInfo{ID: "k2", KeyHash: HashAPIKey("user-key"), Principal: "bob", Roles: []string{"user"}})
+	authn := NewAPIKeyAuthenticator(APIKeyConfig{}, store)
+
+	authz, err := NewMethodAuthorizer(MethodAuthorizerConfig{
+		Rules: []MethodRule{{Pattern: `/admin\..*/.*`, Roles: []string{"admin"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewMethodAuthorizer() error = %v", err)
+	}
+
+	return UnaryServerInterceptor(authn, authz)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := newTestInterceptor(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/admin.v1.Users/Delete"}
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{"admin key permitted", metadata.Pairs("x-api-key", "admin-key"), codes.OK},
+		{"user key denied", metadata.Pairs("x-api-key", "user-key"), codes.PermissionDenied},
+		{"invalid key", metadata.Pairs("x-api-key", "bogus"), codes.Unauthenticated},
+		{"no credentials", metadata.MD{}, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var gotPrincipal string
+			handler := func(ctx context.Context, req any) (any, error) {
+				gotPrincipal = PrincipalFromContext(ctx)
+				return "ok", nil
+			}
+
+			resp, err := interceptor(ctx, "req", info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err=%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK {
+				if resp != "ok" {
+					t.Errorf("resp = %v, want ok", resp)
+				}
+				if gotPrincipal != "alice" {
+					t.Errorf("principal in handler context = %q, want alice", gotPrincipal)
+				}
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_PublicMethod(t *testing.T) {
+	store := NewMemoryAPIKeyStore()
+	_ = store.Add(&APIKeyInfo{ID: "k1", KeyHash: HashAPIKey("user-key"), Principal: "bob", Roles: []string{"user"}})
+	authn := NewAPIKeyAuthenticator(APIKeyConfig{}, store)
+
+	authz, err := NewMethodAuthorizer(MethodAuthorizerConfig{
+		Rules: []MethodRule{
+			{Pattern: `/grpc\.health\..*/.*`, Public: true},
+			{Pattern: `/.*`, Roles: []string{"user"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMethodAuthorizer() error = %v", err)
+	}
+	interceptor := UnaryServerInterceptor(authn, authz)
+
+	tests := []struct {
+		name          string
+		method        string
+		md            metadata.MD
+		wantCode      codes.Code
+		wantPrincipal string
+	}{
+		{"public without credentials", "/grpc.health.v1.Health/Check", metadata.MD{}, codes.OK, ""},
+		{"public with credentials", "/grpc.health.v1.Health/Check", metadata.Pairs("x-api-key", "user-key"), codes.OK, "bob"},
+		{"public with invalid credentials", "/grpc.health.v1.Health/Check", metadata.Pairs("x-api-key", "bogus"), codes.Unauthenticated, ""},
+		{"protected without credentials", "/app.v1.Items/List", metadata.MD{}, codes.Unauthenticated, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var gotPrincipal string
+			handler := func(ctx context.Context, req any) (any, error) {
+				gotPrincipal = PrincipalFromContext(ctx)
+				return "ok", nil
+			}
+
+			_, err := interceptor(ctx, "req", &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err=%v)", code, tt.wantCode, err)
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("principal in handler context = %q, want %q", gotPrincipal, tt.wantPrincipal)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_NoCredentialsFailClosed(t *testing.T) {
+	authn := NewAPIKeyAuthenticator(APIKeyConfig{}, NewMemoryAPIKeyStore())
+	permissive := AuthorizerFunc(func(context.Context, *AuthzRequest) error { return nil })
+
+	tests := []struct {
+		name  string
+		authz Authorizer
+	}{
+		{"allow all authorizer", AllowAllAuthorizer{}},
+		{"authorizer func ignoring subject", permissive},
+		{"nil authorizer", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor(authn, tt.authz)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+
+			called := false
+			_, err := interceptor(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/app.v1.Items/List"},
+				func(ctx context.Context, req any) (any, error) {
+					called = true
+					return "ok", nil
+				})
+			if code := status.Code(err); code != codes.Unauthenticated {
+				t.Errorf("code = %v, want Unauthenticated", code)
+			}
+			if called {
+				t.Error("handler called for a call without credentials")
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_AuthenticatorError(t *testing.T) {
+	authn := NewAuthenticatorFunc("failing",
+		func(context.Context, *AuthRequest) bool { return true },
+		func(context.Context, *AuthRequest) (*AuthResult, error) {
+			return nil, errors.New("store unavailable")
+		},
+	)
+
+	interceptor := UnaryServerInterceptor(authn, nil)
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("code = %v, want Internal", code)
+	}
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signHMAC returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+func signHMAC(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewHMACAuthenticator(t *testing.T) {
+	auth := NewHMACAuthenticator(HMACConfig{Secret: []byte("s")})
+
+	if auth.Name() != "hmac" {
+		t.Errorf("Name() = %v, want hmac", auth.Name())
+	}
+	if auth.config.SignatureHeader != "X-Signature" {
+		t.Errorf("SignatureHeader = %v, want X-Signature", auth.config.SignatureHeader)
+	}
+	if auth.config.TimestampHeader != "X-Timestamp" {
+		t.Errorf("TimestampHeader = %v, want X-Timestamp", auth.config.TimestampHeader)
+	}
+	if auth.config.MaxSkew != 5*time.Minute {
+		t.Errorf("MaxSkew = %v, want 5m", auth.config.MaxSkew)
+	}
+	if auth.config.Encoding != HMACEncodingHex {
+		t.Errorf("Encoding = %v, want hex", auth.config.Encoding)
+	}
+}
+
+func TestHMACAuthenticator_Authenticate(t *testing.T) {
+	const secret = "shared-secret"
+	const body = `{"event":"created"}`
+	signedAt := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	signature := signHMAC(secret, timestamp, body)
+
+	auth := NewHMACAuthenticator(HMACConfig{
+		Secret: []byte(secret),
+		Roles:  []string{"integration"},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      string
+		now       time.Time
+		wantOK    bool
+		wantErr   error
+	}{
+		{"valid signature", signature, timestamp, body, signedAt.Add(time.Minute), true, nil},
+		{"tampered body", signature, timestamp, `{"event":"deleted"}`, signedAt, false, ErrInvalidCredentials},
+		{"timestamp altered", signature, "1700000001", body, signedAt, false, ErrInvalidCredentials},
+		{"expired timestamp", signature, timestamp, body, signedAt.Add(10 * time.Minute), false, ErrTokenExpired},
+		{"future timestamp", signature, timestamp, body, signedAt.Add(-10 * time.Minute), false, ErrTokenExpired},
+		{"missing timestamp", signature, "", body, signedAt, false, ErrMissingCredentials},
+		{"non-numeric timestamp", signature, "yesterday", body, signedAt, false, ErrTokenMalformed},
+		{"non-hex signature", "zz", timestamp, body, signedAt, false, ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth.now = func() time.Time { return tt.now }
+			req := &AuthRequest{
+				Headers: map[string][]string{
+					"X-Signature": {tt.signature},
+					"X-Timestamp": {tt.timestamp},
+				},
+				Body: []byte(tt.body),
+			}
+			if !auth.Supports(ctx, req) {
+				t.Fatal("Supports() = false, want true")
+			}
+
+			result, err := auth.Authenticate(ctx, req)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v (error %v)", result.Authenticated, tt.wantOK, result.Error)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+			if tt.wantOK {
+				if result.Identity.Principal != "hmac" {
+					t.Errorf("Principal = %v, want hmac", result.Identity.Principal)
+				}
+				if result.Identity.Method != AuthMethodHMAC {
+					t.Errorf("Method = %v, want %v", result.Identity.Method, AuthMethodHMAC)
+				}
+				if !result.Identity.HasRole("integration") {
+					t.Error("Identity should have integration role")
+				}
+			}
+		})
+	}
+}
+
+func TestHMACAuthenticator_CustomFormat(t *testing.T) {
+	// Slack-style: "v0=<hex>" over "v0:<timestamp>:<body>"
+	const secret = "slack-secret"
+	const body = "token=abc&command=/deploy"
+	signedAt := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	sum := mac.Sum(nil)
+
+	t.Run("prefix and payload", func(t *testing.T) {
+		auth := NewHMACAuthenticator(HMACConfig{
+			Secret:          []byte(secret),
+			SignatureHeader: "X-Slack-Signature",
+			TimestampHeader: "X-Slack-Request-Timestamp",
+			SignaturePrefix: "v0=",
+			Payload: func(timestamp string, body []byte) []byte {
+				return []byte("v0:" + timestamp + ":" + string(body))
+			},
+		})
+		auth.now = func() time.Time { return signedAt }
+
+		result, err := auth.Authenticate(context.Background(), &AuthRequest{
+			Headers: map[string][]string{
+				"X-Slack-Signature":         {"v0=" + hex.EncodeToString(sum)},
+				"X-Slack-Request-Timestamp": {timestamp},
+			},
+			Body: []byte(body),
+		})
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if !result.Authenticated {
+			t.Errorf("Authenticated = false, error = %v", result.Error)
+		}
+	})
+
+	t.Run("base64 encoding", func(t *testing.T) {
+		auth := NewHMACAuthenticator(HMACConfig{
+			Secret:   []byte(secret),
+			Encoding: HMACEncodingBase64,
+		})
+		auth.now = func() time.Time { return signedAt }
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "." + body))
+
+		result, err := auth.Authenticate(context.Background(), &AuthRequest{
+			Headers: map[string][]string{
+				"X-Signature": {base64.StdEncoding.EncodeToString(mac.Sum(nil))},
+				"X-Timestamp": {timestamp},
+			},
+			Body: []byte(body),
+		})
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if !result.Authenticated {
+			t.Errorf("Authenticated = false, error = %v", result.Error)
+		}
+	})
+}
+
+func TestHMACAuthenticator_MissingHeaderAndSecret(t *testing.T) {
+	ctx := context.Background()
+
+	auth := NewHMACAuthenticator(HMACConfig{Secret: []byte("s")})
+	req := &AuthRequest{Headers: map[string][]string{}}
+	if auth.Supports(ctx, req) {
+		t.Error("Supports() = true without signature header")
+	}
+	result, err := auth.Authenticate(ctx, req)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !errors.Is(result.Error, ErrMissingCredentials) {
+		t.Errorf("Error = %v, want ErrMissingCredentials", result.Error)
+	}
+
+	noSecret := NewHMACAuthenticator(HMACConfig{})
+	if _, err := noSecret.Authenticate(ctx, req); err == nil {
+		t.Error("Authenticate() without secret should return an internal error")
+	}
+}
+
+func TestHMACAuthenticator_UnknownAlgorithm(t *testing.T) {
+	auth := NewHMACAuthenticator(HMACConfig{Secret: []byte("s"), Algorithm: "md5"})
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req := &AuthRequest{Headers: map[string][]string{
+		"X-Signature": {signHMAC("s", timestamp, "")},
+		"X-Timestamp": {timestamp},
+	}}
+	if result, err := auth.Authenticate(context.Background(), req); err == nil {
+		t.Errorf("Authenticate() = %+v, want an internal error for an unknown algorithm", result)
+	}
+}
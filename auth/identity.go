@@ -13,6 +13,8 @@ const (
 	AuthMethodBasic     AuthMethod = "basic"
 	AuthMethodAnonymous AuthMethod = "anonymous"
 	AuthMethodComposite AuthMethod = "composite"
+	AuthMethodWebhook   AuthMethod = "webhook"
+	AuthMethodHMAC      AuthMethod = "hmac"
 )
 
 // Identity represents an authenticated principal.
@@ -25,6 +25,7 @@ type JWKSConfig struct {
 
 	// HTTPClient is the HTTP client to use for requests.
 	// If nil, a default client with 30s timeout is used.
+	// Use observe.NewHTTPClient to trace fetches as child spans.
 	HTTPClient *http.Client
 }
 
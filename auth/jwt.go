@@ -14,8 +14,15 @@ type JWTConfig struct {
 	Issuer string
 
 	// Audience is the expected token audience (aud claim).
+	// Kept for compatibility; it is treated as one more entry in Audiences.
 	Audience string
 
+	// Audiences are the accepted token audiences. A token passes if any of
+	// its aud values matches any accepted audience. The aud claim may be a
+	// string or an array of strings.
+	// Default: nil (audience is not checked unless Audience is set)
+	Audiences []string
+
 	// HeaderName is the header containing the token.
 	// Default: "Authorization"
 	HeaderName string
@@ -33,6 +40,11 @@ type JWTConfig struct {
 
 	// RolesClaim is the claim containing user roles.
 	RolesClaim string
+
+	// Revoker rejects otherwise valid tokens whose jti claim is revoked.
+	// Tokens without a jti claim cannot be revoked.
+	// Default: nil (no revocation check)
+	Revoker Revoker
 }
 
 // KeyProvider retrieves signing keys for JWT validation.
@@ -58,8 +70,9 @@ func (p *StaticKeyProvider) GetKey(_ context.Context, _ string) (any, error) {
 
 // JWTAuthenticator validates JWT tokens.
 type JWTAuthenticator struct {
-	config      JWTConfig
-	keyProvider KeyProvider
+	config           JWTConfig
+	keyProvider      KeyProvider
+	allowedAudiences map[string]struct{}
 }
 
 // NewJWTAuthenticator creates a new JWT authenticator.
@@ -75,9 +88,20 @@ func NewJWTAuthenticator(config JWTConfig, keyProvider KeyProvider) *JWTAuthenti
 		config.PrincipalClaim = "sub"
 	}
 
+	allowed := make(map[string]struct{}, len(config.Audiences)+1)
+	if config.Audience != "" {
+		allowed[config.Audience] = struct{}{}
+	}
+	for _, aud := range config.Audiences {
+		if aud != "" {
+			allowed[aud] = struct{}{}
+		}
+	}
+
 	return &JWTAuthenticator{
-		config:      config,
-		keyProvider: keyProvider,
+		config:           config,
+		keyProvider:      keyProvider,
+		allowedAudiences: allowed,
 	}
 }
 
@@ -142,13 +166,19 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req *AuthRequest) (
 	}
 
 	// Validate audience if configured
-	if a.config.Audience != "" {
-		aud := a.getAudience(claims)
-		if !a.containsAudience(aud, a.config.Audience) {
+	if len(a.allowedAudiences) > 0 {
+		if !a.matchesAudience(a.getAudience(claims)) {
 			return AuthFailure(ErrInvalidCredentials, "jwt"), nil
 		}
 	}
 
+	// Reject revoked tokens
+	if a.config.Revoker != nil {
+		if jti, ok := claims["jti"].(string); ok && jti != "" && a.config.Revoker.IsRevoked(ctx, jti) {
+			return AuthFailure(ErrTokenRevoked, "jwt"), nil
+		}
+	}
+
 	// Build identity
 	identity := a.buildIdentity(claims)
 
@@ -167,14 +197,17 @@ func (a *JWTAuthenticator) getAudience(claims jwt.MapClaims) []string {
 			}
 		}
 		return result
+	case []string:
+		return v
 	default:
 		return nil
 	}
 }
 
-func (a *JWTAuthenticator) containsAudience(audiences []string, target string) bool {
+// matchesAudience reports whether any token audience is allowed.
+func (a *JWTAuthenticator) matchesAudience(audiences []string) bool {
 	for _, aud := range audiences {
-		if aud == target {
+		if _, ok := a.allowedAudiences[aud]; ok {
 			return true
 		}
 	}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -235,6 +236,122 @@ func TestJWTAuthenticator_Authenticate(t *testing.T) {
 	})
 }
 
+func TestJWTAuthenticator_Audiences(t *testing.T) {
+	secret := []byte("test-secret-key-at-least-32-bytes")
+	keyProvider := NewStaticKeyProvider(secret)
+
+	tests := []struct {
+		name   string
+		config JWTConfig
+		aud    any
+		wantOK bool
+	}{
+		{
+			name:   "multiple token audiences match one allowed",
+			config: JWTConfig{Audiences: []string{"billing-api", "tools-api"}},
+			aud:    []string{"web-app", "tools-api"},
+			wantOK: true,
+		},
+		{
+			name:   "string audience matches one allowed",
+			config: JWTConfig{Audiences: []string{"billing-api", "tools-api"}},
+			aud:    "billing-api",
+			wantOK: true,
+		},
+		{
+			name:   "audiences do not intersect",
+			config: JWTConfig{Audiences: []string{"billing-api", "tools-api"}},
+			aud:    []string{"web-app", "mobile-app"},
+			wantOK: false,
+		},
+		{
+			name:   "legacy Audience combined with Audiences",
+			config: JWTConfig{Audience: "legacy-api", Audiences: []string{"tools-api"}},
+			aud:    []string{"legacy-api"},
+			wantOK: true,
+		},
+		{
+			name:   "missing aud claim",
+			config: JWTConfig{Audiences: []string{"tools-api"}},
+			aud:    nil,
+			wantOK: false,
+		},
+		{
+			name:   "no audience configured",
+			config: JWTConfig{},
+			aud:    []string{"anything"},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewJWTAuthenticator(tt.config, keyProvider)
+
+			claims := jwt.MapClaims{"sub": "user123", "exp": time.Now().Add(time.Hour).Unix()}
+			if tt.aud != nil {
+				claims["aud"] = tt.aud
+			}
+			tokenStr, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+
+			result, err := auth.Authenticate(context.Background(), &AuthRequest{
+				Headers: map[string][]string{"Authorization": {"Bearer " + tokenStr}},
+			})
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v (error %v)", result.Authenticated, tt.wantOK, result.Error)
+			}
+			if !tt.wantOK && !errors.Is(result.Error, ErrInvalidCredentials) {
+				t.Errorf("Error = %v, want ErrInvalidCredentials", result.Error)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_Revoker(t *testing.T) {
+	secret := []byte("test-secret-key-at-least-32-bytes")
+	revoker := NewMemoryRevoker()
+	auth := NewJWTAuthenticator(JWTConfig{Revoker: revoker}, NewStaticKeyProvider(secret))
+
+	exp := time.Now().Add(time.Hour)
+	revoker.Revoke("revoked-jti", exp)
+
+	tests := []struct {
+		name   string
+		jti    string
+		wantOK bool
+	}{
+		{"revoked jti", "revoked-jti", false},
+		{"non-revoked jti", "active-jti", true},
+		{"no jti", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{"sub": "user123", "exp": exp.Unix()}
+			if tt.jti != "" {
+				claims["jti"] = tt.jti
+			}
+			tokenStr, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+
+			result, err := auth.Authenticate(context.Background(), &AuthRequest{
+				Headers: map[string][]string{"Authorization": {"Bearer " + tokenStr}},
+			})
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v (error %v)", result.Authenticated, tt.wantOK, result.Error)
+			}
+			if !tt.wantOK && !errors.Is(result.Error, ErrTokenRevoked) {
+				t.Errorf("Error = %v, want ErrTokenRevoked", result.Error)
+			}
+		})
+	}
+}
+
 func TestStaticKeyProvider(t *testing.T) {
 	secret := []byte("my-secret")
 	provider := NewStaticKeyProvider(secret)
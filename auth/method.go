@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceTypeMethod is the AuthzRequest.ResourceType for RPC method paths.
+const ResourceTypeMethod = "method"
+
+// MethodRule maps an RPC method path pattern to its access requirements.
+type MethodRule struct {
+	// Pattern is a regular expression matched against the full method path
+	// (e.g., "/admin\\..*/.*" or "/Mutation/delete.*"). It is anchored at
+	// both ends.
+	Pattern string
+
+	// Roles permits the request if the subject has any of these roles.
+	Roles []string
+
+	// Permissions permits the request if the subject has any of these permissions.
+	Permissions []string
+
+	// Public permits the request without an authenticated subject. It is
+	// reported by IsPublicMethod, which is how UnaryServerInterceptor
+	// decides to admit calls without credentials.
+	Public bool
+}
+
+// MethodAuthorizerConfig configures the method authorizer.
+type MethodAuthorizerConfig struct {
+	// Rules are evaluated in order; the first matching rule decides.
+	Rules []MethodRule
+
+	// DefaultAllow permits authenticated subjects when no rule matches.
+	// Default: false (deny unmatched methods)
+	DefaultAllow bool
+}
+
+type compiledMethodRule struct {
+	rule MethodRule
+	re   *regexp.Regexp
+}
+
+// MethodAuthorizer authorizes requests by RPC method path, such as gRPC
+// full methods ("/pkg.Service/Method") or GraphQL fields ("/Query/user").
+type MethodAuthorizer struct {
+	rules        []compiledMethodRule
+	defaultAllow bool
+}
+
+// NewMethodAuthorizer creates a method authorizer.
+// Returns an error if any rule pattern is not a valid regular expression.
+func NewMethodAuthorizer(config MethodAuthorizerConfig) (*MethodAuthorizer, error) {
+	rules := make([]compiledMethodRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		re, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("auth: invalid method pattern %q: %w", rule.Pattern, err)
+		}
+		rules = append(rules, compiledMethodRule{rule: rule, re: re})
+	}
+
+	return &MethodAuthorizer{
+		rules:        rules,
+		defaultAllow: config.DefaultAllow,
+	}, nil
+}
+
+// Name returns "method".
+func (a *MethodAuthorizer) Name() string {
+	return "method"
+}
+
+// PublicMethodMatcher is implemented by authorizers that can mark a method
+// as callable without credentials. UnaryServerInterceptor admits a call
+// without credentials only when its authorizer implements this interface
+// and IsPublicMethod returns true; Authorize alone never admits one.
+type PublicMethodMatcher interface {
+	// IsPublicMethod reports whether fullMethod may be called without
+	// credentials.
+	IsPublicMethod(fullMethod string) bool
+}
+
+// IsPublicMethod reports whether the first rule matching fullMethod is
+// Public.
+func (a *MethodAuthorizer) IsPublicMethod(fullMethod string) bool {
+	for _, cr := range a.rules {
+		if cr.re.MatchString(fullMethod) {
+			return cr.rule.Public
+		}
+	}
+	return false
+}
+
+// Authorize checks the request's method path against the configured rules.
+func (a *MethodAuthorizer) Authorize(_ context.Context, req *AuthzRequest) error {
+	subject := ""
+	if req.Subject != nil {
+		subject = req.Subject.Principal
+	}
+
+	for _, cr := range a.rules {
+		if !cr.re.MatchString(req.Resource) {
+			continue
+		}
+
+		if cr.rule.Public {
+			return nil
+		}
+		if req.Subject == nil {
+			return &AuthzError{
+				Resource: req.Resource,
+				Action:   req.Action,
+				Reason:   "no identity provided",
+			}
+		}
+		if methodRulePermits(cr.rule, req.Subject) {
+			return nil
+		}
+		return &AuthzError{
+			Subject:  subject,
+			Resource: req.Resource,
+			Action:   req.Action,
+			Reason:   "method rule " + cr.rule.Pattern + " not satisfied",
+		}
+	}
+
+	if a.defaultAllow && req.Subject != nil {
+		return nil
+	}
+
+	return &AuthzError{
+		Subject:  subject,
+		Resource: req.Resource,
+		Action:   req.Action,
+		Reason:   "no rule matches method",
+	}
+}
+
+func methodRulePermits(rule MethodRule, subject *Identity) bool {
+	if len(rule.Roles) == 0 && len(rule.Permissions) == 0 {
+		return true
+	}
+	for _, role := range rule.Roles {
+		if subject.HasRole(role) {
+			return true
+		}
+	}
+	for _, perm := range rule.Permissions {
+		if subject.HasPermission(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// GRPCMethodRequest builds an AuthzRequest from a gRPC full method string
+// ("/package.Service/Method"). The action is the method name.
+func GRPCMethodRequest(subject *Identity, fullMethod string) *AuthzRequest {
+	action := fullMethod
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		action = fullMethod[i+1:]
+	}
+	return &AuthzRequest{
+		Subject:      subject,
+		Resource:     fullMethod,
+		Action:       action,
+		ResourceType: ResourceTypeMethod,
+	}
+}
+
+// GraphQLFieldRequest builds an AuthzRequest for a GraphQL field resolved on
+// the given parent type (e.g., "Query", "Mutation"). The resource path is
+// "/<parentType>/<field>", matching the gRPC method path shape.
+func GraphQLFieldRequest(subject *Identity, parentType, field string) *AuthzRequest {
+	return &AuthzRequest{
+		Subject:      subject,
+		Resource:     "/" + parentType + "/" + field,
+		Action:       field,
+		ResourceType: ResourceTypeMethod,
+	}
+}
+
+// Ensure MethodAuthorizer implements Authorizer
+var _ Authorizer = (*MethodAuthorizer)(nil)
+
+// Ensure MethodAuthorizer implements PublicMethodMatcher
+var _ PublicMethodMatcher = (*MethodAuthorizer)(nil)
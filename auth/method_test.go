@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMethodAuthorizer_Authorize(t *testing.T) {
+	authz, err := NewMethodAuthorizer(MethodAuthorizerConfig{
+		Rules: []MethodRule{
+			{Pattern: `/grpc\.health\.v1\.Health/.*`, Public: true},
+			{Pattern: `/admin\..*/.*`, Roles: []string{"admin"}},
+			{Pattern: `/Mutation/delete.*`, Permissions: []string{"data:delete"}},
+			{Pattern: `/tools\.v1\.Tools/.*`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMethodAuthorizer() error = %v", err)
+	}
+
+	admin := &Identity{Principal: "alice", Roles: []string{"admin"}}
+	user := &Identity{Principal: "bob", Roles: []string{"user"}}
+	deleter := &Identity{Principal: "carol", Permissions: []string{"data:delete"}}
+
+	tests := []struct {
+		name    string
+		req     *AuthzRequest
+		allowed bool
+	}{
+		{"public health without identity", GRPCMethodRequest(nil, "/grpc.health.v1.Health/Check"), true},
+		{"admin method with admin role", GRPCMethodRequest(admin, "/admin.v1.Users/Delete"), true},
+		{"admin method without admin role", GRPCMethodRequest(user, "/admin.v1.Users/Delete"), false},
+		{"admin method without identity", GRPCMethodRequest(nil, "/admin.v1.Users/Delete"), false},
+		{"graphql mutation with permission", GraphQLFieldRequest(deleter, "Mutation", "deleteUser"), true},
+		{"graphql mutation without permission", GraphQLFieldRequest(user, "Mutation", "deleteUser"), false},
+		{"authenticated-only rule", GRPCMethodRequest(user, "/tools.v1.Tools/Call"), true},
+		{"unmatched method denied by default", GRPCMethodRequest(admin, "/other.v1.Svc/Do"), false},
+		{"pattern is anchored", GRPCMethodRequest(user, "/x/admin.v1.Users/Delete"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authz.Authorize(context.Background(), tt.req)
+			if tt.allowed && err != nil {
+				t.Errorf("Authorize() error = %v, want nil", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrForbidden) {
+				t.Errorf("Authorize() error = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestMethodAuthorizer_DefaultAllow(t *testing.T) {
+	authz, err := NewMethodAuthorizer(MethodAuthorizerConfig{DefaultAllow: true})
+	if err != nil {
+		t.Fatalf("NewMethodAuthorizer() error = %v", err)
+	}
+
+	if err := authz.Authorize(context.Background(), GRPCMethodRequest(&Identity{Principal: "u"}, "/a.B/C")); err != nil {
+		t.Errorf("Authorize() error = %v, want nil", err)
+	}
+	if err := authz.Authorize(context.Background(), GRPCMethodRequest(nil, "/a.B/C")); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Authorize() without identity error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestNewMethodAuthorizer_InvalidPattern(t *testing.T) {
+	_, err := NewMethodAuthorizer(MethodAuthorizerConfig{
+		Rules: []MethodRule{{Pattern: "/admin(/"}},
+	})
+	if err == nil {
+		t.Error("NewMethodAuthorizer() expected error for invalid pattern")
+	}
+}
+
+func TestGRPCMethodRequest(t *testing.T) {
+	req := GRPCMethodRequest(nil, "/pkg.Service/Method")
+
+	if req.Resource != "/pkg.Service/Method" {
+		t.Errorf("Resource = %q, want full method", req.Resource)
+	}
+	if req.Action != "Method" {
+		t.Errorf("Action = %q, want Method", req.Action)
+	}
+	if req.ResourceType != ResourceTypeMethod {
+		t.Errorf("ResourceType = %q, want %q", req.ResourceType, ResourceTypeMethod)
+	}
+}
@@ -52,7 +52,14 @@ type OAuth2Config struct {
 	ScopesClaim string
 
 	// HTTPClient is the HTTP client to use. If nil, a default client is used.
+	// Use observe.NewHTTPClient to trace introspection as a child span.
 	HTTPClient *http.Client
+
+	// Revoker rejects active tokens whose introspected jti is revoked. It is
+	// also checked on cache hits, so revocation takes effect before
+	// CacheTTL elapses.
+	// Default: nil (no revocation check)
+	Revoker Revoker
 }
 
 // OAuth2IntrospectionAuthenticator validates OAuth2 tokens via introspection.
@@ -118,6 +125,9 @@ func (a *OAuth2IntrospectionAuthenticator) Authenticate(ctx context.Context, req
 	// Check cache first
 	tokenHash := hashTokenForCache(token)
 	if identity := a.cache.Get(tokenHash); identity != nil {
+		if a.revoked(ctx, identity) {
+			return AuthFailure(ErrTokenRevoked, "Bearer"), nil
+		}
 		return AuthSuccess(identity), nil
 	}
 
@@ -138,9 +148,22 @@ func (a *OAuth2IntrospectionAuthenticator) Authenticate(ctx context.Context, req
 	// Cache positive result
 	a.cache.Set(tokenHash, identity, a.config.CacheTTL)
 
+	if a.revoked(ctx, identity) {
+		return AuthFailure(ErrTokenRevoked, "Bearer"), nil
+	}
+
 	return AuthSuccess(identity), nil
 }
 
+// revoked reports whether the identity's jti claim is revoked.
+func (a *OAuth2IntrospectionAuthenticator) revoked(ctx context.Context, identity *Identity) bool {
+	if a.config.Revoker == nil {
+		return false
+	}
+	jti, ok := identity.Claims["jti"].(string)
+	return ok && jti != "" && a.config.Revoker.IsRevoked(ctx, jti)
+}
+
 // introspectionResult represents the OAuth2 introspection response.
 type introspectionResult struct {
 	Active   bool           `json:"active"`
@@ -3,10 +3,15 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/jonwraymond/toolops/observe"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewOAuth2IntrospectionAuthenticator(t *testing.T) {
@@ -292,6 +297,101 @@ func TestOAuth2IntrospectionAuthenticator_Authenticate(t *testing.T) {
 	})
 }
 
+func TestOAuth2IntrospectionAuthenticator_TracedHTTPClient(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": true, "sub": "user123"})
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	auth := NewOAuth2IntrospectionAuthenticator(OAuth2Config{
+		IntrospectionEndpoint: server.URL,
+		ClientID:              "client123",
+		ClientSecret:          "secret456",
+		HTTPClient:            &http.Client{Transport: observe.NewHTTPTransport(nil, tracer)},
+	})
+
+	ctx, parent := tracer.Start(context.Background(), "authenticate")
+	result, err := auth.Authenticate(ctx, &AuthRequest{
+		Headers: map[string][]string{"Authorization": {"Bearer test-token"}},
+	})
+	parent.End()
+	if err != nil || !result.Authenticated {
+		t.Fatalf("Authenticate() = %v, %v; want authenticated", result, err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	introspection := spans[0]
+	if introspection.Name() != "HTTP POST" {
+		t.Errorf("span name = %q, want \"HTTP POST\"", introspection.Name())
+	}
+	if introspection.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("introspection span should be a child of the authenticating span")
+	}
+	if traceparent == "" {
+		t.Error("introspection request should carry traceparent")
+	}
+}
+
+func TestOAuth2IntrospectionAuthenticator_Revoker(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"active": true,
+			"sub":    "user123",
+			"jti":    "jti-" + r.PostForm.Get("token"),
+		})
+	}))
+	defer server.Close()
+
+	revoker := NewMemoryRevoker()
+	auth := NewOAuth2IntrospectionAuthenticator(OAuth2Config{
+		IntrospectionEndpoint: server.URL,
+		Revoker:               revoker,
+	})
+	ctx := context.Background()
+	authenticate := func(token string) *AuthResult {
+		t.Helper()
+		result, err := auth.Authenticate(ctx, &AuthRequest{
+			Headers: map[string][]string{"Authorization": {"Bearer " + token}},
+		})
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		return result
+	}
+
+	revoker.Revoke("jti-revoked", time.Now().Add(time.Hour))
+
+	if result := authenticate("revoked"); result.Authenticated || !errors.Is(result.Error, ErrTokenRevoked) {
+		t.Errorf("revoked token: Authenticated = %v, Error = %v, want ErrTokenRevoked", result.Authenticated, result.Error)
+	}
+	if result := authenticate("active"); !result.Authenticated {
+		t.Errorf("non-revoked token: Authenticated = false, Error = %v", result.Error)
+	}
+
+	// Revocation applies to cached identities without re-introspecting
+	revoker.Revoke("jti-active", time.Now().Add(time.Hour))
+	before := calls
+	if result := authenticate("active"); result.Authenticated || !errors.Is(result.Error, ErrTokenRevoked) {
+		t.Errorf("cached revoked token: Authenticated = %v, Error = %v, want ErrTokenRevoked", result.Authenticated, result.Error)
+	}
+	if calls != before {
+		t.Errorf("introspection calls = %d, want cached result (%d)", calls, before)
+	}
+}
+
 func TestExtractBearerToken(t *testing.T) {
 	tests := []struct {
 		header    string
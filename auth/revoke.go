@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Revoker reports whether a token has been revoked, by its token ID (the
+// jti claim). JWTAuthenticator and OAuth2IntrospectionAuthenticator consult
+// it after a token validates, so a valid, unexpired token can still be
+// rejected after logout or compromise.
+//
+// Contract:
+//   - Concurrency: Implementations must be safe for concurrent use.
+//   - Errors: IsRevoked cannot fail; implementations backed by remote
+//     storage decide whether to fail open or closed.
+type Revoker interface {
+	// IsRevoked reports whether the token with ID jti is revoked.
+	IsRevoked(ctx context.Context, jti string) bool
+}
+
+// revokerSweepInterval is how often Revoke removes expired entries.
+const revokerSweepInterval = time.Minute
+
+// MemoryRevoker is an in-memory revocation list. Entries expire with the
+// token they revoke, so the list only holds tokens that would otherwise
+// still be valid.
+type MemoryRevoker struct {
+	mu        sync.Mutex
+	entries   map[string]time.Time // jti -> expiry (zero = never)
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRevoker creates a new in-memory revocation list.
+func NewMemoryRevoker() *MemoryRevoker {
+	return &MemoryRevoker{
+		entries: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Revoke revokes the token with ID jti until expiry, which should be the
+// token's own expiration (exp claim). A zero expiry keeps the entry until
+// Unrevoke.
+func (r *MemoryRevoker) Revoke(jti string, expiry time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastSweep) >= revokerSweepInterval {
+		r.sweepLocked(now)
+		r.lastSweep = now
+	}
+	r.entries[jti] = expiry
+}
+
+// Unrevoke removes jti from the revocation list.
+func (r *MemoryRevoker) Unrevoke(jti string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, jti)
+}
+
+// IsRevoked reports whether jti is revoked and its entry has not expired.
+func (r *MemoryRevoker) IsRevoked(_ context.Context, jti string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiry, ok := r.entries[jti]
+	if !ok {
+		return false
+	}
+	if !expiry.IsZero() && !r.now().Before(expiry) {
+		delete(r.entries, jti)
+		return false
+	}
+	return true
+}
+
+// Len returns the number of entries, including expired entries not yet
+// removed.
+func (r *MemoryRevoker) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// sweepLocked removes expired entries. Callers hold r.mu.
+func (r *MemoryRevoker) sweepLocked(now time.Time) {
+	for jti, expiry := range r.entries {
+		if !expiry.IsZero() && !now.Before(expiry) {
+			delete(r.entries, jti)
+		}
+	}
+}
+
+// Ensure MemoryRevoker implements Revoker
+var _ Revoker = (*MemoryRevoker)(nil)
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryRevoker_IsRevoked(t *testing.T) {
+	r := NewMemoryRevoker()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+
+	r.Revoke("jti-1", now.Add(time.Hour))
+	r.Revoke("jti-forever", time.Time{})
+
+	if !r.IsRevoked(ctx, "jti-1") {
+		t.Error("IsRevoked(jti-1) = false, want true")
+	}
+	if r.IsRevoked(ctx, "jti-2") {
+		t.Error("IsRevoked(jti-2) = true, want false")
+	}
+
+	// Entries expire with the token
+	now = now.Add(time.Hour)
+	if r.IsRevoked(ctx, "jti-1") {
+		t.Error("IsRevoked(jti-1) = true after expiry, want false")
+	}
+	if !r.IsRevoked(ctx, "jti-forever") {
+		t.Error("IsRevoked(jti-forever) = false, want true for zero expiry")
+	}
+	if r.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after expired entry is checked", r.Len())
+	}
+
+	r.Unrevoke("jti-forever")
+	if r.IsRevoked(ctx, "jti-forever") {
+		t.Error("IsRevoked(jti-forever) = true after Unrevoke, want false")
+	}
+}
+
+func TestMemoryRevoker_SweepsExpired(t *testing.T) {
+	r := NewMemoryRevoker()
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+
+	for _, jti := range []string{"a", "b", "c"} {
+		r.Revoke(jti, now.Add(time.Second))
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", r.Len())
+	}
+
+	// Expired entries are removed by a later Revoke without being checked
+	now = now.Add(revokerSweepInterval)
+	r.Revoke("d", now.Add(time.Hour))
+	if r.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after sweep", r.Len())
+	}
+}
+
+func TestMemoryRevoker_Concurrent(t *testing.T) {
+	r := NewMemoryRevoker()
+	ctx := context.Background()
+	expiry := time.Now().Add(time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jti := string(rune('a' + i))
+			r.Revoke(jti, expiry)
+			if !r.IsRevoked(ctx, jti) {
+				t.Errorf("IsRevoked(%s) = false, want true", jti)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// githubVector is the example from GitHub's webhook validation documentation.
+var githubVector = struct {
+	secret, body, signature string
+}{
+	secret:    "It's a Secret to Everybody",
+	body:      "Hello, World!",
+	signature: "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+}
+
+// stripeVector is an HMAC-SHA256 of "<t>.<body>" in Stripe's scheme.
+var stripeVector = struct {
+	secret, body, signature string
+	timestamp               int64
+}{
+	secret:    "whsec_test_secret",
+	body:      `{"id":"evt_test","object":"event"}`,
+	timestamp: 1492774577,
+	signature: "691252e266ce41cb94d709c84e9580d4172b117a510bbc81723f657d2cd5d215",
+}
+
+func newTestWebhook(t *testing.T, config WebhookConfig) *WebhookAuthenticator {
+	t.Helper()
+
+	auth, err := NewWebhookAuthenticator(config)
+	if err != nil {
+		t.Fatalf("NewWebhookAuthenticator() error = %v", err)
+	}
+	return auth
+}
+
+func TestNewWebhookAuthenticator(t *testing.T) {
+	auth := newTestWebhook(t, WebhookConfig{Secret: []byte("s")})
+
+	if auth.Name() != "webhook" {
+		t.Errorf("Name() = %v, want webhook", auth.Name())
+	}
+	if auth.config.SignatureHeader != "X-Hub-Signature-256" {
+		t.Errorf("SignatureHeader = %v, want X-Hub-Signature-256", auth.config.SignatureHeader)
+	}
+	if auth.config.Tolerance != 5*time.Minute {
+		t.Errorf("Tolerance = %v, want 5m", auth.config.Tolerance)
+	}
+
+	stripe := newTestWebhook(t, WebhookConfig{Secret: []byte("s"), Format: WebhookFormatStripe})
+	if stripe.config.SignatureHeader != "Stripe-Signature" {
+		t.Errorf("SignatureHeader = %v, want Stripe-Signature", stripe.config.SignatureHeader)
+	}
+}
+
+func TestNewWebhookAuthenticator_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config WebhookConfig
+	}{
+		{"unknown format", WebhookConfig{Secret: []byte("s"), Format: "gitlab"}},
+		{"unknown algorithm", WebhookConfig{Secret: []byte("s"), Algorithm: "md5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if auth, err := NewWebhookAuthenticator(tt.config); err == nil {
+				t.Errorf("NewWebhookAuthenticator() = %v, want error", auth)
+			}
+		})
+	}
+}
+
+func TestWebhookAuthenticator_GitHub(t *testing.T) {
+	auth := newTestWebhook(t, WebhookConfig{
+		Secret: []byte(githubVector.secret),
+		Roles:  []string{"webhook"},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		header  string
+		body    string
+		wantOK  bool
+		wantErr error
+	}{
+		{"valid signature", githubVector.signature, githubVector.body, true, nil},
+		{"tampered body", githubVector.signature, "Hello, World?", false, ErrInvalidCredentials},
+		{"wrong signature", "sha256=" + stripeVector.signature, githubVector.body, false, ErrInvalidCredentials},
+		{"non-hex signature", "sha256=zz", githubVector.body, false, ErrInvalidCredentials},
+		{"missing algorithm prefix", githubVector.signature[7:], githubVector.body, false, ErrTokenMalformed},
+		{"wrong algorithm prefix", "sha1=" + githubVector.signature[7:], githubVector.body, false, ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &AuthRequest{
+				Headers: map[string][]string{"X-Hub-Signature-256": {tt.header}},
+				Body:    []byte(tt.body),
+			}
+			if !auth.Supports(ctx, req) {
+				t.Fatal("Supports() = false, want true")
+			}
+
+			result, err := auth.Authenticate(ctx, req)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v", result.Authenticated, tt.wantOK)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+			if tt.wantOK {
+				if result.Identity.Principal != "webhook" {
+					t.Errorf("Principal = %v, want webhook", result.Identity.Principal)
+				}
+				if result.Identity.Method != AuthMethodWebhook {
+					t.Errorf("Method = %v, want %v", result.Identity.Method, AuthMethodWebhook)
+				}
+				if !result.Identity.HasRole("webhook") {
+					t.Error("Identity should have webhook role")
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookAuthenticator_Stripe(t *testing.T) {
+	auth := newTestWebhook(t, WebhookConfig{
+		Secret: []byte(stripeVector.secret),
+		Format: WebhookFormatStripe,
+	})
+	signedAt := time.Unix(stripeVector.timestamp, 0)
+	ctx := context.Background()
+
+	validHeader := "t=1492774577,v1=" + stripeVector.signature
+	tests := []struct {
+		name    string
+		header  string
+		now     time.Time
+		wantOK  bool
+		wantErr error
+	}{
+		{"valid signature", validHeader, signedAt.Add(time.Minute), true, nil},
+		{"rotated secret second v1", "t=1492774577,v1=" + githubVector.signature[7:] + ",v1=" + stripeVector.signature, signedAt, true, nil},
+		{"replay outside tolerance", validHeader, signedAt.Add(10 * time.Minute), false, ErrTokenExpired},
+		{"future timestamp outside tolerance", validHeader, signedAt.Add(-10 * time.Minute), false, ErrTokenExpired},
+		{"timestamp altered", "t=1492774578,v1=" + stripeVector.signature, signedAt, false, ErrInvalidCredentials},
+		{"missing timestamp", "v1=" + stripeVector.signature, signedAt, false, ErrTokenMalformed},
+		{"missing signature", "t=1492774577", signedAt, false, ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth.now = func() time.Time { return tt.now }
+			req := &AuthRequest{
+				Headers: map[string][]string{"Stripe-Signature": {tt.header}},
+				Body:    []byte(stripeVector.body),
+			}
+
+			result, err := auth.Authenticate(ctx, req)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if result.Authenticated != tt.wantOK {
+				t.Fatalf("Authenticated = %v, want %v (error %v)", result.Authenticated, tt.wantOK, result.Error)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookAuthenticator_BodyProvider(t *testing.T) {
+	auth := newTestWebhook(t, WebhookConfig{
+		Secret: []byte(githubVector.secret),
+		BodyProvider: func(req *AuthRequest) []byte {
+			return []byte(req.Metadata["raw_body"].(string))
+		},
+	})
+
+	result, err := auth.Authenticate(context.Background(), &AuthRequest{
+		Headers:  map[string][]string{"X-Hub-Signature-256": {githubVector.signature}},
+		Metadata: map[string]any{"raw_body": githubVector.body},
+	})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !result.Authenticated {
+		t.Errorf("Authenticated = false, error = %v", result.Error)
+	}
+}
+
+func TestWebhookAuthenticator_MissingHeaderAndSecret(t *testing.T) {
+	ctx := context.Background()
+
+	auth := newTestWebhook(t, WebhookConfig{Secret: []byte("s")})
+	req := &AuthRequest{Headers: map[string][]string{}}
+	if auth.Supports(ctx, req) {
+		t.Error("Supports() = true without signature header")
+	}
+	result, err := auth.Authenticate(ctx, req)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if !errors.Is(result.Error, ErrMissingCredentials) {
+		t.Errorf("Error = %v, want ErrMissingCredentials", result.Error)
+	}
+
+	noSecret := newTestWebhook(t, WebhookConfig{})
+	if _, err := noSecret.Authenticate(ctx, req); err == nil {
+		t.Error("Authenticate() without secret should return an internal error")
+	}
+}
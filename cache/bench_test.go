@@ -47,6 +47,20 @@ func BenchmarkMemoryCache_Set(b *testing.B) {
 	}
 }
 
+// BenchmarkMemoryCache_Set_LRUEvicting measures insert cost when every Set evicts.
+func BenchmarkMemoryCache_Set_LRUEvicting(b *testing.B) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 1000
+	c := NewMemoryCache(policy)
+	ctx := context.Background()
+	value := []byte("test value")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Set(ctx, fmt.Sprintf("key-%d", i), value, time.Hour)
+	}
+}
+
 // BenchmarkMemoryCache_Set_SameKey measures overwrite performance.
 func BenchmarkMemoryCache_Set_SameKey(b *testing.B) {
 	policy := DefaultPolicy()
@@ -15,6 +15,22 @@ var (
 	ErrNilCache   = errors.New("cache: cache is nil")
 	ErrInvalidKey = errors.New("cache: key is invalid")
 	ErrKeyTooLong = errors.New("cache: key exceeds max length")
+
+	// ErrInvalidationUnsupported indicates the cache cannot invalidate by
+	// tool (it does not implement ToolInvalidator).
+	ErrInvalidationUnsupported = errors.New("cache: tool invalidation not supported")
+
+	// ErrBreakerOpen indicates a TieredCache breaker rejected an L2 call
+	// without running it. The breaker's own error is wrapped as well.
+	ErrBreakerOpen = errors.New("cache: breaker is open")
+
+	// ErrUnsupportedInput indicates a keyer input cannot be canonicalized
+	// (e.g., channels, functions, NaN or infinite floats).
+	ErrUnsupportedInput = errors.New("cache: input is not serializable")
+
+	// ErrTenantRequired indicates a TenantAwareKeyer was asked for a key
+	// without a way to resolve the tenant.
+	ErrTenantRequired = errors.New("cache: tenant required")
 )
 
 // Cache is the interface for caching tool execution results.
@@ -32,6 +48,9 @@ type Cache interface {
 
 	// Delete removes a cached value. Idempotent - no error on miss.
 	Delete(ctx context.Context, key string) error
+
+	// Clear removes all cached values.
+	Clear(ctx context.Context) error
 }
 
 // ValidateKey checks if a key is valid for caching.
@@ -48,3 +67,33 @@ func ValidateKey(key string) error {
 	}
 	return nil
 }
+
+// RemoteCache is implemented by cache backends that can report backend
+// failures on reads, distinguishing an outage from a miss. TieredCache uses
+// Fetch, when available, so read failures count against its breaker.
+type RemoteCache interface {
+	Cache
+
+	// Fetch retrieves a cached value. A miss returns (nil, false, nil);
+	// a backend failure returns a non-nil error.
+	Fetch(ctx context.Context, key string) ([]byte, bool, error)
+}
+
+// TTLCache is implemented by caches that can report how long a value has
+// left before it expires. TieredCache uses it so values promoted from L2
+// never outlive their L2 entry in L1.
+type TTLCache interface {
+	Cache
+
+	// GetWithTTL retrieves a value and its remaining TTL.
+	// A miss returns (nil, 0, false).
+	GetWithTTL(ctx context.Context, key string) ([]byte, time.Duration, bool)
+}
+
+// ToolInvalidator is implemented by caches that can remove every entry for
+// a tool, assuming DefaultKeyer's "cache:<toolID>:<hash>" key format.
+type ToolInvalidator interface {
+	// InvalidateByTool removes all entries whose key begins with
+	// ToolKeyPrefix(toolID) and returns how many were removed.
+	InvalidateByTool(ctx context.Context, toolID string) int
+}
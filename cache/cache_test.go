@@ -61,6 +61,10 @@ func (m *mockCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *mockCache) Clear(ctx context.Context) error {
+	return nil
+}
+
 // TestSentinelErrors verifies sentinel errors are distinct and have expected messages.
 func TestSentinelErrors(t *testing.T) {
 	tests := []struct {
@@ -71,6 +75,8 @@ func TestSentinelErrors(t *testing.T) {
 		{"ErrNilCache", ErrNilCache, "cache: cache is nil"},
 		{"ErrInvalidKey", ErrInvalidKey, "cache: key is invalid"},
 		{"ErrKeyTooLong", ErrKeyTooLong, "cache: key exceeds max length"},
+		{"ErrInvalidationUnsupported", ErrInvalidationUnsupported, "cache: tool invalidation not supported"},
+		{"ErrTenantRequired", ErrTenantRequired, "cache: tenant required"},
 	}
 
 	for _, tt := range tests {
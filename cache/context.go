@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Context keys for cache-related values.
+type contextKey int
+
+const (
+	ttlKey contextKey = iota
+	bypassKey
+)
+
+// WithTTL returns a new context that overrides the cache TTL for calls made
+// with it. CacheMiddleware uses the override in place of the policy's
+// default TTL, still clamped to Policy.MaxTTL. A non-positive ttl is ignored.
+func WithTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, ttlKey, ttl)
+}
+
+// TTLFromContext retrieves the TTL override from the context.
+// Returns false if no positive override is present.
+func TTLFromContext(ctx context.Context) (time.Duration, bool) {
+	ttl, ok := ctx.Value(ttlKey).(time.Duration)
+	if !ok || ttl <= 0 {
+		return 0, false
+	}
+	return ttl, true
+}
+
+// WithBypass returns a new context that makes CacheMiddleware skip the
+// cache lookup and run the executor, while still storing the fresh result
+// for later callers. Use it when the caller knows the cached value is stale,
+// e.g. after a write earlier in the same request. Executor errors are
+// returned as is, even with WithServeStaleOnError.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey, true)
+}
+
+// BypassFromContext reports whether the context requests a cache bypass.
+func BypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey).(bool)
+	return bypass
+}
@@ -29,10 +29,12 @@
 //
 // # Core Components
 //
-//   - [Cache]: Interface for caching tool execution results (Get/Set/Delete)
-//   - [MemoryCache]: Thread-safe in-memory cache with TTL support
+//   - [Cache]: Interface for caching tool execution results (Get/Set/Delete/Clear)
+//   - [MemoryCache]: Thread-safe in-memory cache with TTL and optional LRU bound
+//   - [TieredCache]: Node-local L1 over a shared L2 with bounded staleness
 //   - [Keyer]: Interface for deterministic cache key generation
 //   - [DefaultKeyer]: SHA-256 based keyer with canonical JSON serialization
+//   - [TenantAwareKeyer]: DefaultKeyer variant that scopes keys per tenant
 //   - [Policy]: Configures TTL defaults, maximums, and unsafe tag handling
 //   - [CacheMiddleware]: Transparent caching wrapper for tool execution
 //
@@ -60,20 +62,171 @@
 //
 // Where hash is the first 16 hex characters of SHA-256(canonical JSON(input)).
 // Canonical JSON ensures map keys are sorted for deterministic serialization.
+// Numbers are normalized (1 and 1.0 produce the same key), nil values encode
+// as null, and structs are canonicalized via their JSON encoding, so a struct
+// and a map with the same JSON fields share a key. Inputs that cannot be
+// encoded (channels, functions, NaN) return [ErrUnsupportedInput].
+//
+// Sixteen hex characters (64 bits) keep collisions negligible for most
+// tools. For extremely high-cardinality tools, widen the hash up to the
+// full 64 characters with [WithHashWidth]:
+//
+//	keyer := cache.NewDefaultKeyer(cache.WithHashWidth(cache.MaxHashWidth))
+//
+// For inputs that do not round-trip through encoding/json, such as types
+// whose MarshalJSON drops fields, plug in a [CanonicalSerializer] with
+// [NewDefaultKeyerWithSerializer]. The serializer must be deterministic:
+// equal inputs must produce identical bytes in every process, or identical
+// calls will miss the cache.
+//
+// To drop a single cached result, call [CacheMiddleware.DeleteByInput] with
+// the same tool ID and input as the read; the key is derived for you.
+// To drop every cached result for a tool after its data changes, call
+// [CacheMiddleware.InvalidateTool], which relies on this key format and a
+// cache implementing [ToolInvalidator] such as [MemoryCache]. The
+// MemoryCache scan is O(n) in the number of entries. A tool ID that is a
+// prefix of another followed by ":" (e.g., "repo" and "repo:sub") also
+// matches the longer ID's keys.
+//
+// # Multi-Tenant Keys
+//
+// DefaultKeyer ignores who is calling, so two tenants making the same call
+// share an entry. When tool output depends on the caller, use a
+// [TenantAwareKeyer], which folds the tenant ID into the hash:
+//
+//	keyer := cache.NewTenantAwareKeyer(auth.TenantIDFromContext)
+//	mw := cache.NewCacheMiddleware(memCache, keyer, policy, nil)
+//
+// CacheMiddleware passes the request context to any [ContextKeyer]. Calls
+// with no tenant share the DefaultKeyer key. Key, which has no context,
+// returns [ErrTenantRequired]; use KeyContext or KeyForTenant directly.
 //
 // # TTL Policies
 //
 // The [Policy] type controls caching behavior:
 //
 //   - DefaultTTL: Applied when no specific TTL is provided
+//   - PerTool: Exact-match tool ID overrides of DefaultTTL
 //   - MaxTTL: Upper bound for any TTL (prevents excessive caching)
+//   - JitterPct: Random ±spread on TTLs to avoid synchronized expiry
 //   - AllowUnsafe: Whether to cache tools with unsafe tags
+//   - MaxEntries: LRU bound for [MemoryCache] (monitor with [MemoryCache.Len])
+//   - StaleTTL: How long results are retained past expiry for stale serving
+//   - StaleWhileRevalidate: Window for serving expired results while refreshing
+//
+// A single call can override the default and per-tool TTL with [WithTTL];
+// the override is still clamped to MaxTTL:
+//
+//	ctx = cache.WithTTL(ctx, 10*time.Second) // volatile result
+//	result, err := mw.Execute(ctx, toolID, input, tags, exec)
 //
 // Preset policies:
 //
 //   - [DefaultPolicy]: 5 minute default, 1 hour max, unsafe=false
 //   - [NoCachePolicy]: Disabled (0 TTL)
 //
+// Unlike NoCachePolicy, [WithBypass] applies to a single call: it skips the
+// lookup and runs the executor, but still stores the fresh result:
+//
+//	ctx = cache.WithBypass(ctx) // just wrote to the repo
+//	result, err := mw.Execute(ctx, "github.get_repo", input, tags, exec)
+//
+// # Stampede Protection
+//
+// When many callers miss the same key at once (a popular entry expiring),
+// [CacheMiddleware] runs the executor once per key and shares the result or
+// error with every waiting caller. The shared call runs with the first
+// caller's context, so its cancellation fails the whole group.
+//
+// # Stale While Revalidate
+//
+// With a positive Policy.StaleWhileRevalidate, a result that expired within
+// that window is returned immediately and refreshed by a background executor
+// call, so callers of expensive read tools never wait on a cold refresh.
+// The refresh runs detached from the caller's cancellation, is shared by
+// concurrent callers, and keeps the stale value if the executor fails.
+//
+// # Serving Stale on Error
+//
+// With a positive Policy.StaleTTL and [WithServeStaleOnError], a failed
+// executor call returns the last good result if it expired less than
+// StaleTTL ago, and reports [EventServedStaleOnError] to the
+// [WithEventHook] callback. The caller gets no error, so only enable this
+// for reads where slightly old data beats no data. Results excluded by a
+// skip rule or by [WithShouldCacheResult] are never stored, so they have no
+// stale copy to fall back on.
+//
+// # Negative Caching
+//
+// Errors are not cached by default. For failures that recur deterministically,
+// such as "not found", set Policy.NegativeTTL and accept them with
+// [WithCacheableError]; repeated calls then return a [CachedError] with the
+// original message until NegativeTTL passes:
+//
+//	policy.NegativeTTL = 30 * time.Second
+//	mw := cache.NewCacheMiddleware(memCache, keyer, policy, nil,
+//	    cache.WithCacheableError(func(_ string, err error) bool {
+//	        return errors.Is(err, ErrNotFound)
+//	    }))
+//
+// Serving stale on error takes precedence: when a stale value is available,
+// it is returned and the error is not cached.
+//
+// # Expiry
+//
+// A [MemoryCache] removes an expired entry when it is next read. Entries
+// that are never read again linger until then; set Policy.SweepInterval to
+// remove them with a background sweeper, and Close the cache when done:
+//
+//	policy.SweepInterval = time.Minute
+//	memCache := cache.NewMemoryCache(policy)
+//	defer memCache.Close()
+//
+// [MemoryCache.GetWithTTL] also returns how long a value has left, for
+// callers that refresh results nearing expiry.
+//
+// # Compression
+//
+// Set Policy.CompressThreshold to have a [MemoryCache] gzip values larger
+// than that many bytes. Get returns the original bytes, so compression is
+// invisible through the [Cache] interface. Values that do not shrink are
+// stored as-is.
+//
+// # Statistics
+//
+// [MemoryCache.Stats] reports hits, misses, LRU evictions, sets, bytes
+// before and after compression, and the current entry count; [CacheMiddleware.Stats] reports hits and misses
+// across every tool a middleware wraps. Counters are atomic and can be
+// zeroed with ResetStats, e.g. after each scrape:
+//
+//	stats := mw.Stats()
+//	log.Printf("cache hit rate: %.1f%%", stats.HitRate()*100)
+//
+// For per-tool metrics, [WithHooks] calls OnHit and OnMiss with the tool
+// ID as each lookup resolves:
+//
+//	mw := cache.NewCacheMiddleware(memCache, keyer, policy, nil,
+//	    cache.WithHooks(cache.Hooks{
+//	        OnHit:  func(toolID string) { hits.WithLabelValues(toolID).Inc() },
+//	        OnMiss: func(toolID string) { misses.WithLabelValues(toolID).Inc() },
+//	    }))
+//
+// # Tiered Consistency
+//
+// A [TieredCache] writes through to its shared L2 and node-local L1. Other
+// nodes may keep serving an older value from their own L1, but only for
+// TieredCacheConfig.MaxStaleness. For tighter bounds, wire OnInvalidate to a
+// broadcast transport (e.g., Redis pub/sub) and call [TieredCache.Invalidate]
+// on peers as messages arrive. The transport is left to the consumer.
+//
+// [WithBreaker] guards L2 with a [Breaker] (such as
+// resilience.CircuitBreaker). While it is open, L2 reads are misses and L2
+// calls are skipped, so an outage of the shared cache adds no latency.
+// Writes and deletes still apply to L1 but return [ErrBreakerOpen], since
+// L2 and peers were not updated. Backends implementing [RemoteCache] report
+// read failures to the breaker. [TieredCache.BreakerOpen] exposes the
+// breaker state for health checks.
+//
 // # Unsafe Tag Handling
 //
 // Tools with certain tags should not be cached because they have side effects:
@@ -83,13 +236,18 @@
 // The [DefaultSkipRule] checks for these tags (case-insensitive) and skips
 // caching. Override via [NewCacheMiddleware]'s skipRule parameter.
 //
+// Skip rules decide before execution. To decline caching based on the
+// result itself, such as a success envelope reporting {"ok": false}, pass
+// [WithShouldCacheResult] to [NewCacheMiddleware].
+//
 // # Thread Safety
 //
 // All exported types are safe for concurrent use:
 //
-//   - [MemoryCache]: sync.RWMutex protects all operations
+//   - [MemoryCache]: sync.RWMutex protects all operations; stats are atomic
+//   - [TieredCache]: Concurrent-safe when its L1 and L2 are
 //   - [DefaultKeyer]: Stateless, concurrent-safe
-//   - [CacheMiddleware]: Delegates to thread-safe Cache/Keyer
+//   - [CacheMiddleware]: Delegates to thread-safe Cache/Keyer; stats are atomic
 //   - [Policy]: Immutable struct, concurrent-safe
 //
 // # Error Handling
@@ -97,8 +255,12 @@
 // Sentinel errors (use errors.Is for checking):
 //
 //   - [ErrNilCache]: Cache is nil
+//   - [ErrBreakerOpen]: TieredCache breaker skipped the L2 call
+//   - [ErrInvalidationUnsupported]: Cache or keyer cannot invalidate by tool
 //   - [ErrInvalidKey]: Key is empty, whitespace-only, or contains newlines
 //   - [ErrKeyTooLong]: Key exceeds MaxKeyLength (512 characters)
+//   - [ErrUnsupportedInput]: Keyer input cannot be canonicalized
+//   - [ErrTenantRequired]: TenantAwareKeyer.Key was called without a tenant
 //
 // Note: Cache.Get never returns errors - it returns (nil, false) on miss.
 // Key validation is performed via [ValidateKey] function.
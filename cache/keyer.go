@@ -1,11 +1,15 @@
 package cache
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 )
 
 // Keyer generates deterministic cache keys from tool execution parameters.
@@ -18,50 +22,301 @@ type Keyer interface {
 	Key(toolID string, input any) (string, error)
 }
 
+// CanonicalSerializer converts tool input into the bytes a keyer hashes.
+//
+// Contract:
+//   - Determinism: equal inputs must serialize to identical bytes across
+//     calls, processes, and restarts, e.g. independent of map iteration order
+//     or pointer addresses. Otherwise identical calls miss the cache.
+//   - Distinctness: inputs that should not share a cache entry must
+//     serialize differently, including fields a MarshalJSON method omits.
+//   - Concurrency: implementations must be safe for concurrent use.
+type CanonicalSerializer interface {
+	// Serialize returns the canonical encoding of input.
+	Serialize(input any) ([]byte, error)
+}
+
+// SerializerFunc adapts a function to the CanonicalSerializer interface.
+type SerializerFunc func(input any) ([]byte, error)
+
+// Serialize calls f(input).
+func (f SerializerFunc) Serialize(input any) ([]byte, error) {
+	return f(input)
+}
+
+// Hash widths, in hex characters, for DefaultKeyer keys.
+const (
+	// DefaultHashWidth is the hash width used unless WithHashWidth is set.
+	DefaultHashWidth = 16
+	// MinHashWidth is the narrowest allowed hash (32 bits).
+	MinHashWidth = 8
+	// MaxHashWidth is the full SHA-256 hash.
+	MaxHashWidth = 64
+)
+
 // DefaultKeyer generates SHA-256 based cache keys.
-type DefaultKeyer struct{}
+type DefaultKeyer struct {
+	serializer CanonicalSerializer // nil means canonical JSON
+	hashWidth  int                 // 0 means DefaultHashWidth
+}
+
+// KeyerOption configures a DefaultKeyer or TenantAwareKeyer.
+type KeyerOption func(*DefaultKeyer)
+
+// WithHashWidth sets how many hex characters of the SHA-256 hash keys
+// keep. Wider keys make collisions less likely for very high-cardinality
+// tools at the cost of key size. Widths outside MinHashWidth..MaxHashWidth
+// are clamped to the nearest bound.
+// Default: DefaultHashWidth (16, i.e. 64 bits)
+func WithHashWidth(width int) KeyerOption {
+	return func(k *DefaultKeyer) {
+		k.hashWidth = min(max(width, MinHashWidth), MaxHashWidth)
+	}
+}
+
+// WithSerializer hashes the output of serializer instead of canonical JSON,
+// for inputs that do not round-trip through encoding/json. The key format
+// is unchanged. A nil serializer means canonical JSON.
+func WithSerializer(serializer CanonicalSerializer) KeyerOption {
+	return func(k *DefaultKeyer) {
+		k.serializer = serializer
+	}
+}
 
 // NewDefaultKeyer creates a new default keyer.
-func NewDefaultKeyer() *DefaultKeyer {
-	return &DefaultKeyer{}
+func NewDefaultKeyer(opts ...KeyerOption) *DefaultKeyer {
+	k := &DefaultKeyer{}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
+// NewDefaultKeyerWithSerializer creates a keyer that hashes the output of
+// serializer instead of canonical JSON. It is shorthand for
+// NewDefaultKeyer with WithSerializer.
+func NewDefaultKeyerWithSerializer(serializer CanonicalSerializer, opts ...KeyerOption) *DefaultKeyer {
+	return NewDefaultKeyer(append(opts, WithSerializer(serializer))...)
 }
 
 // Key generates a deterministic cache key.
 // Format: cache:<toolID>:<hash>
-// where hash is the first 16 characters of SHA-256(canonical JSON(input))
+// where hash is the first 16 characters (or the configured hash width) of
+// SHA-256(canonical JSON(input)), or of the custom serializer's output.
 func (k *DefaultKeyer) Key(toolID string, input any) (string, error) {
+	return hashKey(k.serializer, k.hashWidth, "", toolID, input)
+}
+
+// ContextKeyer is a Keyer that can also derive keys from the request
+// context. CacheMiddleware calls KeyContext instead of Key when the
+// configured keyer implements it.
+type ContextKeyer interface {
+	Keyer
+
+	// KeyContext generates a cache key from the context, tool ID, and input.
+	KeyContext(ctx context.Context, toolID string, input any) (string, error)
+}
+
+// TenantFunc extracts the tenant ID from a request context.
+// auth.TenantIDFromContext has this signature.
+type TenantFunc func(ctx context.Context) string
+
+// TenantAwareKeyer generates keys like DefaultKeyer but folds the caller's
+// tenant ID into the hash, so identical calls from different tenants never
+// share an entry. Use it when tool output depends on caller identity.
+//
+// Keys keep the cache:<toolID>:<hash> format, so tool-scoped invalidation
+// covers every tenant. An empty tenant produces the same key as a
+// DefaultKeyer with the same options.
+type TenantAwareKeyer struct {
+	tenant TenantFunc
+	base   DefaultKeyer // serializer and hash width
+}
+
+// NewTenantAwareKeyer creates a keyer that reads the tenant with tenant,
+// typically auth.TenantIDFromContext. A nil tenant func always yields an
+// empty tenant. Options are those of NewDefaultKeyer.
+func NewTenantAwareKeyer(tenant TenantFunc, opts ...KeyerOption) *TenantAwareKeyer {
+	if tenant == nil {
+		tenant = func(context.Context) string { return "" }
+	}
+	k := &TenantAwareKeyer{tenant: tenant}
+	for _, opt := range opts {
+		opt(&k.base)
+	}
+	return k
+}
+
+// Key returns ErrTenantRequired: without a context there is no tenant to
+// scope the key to, and an unscoped key could leak results across tenants.
+// Use KeyContext or KeyForTenant.
+func (k *TenantAwareKeyer) Key(_ string, _ any) (string, error) {
+	return "", ErrTenantRequired
+}
+
+// KeyContext generates a key scoped to the tenant extracted from ctx.
+func (k *TenantAwareKeyer) KeyContext(ctx context.Context, toolID string, input any) (string, error) {
+	return k.KeyForTenant(k.tenant(ctx), toolID, input)
+}
+
+// KeyForTenant generates a key scoped to an explicit tenant ID.
+func (k *TenantAwareKeyer) KeyForTenant(tenant, toolID string, input any) (string, error) {
+	return hashKey(k.base.serializer, k.base.hashWidth, tenant, toolID, input)
+}
+
+// hashKey builds cache:<toolID>:<hash>, where hash is the first width hex
+// characters of SHA-256 over the serialized input, prefixed by the
+// length-delimited tenant when one is set. A nil serializer means
+// canonical JSON; a zero width means DefaultHashWidth.
+func hashKey(serializer CanonicalSerializer, width int, tenant, toolID string, input any) (string, error) {
 	// Canonicalize input to ensure deterministic serialization
-	canonical, err := canonicalize(input)
+	var canonical []byte
+	var err error
+	if serializer != nil {
+		canonical, err = serializer.Serialize(input)
+	} else {
+		canonical, err = canonicalize(input)
+	}
 	if err != nil {
 		return "", fmt.Errorf("cache: failed to canonicalize input: %w", err)
 	}
 
 	// Hash the canonical representation
-	hash := sha256.Sum256(canonical)
-	hashStr := hex.EncodeToString(hash[:8]) // First 8 bytes = 16 hex chars
+	h := sha256.New()
+	if tenant != "" {
+		// Length prefix keeps tenant and input unambiguous
+		h.Write([]byte("tenant:" + strconv.Itoa(len(tenant)) + ":" + tenant + ":"))
+	}
+	h.Write(canonical)
+	if width == 0 {
+		width = DefaultHashWidth
+	}
+	hashStr := hex.EncodeToString(h.Sum(nil))[:width]
 
-	return fmt.Sprintf("cache:%s:%s", toolID, hashStr), nil
+	return ToolKeyPrefix(toolID) + hashStr, nil
+}
+
+// ToolKeyPrefix returns the prefix shared by every DefaultKeyer key for
+// toolID: "cache:<toolID>:".
+func ToolKeyPrefix(toolID string) string {
+	return "cache:" + toolID + ":"
 }
 
 // canonicalize produces a deterministic JSON representation of the input.
-// Maps are sorted by key to ensure consistent ordering.
+//
+// Canonicalization rules:
+//   - nil (including typed nil maps, slices, and pointers) encodes as null.
+//   - Map keys are sorted; array order is preserved.
+//   - Numbers are normalized so integers and integral floats (1 vs 1.0)
+//     encode identically; other floats use the shortest round-trip form.
+//   - Structs and other types are canonicalized via their encoding/json
+//     representation, so a struct and a map with the same JSON keys and
+//     values produce the same output (json tags and MarshalJSON are honored).
+//   - Values encoding/json cannot represent (channels, functions, NaN,
+//     ±Inf) return an error wrapping ErrUnsupportedInput.
 func canonicalize(v any) ([]byte, error) {
-	if v == nil {
-		return []byte("null"), nil
-	}
-
-	// For maps, sort keys for determinism
 	switch val := v.(type) {
+	case nil:
+		return []byte("null"), nil
 	case map[string]any:
+		if val == nil {
+			return []byte("null"), nil
+		}
 		return canonicalizeMap(val)
 	case []any:
+		if val == nil {
+			return []byte("null"), nil
+		}
 		return canonicalizeSlice(val)
+	case string, bool:
+		return json.Marshal(val)
+	case json.Number:
+		return canonicalizeNumber(val)
+	case float64:
+		return canonicalizeFloat(val)
+	case float32:
+		return canonicalizeFloat(float64(val))
+	case int:
+		return strconv.AppendInt(nil, int64(val), 10), nil
+	case int8:
+		return strconv.AppendInt(nil, int64(val), 10), nil
+	case int16:
+		return strconv.AppendInt(nil, int64(val), 10), nil
+	case int32:
+		return strconv.AppendInt(nil, int64(val), 10), nil
+	case int64:
+		return strconv.AppendInt(nil, val, 10), nil
+	case uint:
+		return strconv.AppendUint(nil, uint64(val), 10), nil
+	case uint8:
+		return strconv.AppendUint(nil, uint64(val), 10), nil
+	case uint16:
+		return strconv.AppendUint(nil, uint64(val), 10), nil
+	case uint32:
+		return strconv.AppendUint(nil, uint64(val), 10), nil
+	case uint64:
+		return strconv.AppendUint(nil, val, 10), nil
 	default:
-		// For other types, use standard JSON encoding
-		return json.Marshal(v)
+		// Round-trip through encoding/json to reach the generic form
+		// (map[string]any, []any, json.Number, string, bool, nil).
+		generic, err := toGeneric(v)
+		if err != nil {
+			return nil, err
+		}
+		return canonicalize(generic)
 	}
 }
 
+// toGeneric converts any JSON-serializable value into its generic decoded form.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedInput, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedInput, err)
+	}
+	return generic, nil
+}
+
+// canonicalizeNumber normalizes a JSON number literal.
+func canonicalizeNumber(n json.Number) ([]byte, error) {
+	if i, err := n.Int64(); err == nil {
+		return strconv.AppendInt(nil, i, 10), nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return strconv.AppendUint(nil, u, 10), nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid number %q", ErrUnsupportedInput, string(n))
+	}
+	return canonicalizeFloat(f)
+}
+
+// canonicalizeFloat renders integral floats as integers and other floats in
+// their shortest round-trip form. Integral floats in [2^63, 2^64) go through
+// uint64 so they match the same value passed as a uint64.
+func canonicalizeFloat(f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("%w: unsupported float value %v", ErrUnsupportedInput, f)
+	}
+	if f == math.Trunc(f) {
+		if math.Abs(f) < 1<<63 {
+			return strconv.AppendInt(nil, int64(f), 10), nil
+		}
+		if f > 0 && f < 1<<64 {
+			return strconv.AppendUint(nil, uint64(f), 10), nil
+		}
+	}
+	return strconv.AppendFloat(nil, f, 'g', -1, 64), nil
+}
+
 func canonicalizeMap(m map[string]any) ([]byte, error) {
 	// Sort keys
 	keys := make([]string, 0, len(m))
@@ -117,3 +372,6 @@ func canonicalizeSlice(s []any) ([]byte, error) {
 
 // Ensure DefaultKeyer implements Keyer
 var _ Keyer = (*DefaultKeyer)(nil)
+
+// Ensure TenantAwareKeyer implements ContextKeyer
+var _ ContextKeyer = (*TenantAwareKeyer)(nil)
@@ -1,8 +1,17 @@
 package cache
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolops/auth"
 )
 
 func TestKeyer_DeterministicForMaps(t *testing.T) {
@@ -214,3 +223,327 @@ func TestKeyer_EmptyInput(t *testing.T) {
 		t.Errorf("Keys should differ for nil vs empty map:\n  keyNil=%s\n  keyEmpty=%s", keyNil, keyEmpty)
 	}
 }
+
+func TestCanonicalize_Matrix(t *testing.T) {
+	type query struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	type nested struct {
+		Items []any          `json:"items"`
+		Meta  map[string]any `json:"meta"`
+	}
+	var nilMap map[string]any
+	var nilPtr *query
+
+	tests := []struct {
+		name string
+		a, b any
+		same bool
+	}{
+		{"int vs float64", map[string]any{"n": 1}, map[string]any{"n": 1.0}, true},
+		{"int64 vs float32", map[string]any{"n": int64(42)}, map[string]any{"n": float32(42)}, true},
+		{"uint vs int", []any{uint8(7)}, []any{7}, true},
+		{"uint64 2^63 vs float64", []any{uint64(1 << 63)}, []any{float64(1 << 63)}, true},
+		{"uint64 2^63 vs json.Number", []any{uint64(1 << 63)}, []any{json.Number("9223372036854775808")}, true},
+		{"uint64 max vs float64 2^64", []any{uint64(math.MaxUint64)}, []any{float64(1 << 64)}, false},
+		{"float precision preserved", map[string]any{"n": 1.5}, map[string]any{"n": 1.25}, false},
+		{"integer vs string", map[string]any{"n": 1}, map[string]any{"n": "1"}, false},
+		{"struct vs equivalent map", query{Query: "go", Limit: 10}, map[string]any{"limit": 10, "query": "go"}, true},
+		{"struct pointer vs struct", &query{Query: "go"}, query{Query: "go"}, true},
+		{"nil vs typed nil map", nil, nilMap, true},
+		{"nil vs typed nil pointer", nil, nilPtr, true},
+		{"nil vs empty map", nil, map[string]any{}, false},
+		{"nested arrays order preserved", []any{[]any{1, 2}, []any{3}}, []any{[]any{3}, []any{1, 2}}, false},
+		{"nested arrays equal", []any{[]any{1, 2.0}, map[string]any{"b": 1, "a": 2}}, []any{[]any{1.0, 2}, map[string]any{"a": 2, "b": 1}}, true},
+		{
+			"nested struct vs map",
+			nested{Items: []any{1, "x"}, Meta: map[string]any{"z": true, "a": nil}},
+			map[string]any{"meta": map[string]any{"a": nil, "z": true}, "items": []any{1.0, "x"}},
+			true,
+		},
+		{"typed map vs generic map", map[string]int{"b": 2, "a": 1}, map[string]any{"a": 1.0, "b": 2}, true},
+		{"typed slice vs generic slice", []string{"a", "b"}, []any{"a", "b"}, true},
+		{"time vs RFC3339 string", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "2026-01-02T03:04:05Z", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := canonicalize(tt.a)
+			if err != nil {
+				t.Fatalf("canonicalize(a) error = %v", err)
+			}
+			b, err := canonicalize(tt.b)
+			if err != nil {
+				t.Fatalf("canonicalize(b) error = %v", err)
+			}
+			if (string(a) == string(b)) != tt.same {
+				t.Errorf("canonical forms same=%v, want %v:\n  a=%s\n  b=%s", string(a) == string(b), tt.same, a, b)
+			}
+		})
+	}
+}
+
+func TestCanonicalize_Output(t *testing.T) {
+	got, err := canonicalize(map[string]any{"b": []any{2.0, 1e21, 0.1}, "a": nil})
+	if err != nil {
+		t.Fatalf("canonicalize() error = %v", err)
+	}
+	want := `{"a":null,"b":[2,1e+21,0.1]}`
+	if string(got) != want {
+		t.Errorf("canonicalize() = %s, want %s", got, want)
+	}
+}
+
+func TestKeyer_UnsupportedInput(t *testing.T) {
+	keyer := NewDefaultKeyer()
+
+	inputs := map[string]any{
+		"channel":  map[string]any{"ch": make(chan int)},
+		"function": []any{func() {}},
+		"NaN":      map[string]any{"n": math.NaN()},
+		"Inf":      math.Inf(1),
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			_, err := keyer.Key("tool", input)
+			if !errors.Is(err, ErrUnsupportedInput) {
+				t.Errorf("Key() error = %v, want ErrUnsupportedInput", err)
+			}
+		})
+	}
+}
+
+func tenantContext(tenant string) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{Principal: "user", TenantID: tenant})
+}
+
+func TestTenantAwareKeyer_DifferentTenantsDifferentKeys(t *testing.T) {
+	keyer := NewTenantAwareKeyer(auth.TenantIDFromContext)
+	input := map[string]any{"query": "invoices"}
+
+	keyA, err := keyer.KeyContext(tenantContext("acme"), "billing.search", input)
+	if err != nil {
+		t.Fatalf("KeyContext() error = %v", err)
+	}
+	keyB, err := keyer.KeyContext(tenantContext("globex"), "billing.search", input)
+	if err != nil {
+		t.Fatalf("KeyContext() error = %v", err)
+	}
+
+	if keyA == keyB {
+		t.Errorf("keys should differ across tenants, both = %s", keyA)
+	}
+	for _, key := range []string{keyA, keyB} {
+		if !strings.HasPrefix(key, ToolKeyPrefix("billing.search")) {
+			t.Errorf("key %q missing tool prefix", key)
+		}
+	}
+
+	again, _ := keyer.KeyContext(tenantContext("acme"), "billing.search", input)
+	if again != keyA {
+		t.Errorf("same tenant produced different keys: %s vs %s", keyA, again)
+	}
+}
+
+func TestTenantAwareKeyer_KeyForTenant(t *testing.T) {
+	keyer := NewTenantAwareKeyer(auth.TenantIDFromContext)
+	input := map[string]any{"id": 42}
+
+	fromCtx, _ := keyer.KeyContext(tenantContext("acme"), "tool", input)
+	explicit, err := keyer.KeyForTenant("acme", "tool", input)
+	if err != nil {
+		t.Fatalf("KeyForTenant() error = %v", err)
+	}
+	if fromCtx != explicit {
+		t.Errorf("KeyForTenant() = %s, want %s", explicit, fromCtx)
+	}
+}
+
+func TestTenantAwareKeyer_NoTenantMatchesDefault(t *testing.T) {
+	input := map[string]any{"id": 42}
+	want, _ := NewDefaultKeyer().Key("tool", input)
+
+	tests := []struct {
+		name  string
+		keyer *TenantAwareKeyer
+		ctx   context.Context
+	}{
+		{"no identity", NewTenantAwareKeyer(auth.TenantIDFromContext), context.Background()},
+		{"empty tenant", NewTenantAwareKeyer(auth.TenantIDFromContext), tenantContext("")},
+		{"nil tenant func", NewTenantAwareKeyer(nil), tenantContext("acme")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.keyer.KeyContext(tt.ctx, "tool", input)
+			if err != nil {
+				t.Fatalf("KeyContext() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("KeyContext() = %s, want DefaultKeyer key %s", got, want)
+			}
+		})
+	}
+}
+
+func TestTenantAwareKeyer_UnsupportedInput(t *testing.T) {
+	keyer := NewTenantAwareKeyer(auth.TenantIDFromContext)
+	_, err := keyer.KeyContext(tenantContext("acme"), "tool", map[string]any{"ch": make(chan int)})
+	if !errors.Is(err, ErrUnsupportedInput) {
+		t.Errorf("KeyContext() error = %v, want ErrUnsupportedInput", err)
+	}
+}
+
+func TestTenantAwareKeyer_KeyRequiresTenant(t *testing.T) {
+	keyer := NewTenantAwareKeyer(auth.TenantIDFromContext)
+	if key, err := keyer.Key("tool", map[string]any{"id": 42}); !errors.Is(err, ErrTenantRequired) {
+		t.Errorf("Key() = %q, %v; want ErrTenantRequired", key, err)
+	}
+}
+
+func TestTenantAwareKeyer_Options(t *testing.T) {
+	opts := []KeyerOption{WithHashWidth(MaxHashWidth), WithSerializer(fieldSerializer)}
+	keyer := NewTenantAwareKeyer(auth.TenantIDFromContext, opts...)
+	input := redactedQuery{Repo: "toolops", Token: "alice"}
+
+	// Without a tenant the key matches a DefaultKeyer with the same options
+	want, _ := NewDefaultKeyer(opts...).Key("tool", input)
+	got, err := keyer.KeyContext(context.Background(), "tool", input)
+	if err != nil || got != want {
+		t.Errorf("KeyContext() = %s, %v; want %s", got, err, want)
+	}
+
+	scoped, err := keyer.KeyForTenant("acme", "tool", input)
+	if err != nil {
+		t.Fatalf("KeyForTenant() error = %v", err)
+	}
+	if hash := strings.TrimPrefix(scoped, ToolKeyPrefix("tool")); len(hash) != MaxHashWidth {
+		t.Errorf("hash length = %d, want %d", len(hash), MaxHashWidth)
+	}
+	if _, err := keyer.KeyForTenant("acme", "tool", "not a query"); !errors.Is(err, ErrUnsupportedInput) {
+		t.Errorf("KeyForTenant() error = %v, want serializer error", err)
+	}
+}
+
+// redactedQuery hides Token from its JSON form, so canonical JSON cannot
+// tell two queries with different tokens apart.
+type redactedQuery struct {
+	Repo  string
+	Token string
+}
+
+func (q redactedQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"repo": q.Repo})
+}
+
+// fieldSerializer encodes redactedQuery field by field, in a fixed order.
+var fieldSerializer = SerializerFunc(func(input any) ([]byte, error) {
+	q, ok := input.(redactedQuery)
+	if !ok {
+		return nil, ErrUnsupportedInput
+	}
+	return []byte("repo=" + q.Repo + "\x00token=" + q.Token), nil
+})
+
+func TestKeyer_CustomSerializer(t *testing.T) {
+	a := redactedQuery{Repo: "toolops", Token: "alice"}
+	b := redactedQuery{Repo: "toolops", Token: "bob"}
+
+	jsonA, _ := NewDefaultKeyer().Key("tool", a)
+	jsonB, _ := NewDefaultKeyer().Key("tool", b)
+	if jsonA != jsonB {
+		t.Fatalf("canonical JSON keys differ; test input no longer exercises MarshalJSON")
+	}
+
+	keyer := NewDefaultKeyerWithSerializer(fieldSerializer)
+	keyA, err := keyer.Key("tool", a)
+	if err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+	keyB, _ := keyer.Key("tool", b)
+	if keyA == keyB {
+		t.Errorf("custom serializer keys should differ, both = %s", keyA)
+	}
+
+	// Stable across calls and runs: the key depends only on the serialized bytes
+	sum := sha256.Sum256([]byte("repo=toolops\x00token=alice"))
+	want := ToolKeyPrefix("tool") + hex.EncodeToString(sum[:8])
+	for i := 0; i < 3; i++ {
+		if got, _ := keyer.Key("tool", a); got != want {
+			t.Errorf("Key() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestKeyer_CustomSerializerError(t *testing.T) {
+	keyer := NewDefaultKeyerWithSerializer(fieldSerializer)
+	if _, err := keyer.Key("tool", "not a query"); !errors.Is(err, ErrUnsupportedInput) {
+		t.Errorf("Key() error = %v, want ErrUnsupportedInput", err)
+	}
+}
+
+func TestKeyer_NilSerializerUsesCanonicalJSON(t *testing.T) {
+	input := map[string]any{"b": 2, "a": 1}
+	want, _ := NewDefaultKeyer().Key("tool", input)
+	got, err := NewDefaultKeyerWithSerializer(nil).Key("tool", input)
+	if err != nil || got != want {
+		t.Errorf("Key() = %s, %v; want %s", got, err, want)
+	}
+}
+
+func TestKeyer_HashWidth(t *testing.T) {
+	keyer := NewDefaultKeyer(WithHashWidth(MaxHashWidth))
+	prefix := ToolKeyPrefix("tool")
+
+	keyA, err := keyer.Key("tool", map[string]any{"id": "item-1000"})
+	if err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+	keyB, _ := keyer.Key("tool", map[string]any{"id": "item-1001"})
+
+	for _, key := range []string{keyA, keyB} {
+		if !strings.HasPrefix(key, prefix) {
+			t.Errorf("Key %q should have prefix %q", key, prefix)
+		}
+		if hash := strings.TrimPrefix(key, prefix); len(hash) != MaxHashWidth {
+			t.Errorf("hash length = %d, want %d", len(hash), MaxHashWidth)
+		}
+	}
+	if keyA == keyB {
+		t.Errorf("slightly different inputs share key %s", keyA)
+	}
+
+	// A wider hash extends the default one
+	short, _ := NewDefaultKeyer().Key("tool", map[string]any{"id": "item-1000"})
+	if !strings.HasPrefix(keyA, short) {
+		t.Errorf("full-width key %s should extend default key %s", keyA, short)
+	}
+}
+
+func TestKeyer_HashWidthClamped(t *testing.T) {
+	tests := []struct {
+		width int
+		want  int
+	}{
+		{0, MinHashWidth},
+		{-1, MinHashWidth},
+		{MinHashWidth - 1, MinHashWidth},
+		{MinHashWidth, MinHashWidth},
+		{32, 32},
+		{MaxHashWidth, MaxHashWidth},
+		{MaxHashWidth + 1, MaxHashWidth},
+	}
+
+	prefix := ToolKeyPrefix("tool")
+	for _, tt := range tests {
+		key, err := NewDefaultKeyer(WithHashWidth(tt.width)).Key("tool", nil)
+		if err != nil {
+			t.Fatalf("width %d: Key() error = %v", tt.width, err)
+		}
+		if got := len(strings.TrimPrefix(key, prefix)); got != tt.want {
+			t.Errorf("width %d: hash length = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
@@ -1,66 +1,183 @@
 package cache
 
 import (
+	"container/list"
 	"context"
+	"io"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // MemoryCache is an in-memory cache implementation.
+//
+// When Policy.MaxEntries is positive, the cache is bounded and evicts the
+// least-recently-used entry to make room for a new one. Both Get and Set
+// count as use. Eviction is O(1).
+//
+// When Policy.CompressThreshold is positive, larger values are stored
+// gzip-compressed and decompressed on Get.
+//
+// When Policy.SweepInterval is positive, a background goroutine removes
+// expired entries; call Close to stop it.
 type MemoryCache struct {
 	mu      sync.RWMutex
 	entries map[string]*cacheEntry
+	lru     *list.List // Most recently used at front; nil when unbounded
 	policy  Policy
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+	sets      atomic.Int64
+
+	uncompressedBytes atomic.Int64
+	compressedBytes   atomic.Int64
+
+	stopSweep chan struct{} // nil when no sweeper runs
+	sweepDone chan struct{}
+	closeOnce sync.Once
 }
 
 type cacheEntry struct {
+	key       string
 	value     []byte
 	expiresAt time.Time
+	elem      *list.Element // Position in lru; nil when unbounded
+	rawSize   int           // Original length when compressed; 0 otherwise
 }
 
 // NewMemoryCache creates a new in-memory cache with the given policy.
 func NewMemoryCache(policy Policy) *MemoryCache {
-	return &MemoryCache{
+	c := &MemoryCache{
 		entries: make(map[string]*cacheEntry),
 		policy:  policy,
 	}
+	if policy.MaxEntries > 0 {
+		c.lru = list.New()
+	}
+	if policy.SweepInterval > 0 {
+		c.stopSweep = make(chan struct{})
+		c.sweepDone = make(chan struct{})
+		go c.sweepLoop(policy.SweepInterval)
+	}
+	return c
 }
 
 // Get retrieves a value from the cache. Returns (nil, false) on miss or expiry.
 func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool) {
+	value, _, ok := c.lookup(key)
+	return value, ok
+}
+
+// GetWithTTL retrieves a value along with the time remaining until it
+// expires, so callers can judge its freshness. Returns (nil, 0, false) on
+// miss or expiry.
+func (c *MemoryCache) GetWithTTL(_ context.Context, key string) ([]byte, time.Duration, bool) {
+	value, expiresAt, ok := c.lookup(key)
+	if !ok {
+		return nil, 0, false
+	}
+	return value, max(time.Until(expiresAt), 0), true
+}
+
+// lookup implements Get and GetWithTTL, returning the live value and its
+// expiry time.
+func (c *MemoryCache) lookup(key string) ([]byte, time.Time, bool) {
 	c.mu.RLock()
 	entry, ok := c.entries[key]
 	c.mu.RUnlock()
 
 	if !ok {
-		return nil, false
+		c.misses.Add(1)
+		return nil, time.Time{}, false
 	}
 
 	// Check expiry
 	if time.Now().After(entry.expiresAt) {
 		// Expired - clean up lazily
 		c.mu.Lock()
-		delete(c.entries, key)
+		if c.entries[key] == entry {
+			c.removeLocked(entry)
+		}
+		c.mu.Unlock()
+		c.misses.Add(1)
+		return nil, time.Time{}, false
+	}
+
+	// Mark as recently used
+	if c.lru != nil {
+		c.mu.Lock()
+		if entry.elem != nil && c.entries[key] == entry {
+			c.lru.MoveToFront(entry.elem)
+		}
 		c.mu.Unlock()
-		return nil, false
 	}
 
-	return entry.value, true
+	if entry.rawSize > 0 {
+		value, err := decompress(entry.value, entry.rawSize)
+		if err != nil {
+			c.misses.Add(1)
+			return nil, time.Time{}, false
+		}
+		c.hits.Add(1)
+		return value, entry.expiresAt, true
+	}
+
+	c.hits.Add(1)
+	return entry.value, entry.expiresAt, true
 }
 
 // Set stores a value with the given TTL. TTL=0 means immediate expiry (no caching).
+// If the cache is bounded and full, the least-recently-used entry is evicted.
 func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
 	// TTL=0 means don't cache
 	if ttl <= 0 {
 		return nil
 	}
 
+	// Compress outside the lock
+	rawSize := 0
+	if c.policy.CompressThreshold > 0 && len(value) > c.policy.CompressThreshold {
+		if packed, ok := compress(value); ok {
+			c.uncompressedBytes.Add(int64(len(value)))
+			c.compressedBytes.Add(int64(len(packed)))
+			rawSize = len(value)
+			value = packed
+		}
+	}
+
 	c.mu.Lock()
-	c.entries[key] = &cacheEntry{
+	defer c.mu.Unlock()
+
+	if old, ok := c.entries[key]; ok {
+		c.removeLocked(old)
+	}
+
+	// Make room before inserting
+	if c.lru != nil {
+		for len(c.entries) >= c.policy.MaxEntries {
+			oldest := c.lru.Back()
+			if oldest == nil {
+				break
+			}
+			c.removeLocked(oldest.Value.(*cacheEntry))
+			c.evictions.Add(1)
+		}
+	}
+
+	entry := &cacheEntry{
+		key:       key,
 		value:     value,
 		expiresAt: time.Now().Add(ttl),
+		rawSize:   rawSize,
 	}
-	c.mu.Unlock()
+	if c.lru != nil {
+		entry.elem = c.lru.PushFront(entry)
+	}
+	c.entries[key] = entry
+	c.sets.Add(1)
 
 	return nil
 }
@@ -68,10 +185,166 @@ func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.
 // Delete removes a value from the cache. Idempotent - no error on miss.
 func (c *MemoryCache) Delete(_ context.Context, key string) error {
 	c.mu.Lock()
-	delete(c.entries, key)
+	if entry, ok := c.entries[key]; ok {
+		c.removeLocked(entry)
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// Clear removes all entries. Statistics counters are not reset.
+func (c *MemoryCache) Clear(_ context.Context) error {
+	c.mu.Lock()
+	c.entries = make(map[string]*cacheEntry)
+	if c.lru != nil {
+		c.lru = list.New()
+	}
 	c.mu.Unlock()
 	return nil
 }
 
+// InvalidateByTool removes all entries whose key begins with
+// ToolKeyPrefix(toolID) and returns how many were removed. It scans every
+// entry under the write lock, so it is O(n) in the cache size.
+func (c *MemoryCache) InvalidateByTool(_ context.Context, toolID string) int {
+	prefix := ToolKeyPrefix(toolID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, entry := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.removeLocked(entry)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Close stops the background sweeper, if any, and waits for it to exit.
+// The cache remains usable afterwards, with lazy expiry only. Close is
+// idempotent and always returns nil.
+func (c *MemoryCache) Close() error {
+	if c.stopSweep == nil {
+		return nil
+	}
+	c.closeOnce.Do(func() {
+		close(c.stopSweep)
+		<-c.sweepDone
+	})
+	return nil
+}
+
+// sweepLoop calls sweep every interval until Close.
+func (c *MemoryCache) sweepLoop(interval time.Duration) {
+	defer close(c.sweepDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.stopSweep:
+			return
+		}
+	}
+}
+
+// sweep removes every expired entry under the write lock.
+func (c *MemoryCache) sweep() {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			c.removeLocked(entry)
+		}
+	}
+}
+
+// Len returns the number of entries currently held, including expired
+// entries that have not yet been cleaned up.
+func (c *MemoryCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Stats contains MemoryCache statistics.
+type Stats struct {
+	// Hits counts Get calls that returned a value.
+	Hits int64
+	// Misses counts Get calls that found no live entry.
+	Misses int64
+	// Evictions counts entries removed to make room under Policy.MaxEntries.
+	Evictions int64
+	// Sets counts values stored (Set calls with a positive TTL).
+	Sets int64
+	// CurrentEntries is the number of entries held, as reported by Len.
+	CurrentEntries int
+	// UncompressedBytes totals the original size of values stored
+	// compressed under Policy.CompressThreshold.
+	UncompressedBytes int64
+	// CompressedBytes totals the stored size of those same values.
+	CompressedBytes int64
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookups.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Stats returns current cache statistics.
+func (c *MemoryCache) Stats() Stats {
+	return Stats{
+		Hits:              c.hits.Load(),
+		Misses:            c.misses.Load(),
+		Evictions:         c.evictions.Load(),
+		Sets:              c.sets.Load(),
+		CurrentEntries:    c.Len(),
+		UncompressedBytes: c.uncompressedBytes.Load(),
+		CompressedBytes:   c.compressedBytes.Load(),
+	}
+}
+
+// ResetStats zeroes the hit, miss, eviction, set, and byte counters.
+// CurrentEntries is not a counter and is unaffected.
+func (c *MemoryCache) ResetStats() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.sets.Store(0)
+	c.uncompressedBytes.Store(0)
+	c.compressedBytes.Store(0)
+}
+
+// removeLocked removes entry from the map and recency list.
+// Caller must hold the write lock.
+func (c *MemoryCache) removeLocked(entry *cacheEntry) {
+	delete(c.entries, entry.key)
+	if entry.elem != nil {
+		c.lru.Remove(entry.elem)
+		entry.elem = nil
+	}
+}
+
 // Ensure MemoryCache implements Cache
 var _ Cache = (*MemoryCache)(nil)
+
+// Ensure MemoryCache implements TTLCache
+var _ TTLCache = (*MemoryCache)(nil)
+
+// Ensure MemoryCache implements ToolInvalidator
+var _ ToolInvalidator = (*MemoryCache)(nil)
+
+// Ensure MemoryCache implements io.Closer
+var _ io.Closer = (*MemoryCache)(nil)
@@ -3,6 +3,7 @@ package cache
 import (
 	"bytes"
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -272,3 +273,411 @@ func TestMemoryCache_LargeValues(t *testing.T) {
 
 // Verify MemoryCache implements Cache interface at compile time
 var _ Cache = (*MemoryCache)(nil)
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 2
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+
+	// Touch "a" so "b" becomes least recently used
+	if _, ok := cache.Get(ctx, "a"); !ok {
+		t.Fatal("expected hit for a")
+	}
+
+	_ = cache.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok := cache.Get(ctx, "b"); ok {
+		t.Error("b should have been evicted as least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(ctx, key); !ok {
+			t.Errorf("expected %s to remain cached", key)
+		}
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestMemoryCache_LRUOverwriteDoesNotEvict(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 2
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+	_ = cache.Set(ctx, "a", []byte("updated"), time.Minute)
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if val, ok := cache.Get(ctx, "a"); !ok || string(val) != "updated" {
+		t.Errorf("Get(a) = %q, %v; want updated", val, ok)
+	}
+
+	// Overwrite refreshed "a", so "b" is evicted next
+	_ = cache.Set(ctx, "c", []byte("3"), time.Minute)
+	if _, ok := cache.Get(ctx, "b"); ok {
+		t.Error("b should have been evicted")
+	}
+}
+
+func TestMemoryCache_Len(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_ = cache.Set(ctx, string(rune('a'+i)), []byte("v"), time.Minute)
+	}
+	_ = cache.Delete(ctx, "a")
+
+	if got := cache.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4 (unbounded)", got)
+	}
+}
+
+func TestMemoryCache_LRUConcurrentAccess(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 16
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := string(rune('a' + (g*200+i)%40))
+				_ = cache.Set(ctx, key, []byte("v"), time.Minute)
+				_, _ = cache.Get(ctx, key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if got := cache.Len(); got > 16 {
+		t.Errorf("Len() = %d, want <= 16", got)
+	}
+}
+
+func TestMemoryCache_Stats(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 2
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+	_ = cache.Set(ctx, "c", []byte("3"), time.Minute) // evicts a
+	_ = cache.Set(ctx, "zero", []byte("x"), 0)        // not stored
+
+	_, _ = cache.Get(ctx, "b")
+	_, _ = cache.Get(ctx, "c")
+	_, _ = cache.Get(ctx, "a")
+
+	want := Stats{Hits: 2, Misses: 1, Evictions: 1, Sets: 3, CurrentEntries: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	cache.ResetStats()
+	want = Stats{CurrentEntries: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() after reset = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryCache_StatsConcurrent(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	ctx := context.Background()
+	_ = cache.Set(ctx, "hot", []byte("v"), time.Minute)
+	cache.ResetStats()
+
+	const goroutines, iterations = 10, 100
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, _ = cache.Get(ctx, "hot")
+				_, _ = cache.Get(ctx, "cold")
+				_ = cache.Set(ctx, "hot", []byte("v"), time.Minute)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	total := int64(goroutines * iterations)
+	if stats.Hits != total || stats.Misses != total || stats.Sets != total {
+		t.Errorf("Stats() = %+v, want %d hits, misses, and sets", stats, total)
+	}
+	if stats.HitRate() != 0.5 {
+		t.Errorf("HitRate() = %v, want 0.5", stats.HitRate())
+	}
+}
+
+func TestMemoryCache_Clear(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 100
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	keys := []string{"a", "b", "c"}
+	for _, key := range keys {
+		_ = cache.Set(ctx, key, []byte("v"), time.Minute)
+	}
+
+	if err := cache.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+
+	for _, key := range keys {
+		if _, ok := cache.Get(ctx, key); ok {
+			t.Errorf("Get(%q) hit after Clear", key)
+		}
+	}
+	if got := cache.Len(); got != 0 {
+		t.Errorf("Len() = %d, want 0", got)
+	}
+
+	// Still usable, including LRU bookkeeping
+	_ = cache.Set(ctx, "d", []byte("v"), time.Minute)
+	if _, ok := cache.Get(ctx, "d"); !ok {
+		t.Error("expected hit after Set following Clear")
+	}
+}
+
+func TestMemoryCache_ClearConcurrentGets(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.MaxEntries = 8
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_ = cache.Set(ctx, "k", []byte("v"), time.Minute)
+					_, _ = cache.Get(ctx, "k")
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		_ = cache.Clear(ctx)
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestMemoryCache_InvalidateByTool(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	ctx := context.Background()
+
+	keys := []string{
+		ToolKeyPrefix("search") + "aaa",
+		ToolKeyPrefix("search") + "bbb",
+		ToolKeyPrefix("search") + "bbb" + staleKeySuffix,
+		ToolKeyPrefix("search.v2") + "aaa",
+		ToolKeyPrefix("fetch") + "aaa",
+		"unrelated",
+	}
+	for _, key := range keys {
+		_ = cache.Set(ctx, key, []byte("v"), time.Minute)
+	}
+
+	if got := cache.InvalidateByTool(ctx, "search"); got != 3 {
+		t.Errorf("InvalidateByTool() = %d, want 3", got)
+	}
+
+	for _, key := range keys {
+		_, ok := cache.Get(ctx, key)
+		want := !strings.HasPrefix(key, "cache:search:")
+		if ok != want {
+			t.Errorf("Get(%q) hit = %v, want %v", key, ok, want)
+		}
+	}
+
+	if got := cache.InvalidateByTool(ctx, "missing"); got != 0 {
+		t.Errorf("InvalidateByTool(missing) = %d, want 0", got)
+	}
+}
+
+func TestMemoryCache_Compression(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.CompressThreshold = 1024
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	payload := bytes.Repeat([]byte(`{"name":"repo","stars":42},`), (1<<20)/27)
+	if err := cache.Set(ctx, "large", payload, time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := cache.Get(ctx, "large")
+	if !ok {
+		t.Fatal("expected hit")
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("Get() returned %d bytes differing from the %d stored", len(got), len(payload))
+	}
+
+	stats := cache.Stats()
+	if stats.UncompressedBytes != int64(len(payload)) {
+		t.Errorf("UncompressedBytes = %d, want %d", stats.UncompressedBytes, len(payload))
+	}
+	if stats.CompressedBytes <= 0 || stats.CompressedBytes >= stats.UncompressedBytes/10 {
+		t.Errorf("CompressedBytes = %d, want well under %d", stats.CompressedBytes, stats.UncompressedBytes)
+	}
+}
+
+func TestMemoryCache_CompressionThreshold(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.CompressThreshold = 1024
+	cache := NewMemoryCache(policy)
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		value          []byte
+		wantCompressed bool
+	}{
+		{"at threshold", bytes.Repeat([]byte("a"), 1024), false},
+		{"above threshold", bytes.Repeat([]byte("a"), 1025), true},
+		{"incompressible", incompressible(2048), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache.ResetStats()
+			_ = cache.Set(ctx, tt.name, tt.value, time.Minute)
+
+			got, ok := cache.Get(ctx, tt.name)
+			if !ok || !bytes.Equal(got, tt.value) {
+				t.Errorf("Get() = %q, %v; want original value", got, ok)
+			}
+			if compressed := cache.Stats().CompressedBytes > 0; compressed != tt.wantCompressed {
+				t.Errorf("compressed = %v, want %v", compressed, tt.wantCompressed)
+			}
+		})
+	}
+}
+
+// incompressible returns n pseudo-random bytes that gzip cannot shrink.
+func incompressible(n int) []byte {
+	b := make([]byte, n)
+	x := uint32(2463534242)
+	for i := range b {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		b[i] = byte(x)
+	}
+	return b
+}
+
+func TestMemoryCache_SweepRemovesExpired(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.SweepInterval = 5 * time.Millisecond
+	cache := NewMemoryCache(policy)
+	defer func() { _ = cache.Close() }()
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		_ = cache.Set(ctx, key, []byte("v"), 10*time.Millisecond)
+	}
+	_ = cache.Set(ctx, "live", []byte("v"), time.Minute)
+
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() > 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1 (expired entries swept without Get)", got)
+	}
+	if stats := cache.Stats(); stats.Hits+stats.Misses != 0 {
+		t.Errorf("sweeping recorded %d lookups, want 0", stats.Hits+stats.Misses)
+	}
+}
+
+func TestMemoryCache_CloseStopsSweeper(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.SweepInterval = time.Millisecond
+	cache := NewMemoryCache(policy)
+
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case <-cache.sweepDone:
+	default:
+		t.Fatal("sweeper goroutine still running after Close")
+	}
+
+	// Idempotent, and the cache stays usable
+	if err := cache.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	ctx := context.Background()
+	_ = cache.Set(ctx, "k", []byte("v"), time.Minute)
+	if _, ok := cache.Get(ctx, "k"); !ok {
+		t.Error("expected hit after Close")
+	}
+}
+
+func TestMemoryCache_CloseWithoutSweeper(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	if err := cache.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestMemoryCache_GetWithTTL(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	ctx := context.Background()
+
+	if value, ttl, ok := cache.GetWithTTL(ctx, "missing"); ok || value != nil || ttl != 0 {
+		t.Errorf("GetWithTTL(missing) = %q, %v, %v; want nil, 0, false", value, ttl, ok)
+	}
+
+	_ = cache.Set(ctx, "k", []byte("v"), 100*time.Millisecond)
+
+	value, first, ok := cache.GetWithTTL(ctx, "k")
+	if !ok || string(value) != "v" {
+		t.Fatalf("GetWithTTL() = %q, %v; want v, true", value, ok)
+	}
+	if first <= 0 || first > 100*time.Millisecond {
+		t.Errorf("remaining TTL = %v, want in (0, 100ms]", first)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	_, second, ok := cache.GetWithTTL(ctx, "k")
+	if !ok {
+		t.Fatal("expected hit before expiry")
+	}
+	if second >= first {
+		t.Errorf("remaining TTL did not decrease: %v then %v", first, second)
+	}
+
+	time.Sleep(second + 10*time.Millisecond)
+	if value, ttl, ok := cache.GetWithTTL(ctx, "k"); ok || value != nil || ttl != 0 {
+		t.Errorf("GetWithTTL() after expiry = %q, %v, %v; want nil, 0, false", value, ttl, ok)
+	}
+}
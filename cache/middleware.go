@@ -2,7 +2,11 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync/atomic"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // ExecutorFunc is the function signature for tool execution.
@@ -29,32 +33,168 @@ func DefaultSkipRule(_ string, tags []string) bool {
 	return false
 }
 
+// Middleware events reported to the WithEventHook callback.
+const (
+	// EventServedStaleOnError is reported when Execute returns a stale
+	// value in place of an executor error.
+	EventServedStaleOnError = "served_stale_on_error"
+
+	// EventServedStaleWhileRevalidate is reported when Execute returns a
+	// stale value and refreshes the entry in the background.
+	EventServedStaleWhileRevalidate = "served_stale_while_revalidate"
+)
+
+// ResultRule determines whether an executed result should be cached.
+// Returns false to decline caching, e.g. for a success envelope that
+// carries a soft error.
+type ResultRule func(toolID string, result []byte, err error) bool
+
+// ErrorRule determines whether an executor error should be cached.
+// Returns true for deterministic failures, such as "not found", that
+// would recur if the call were repeated.
+type ErrorRule func(toolID string, err error) bool
+
 // CacheMiddleware wraps tool execution with caching.
 type CacheMiddleware struct {
-	cache    Cache
-	keyer    Keyer
-	policy   Policy
-	skipRule SkipRule
+	cache             Cache
+	keyer             Keyer
+	policy            Policy
+	skipRule          SkipRule
+	shouldCacheResult ResultRule
+	cacheableError    ErrorRule
+	serveStaleOnError bool
+	onEvent           func(ctx context.Context, event, toolID string)
+	hooks             Hooks
+	inflight          singleflight.Group
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// MiddlewareStats contains aggregate lookup counts for a CacheMiddleware
+// across all tools it wraps. Calls that bypass the cache (skip rules,
+// disabled policy, key errors) are not counted.
+type MiddlewareStats struct {
+	// Hits counts calls served from the cache.
+	Hits int64
+	// Misses counts calls that ran the executor after a cache lookup.
+	Misses int64
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookups.
+func (s MiddlewareStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// MiddlewareOption configures a CacheMiddleware.
+type MiddlewareOption func(*CacheMiddleware)
+
+// WithShouldCacheResult sets a predicate consulted before each Set, so
+// callers can inspect result bytes and decline caching. It applies in
+// addition to the skip rule. It is not consulted for errors, which are
+// cached only through WithCacheableError.
+// Default: cache all non-error results.
+func WithShouldCacheResult(rule ResultRule) MiddlewareOption {
+	return func(m *CacheMiddleware) {
+		m.shouldCacheResult = rule
+	}
+}
+
+// WithCacheableError caches executor errors that rule accepts for
+// Policy.NegativeTTL, so repeated calls fail fast without re-running the
+// executor. Cached calls return a *CachedError carrying the original
+// message. Has no effect unless Policy.NegativeTTL is positive.
+// Default: errors are never cached.
+func WithCacheableError(rule ErrorRule) MiddlewareOption {
+	return func(m *CacheMiddleware) {
+		m.cacheableError = rule
+	}
+}
+
+// WithServeStaleOnError makes Execute return a stale cached value instead
+// of an executor error, as long as the value is within Policy.StaleTTL of
+// its expiry. This favors availability over correctness: callers may see
+// data up to TTL+StaleTTL old while the backend is failing, with no error
+// to tell them so. Use WithEventHook to observe when it happens.
+//
+// A stale value takes precedence over WithCacheableError: the error is
+// cached only when there is no stale value to serve. Calls skipped by the
+// SkipRule, or by a policy with caching disabled, store nothing, so they
+// have no stale value to fall back on and always return the executor error,
+// as do refreshes forced with WithBypass.
+// Default: false
+func WithServeStaleOnError(enabled bool) MiddlewareOption {
+	return func(m *CacheMiddleware) {
+		m.serveStaleOnError = enabled
+	}
+}
+
+// WithEventHook sets a callback for middleware events such as
+// EventServedStaleOnError, e.g. to forward them to an observer.
+func WithEventHook(hook func(ctx context.Context, event, toolID string)) MiddlewareOption {
+	return func(m *CacheMiddleware) {
+		m.onEvent = hook
+	}
+}
+
+// Hooks are optional callbacks invoked synchronously inside Execute, e.g.
+// to feed a metrics library without the cache package depending on it.
+// Nil callbacks are skipped. Keep them fast: they run on the request path.
+type Hooks struct {
+	// OnHit is called when a call is served from the cache.
+	OnHit func(toolID string)
+
+	// OnMiss is called when a cache lookup misses, before the executor runs.
+	OnMiss func(toolID string)
+}
+
+// WithHooks sets hit and miss callbacks. They fire exactly when the
+// MiddlewareStats counters increment, so calls that bypass the cache fire
+// neither.
+func WithHooks(hooks Hooks) MiddlewareOption {
+	return func(m *CacheMiddleware) {
+		m.hooks = hooks
+	}
 }
 
 // NewCacheMiddleware creates a new cache middleware.
 // If skipRule is nil, DefaultSkipRule is used.
-func NewCacheMiddleware(cache Cache, keyer Keyer, policy Policy, skipRule SkipRule) *CacheMiddleware {
+func NewCacheMiddleware(cache Cache, keyer Keyer, policy Policy, skipRule SkipRule, opts ...MiddlewareOption) *CacheMiddleware {
 	if skipRule == nil {
 		skipRule = DefaultSkipRule
 	}
-	return &CacheMiddleware{
+	m := &CacheMiddleware{
 		cache:    cache,
 		keyer:    keyer,
 		policy:   policy,
 		skipRule: skipRule,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // Execute runs the tool with caching.
 // On cache hit, returns cached result without calling executor.
-// On cache miss, calls executor and caches the result.
-// Errors are NOT cached.
+// On cache miss, calls executor and caches the result. Concurrent misses
+// for the same key share one executor call, run with the first caller's
+// context, and all receive its result or error. If that caller's context is
+// canceled, the others retry with their own instead of failing with it.
+// The TTL is Policy.PerTool[toolID] or the policy default, unless
+// overridden with WithTTL.
+// Errors are not cached unless accepted by WithCacheableError; with
+// WithServeStaleOnError, an error may be replaced by a stale value retained
+// for Policy.StaleTTL.
+// With Policy.StaleWhileRevalidate, a value that expired within that window
+// is returned immediately while the executor refreshes it in the background.
+// A context from WithBypass skips the lookup, runs the executor without
+// sharing a concurrent call, and stores the fresh result; its errors are
+// never replaced by a stale value.
 func (m *CacheMiddleware) Execute(
 	ctx context.Context,
 	toolID string,
@@ -74,29 +214,208 @@ func (m *CacheMiddleware) Execute(
 	}
 
 	// Generate cache key
-	key, err := m.keyer.Key(toolID, input)
+	key, err := m.key(ctx, toolID, input)
 	if err != nil {
 		// Key generation failed - execute without caching
 		return executor(ctx, toolID, input)
 	}
 
+	// Caller knows the entry is stale - refresh it
+	if BypassFromContext(ctx) {
+		return m.fill(ctx, key, toolID, input, executor, fillBypass)
+	}
+
 	// Check cache
 	if cached, ok := m.cache.Get(ctx, key); ok {
+		m.hits.Add(1)
+		if m.hooks.OnHit != nil {
+			m.hooks.OnHit(toolID)
+		}
+		if cachedErr, isErr := decodeNegative(toolID, cached); isErr {
+			return nil, cachedErr
+		}
 		return cached, nil
 	}
+	m.misses.Add(1)
+	if m.hooks.OnMiss != nil {
+		m.hooks.OnMiss(toolID)
+	}
+
+	// Serve a recently expired value now and refresh it in the background
+	if stale, ok := m.getStale(ctx, key, m.policy.StaleWhileRevalidate); ok {
+		m.emit(ctx, EventServedStaleWhileRevalidate, toolID)
+		go m.revalidate(context.WithoutCancel(ctx), key, toolID, input, executor)
+		return stale, nil
+	}
+
+	// Cache miss - deduplicate concurrent executions of the same key
+	for {
+		v, err, _ := m.inflight.Do(key, func() (any, error) {
+			result, err := m.fill(ctx, key, toolID, input, executor, fillMiss)
+			if err != nil && ctx.Err() != nil {
+				return result, &leaderCanceledError{err: err}
+			}
+			return result, err
+		})
+		var canceled *leaderCanceledError
+		if errors.As(err, &canceled) {
+			// The shared call failed because its caller gave up; retry
+			// unless this caller has given up too
+			if ctx.Err() == nil {
+				continue
+			}
+			err = canceled.err
+		}
+		result, _ := v.([]byte)
+		return result, err
+	}
+}
 
-	// Cache miss - execute
+// leaderCanceledError marks a shared fill that failed after the context of
+// the caller running it was done, so waiters with live contexts retry.
+type leaderCanceledError struct {
+	err error
+}
+
+func (e *leaderCanceledError) Error() string { return e.err.Error() }
+
+func (e *leaderCanceledError) Unwrap() error { return e.err }
+
+// revalidate refreshes key in the background. Concurrent refreshes of the
+// same key share one executor call; on error the stale copy is kept and no
+// negative entry is written, so later calls keep getting the stale value.
+func (m *CacheMiddleware) revalidate(ctx context.Context, key, toolID string, input any, executor ExecutorFunc) {
+	_, _, _ = m.inflight.Do(key, func() (any, error) {
+		return m.fill(ctx, key, toolID, input, executor, fillRevalidate)
+	})
+}
+
+// fillMode tells fill why the executor is running.
+type fillMode int
+
+const (
+	// fillMiss fills a cache miss for a waiting caller.
+	fillMiss fillMode = iota
+
+	// fillRevalidate refreshes a stale entry in the background.
+	fillRevalidate
+
+	// fillBypass forces a refresh requested with WithBypass.
+	fillBypass
+)
+
+// fill runs the executor for a cache miss and stores a cacheable result.
+func (m *CacheMiddleware) fill(
+	ctx context.Context,
+	key string,
+	toolID string,
+	input any,
+	executor ExecutorFunc,
+	mode fillMode,
+) ([]byte, error) {
 	result, err := executor(ctx, toolID, input)
 	if err != nil {
-		// Don't cache errors
+		// Prefer a recently expired value over the error, if allowed. A
+		// forced refresh asked for fresh data, so it gets the error instead.
+		if m.serveStaleOnError && mode != fillBypass {
+			if stale, ok := m.getStale(ctx, key, m.policy.StaleTTL); ok {
+				m.emit(ctx, EventServedStaleOnError, toolID)
+				return stale, nil
+			}
+		}
+		// Remember deterministic failures, if allowed. A failed background
+		// refresh must not replace the stale value callers are being served.
+		if mode != fillRevalidate {
+			m.putNegative(ctx, key, toolID, err)
+		}
 		return result, err
 	}
 
-	// Cache the result
-	ttl := m.policy.EffectiveTTL(0)
+	// Let the result predicate decline caching
+	if m.shouldCacheResult != nil && !m.shouldCacheResult(toolID, result, nil) {
+		return result, nil
+	}
+
+	// Cache the result, honoring per-request and per-tool TTL overrides
+	override, _ := TTLFromContext(ctx)
+	ttl := m.policy.TTLForTool(toolID, override)
 	if ttl > 0 {
 		_ = m.cache.Set(ctx, key, result, ttl)
+		m.putStale(ctx, key, result, ttl)
 	}
 
 	return result, nil
 }
+
+// DeleteByInput removes the cached result for toolID and input, along with
+// its stale copy, deriving the key with the configured Keyer (and ctx, for
+// a ContextKeyer). Use it after a write tool changes data a read tool
+// cached. Returns the key generation or cache error, if any.
+func (m *CacheMiddleware) DeleteByInput(ctx context.Context, toolID string, input any) error {
+	key, err := m.key(ctx, toolID, input)
+	if err != nil {
+		return err
+	}
+	if err := m.cache.Delete(ctx, key); err != nil {
+		return err
+	}
+	return m.cache.Delete(ctx, key+staleKeySuffix)
+}
+
+// putNegative caches err under key when the error rule accepts it.
+func (m *CacheMiddleware) putNegative(ctx context.Context, key, toolID string, err error) {
+	if m.cacheableError == nil || m.policy.NegativeTTL <= 0 || !m.cacheableError(toolID, err) {
+		return
+	}
+	ttl := m.policy.NegativeTTL
+	if m.policy.MaxTTL > 0 && ttl > m.policy.MaxTTL {
+		ttl = m.policy.MaxTTL
+	}
+	_ = m.cache.Set(ctx, key, encodeNegative(err), ttl)
+}
+
+// InvalidateTool removes every cached result for toolID, including stale
+// copies, without the caller knowing the key format. It requires the
+// DefaultKeyer or TenantAwareKeyer key format and a cache implementing
+// ToolInvalidator; otherwise it returns ErrInvalidationUnsupported.
+func (m *CacheMiddleware) InvalidateTool(ctx context.Context, toolID string) (int, error) {
+	inv, ok := m.cache.(ToolInvalidator)
+	if !ok {
+		return 0, ErrInvalidationUnsupported
+	}
+	switch m.keyer.(type) {
+	case *DefaultKeyer, *TenantAwareKeyer:
+	default:
+		return 0, ErrInvalidationUnsupported
+	}
+	return inv.InvalidateByTool(ctx, toolID), nil
+}
+
+// key generates the cache key, passing ctx to keyers that use it.
+func (m *CacheMiddleware) key(ctx context.Context, toolID string, input any) (string, error) {
+	if ck, ok := m.keyer.(ContextKeyer); ok {
+		return ck.KeyContext(ctx, toolID, input)
+	}
+	return m.keyer.Key(toolID, input)
+}
+
+// Stats returns aggregate hit and miss counts.
+func (m *CacheMiddleware) Stats() MiddlewareStats {
+	return MiddlewareStats{
+		Hits:   m.hits.Load(),
+		Misses: m.misses.Load(),
+	}
+}
+
+// ResetStats zeroes the hit and miss counters.
+func (m *CacheMiddleware) ResetStats() {
+	m.hits.Store(0)
+	m.misses.Store(0)
+}
+
+// emit reports a middleware event to the configured hook.
+func (m *CacheMiddleware) emit(ctx context.Context, event, toolID string) {
+	if m.onEvent != nil {
+		m.onEvent(ctx, event, toolID)
+	}
+}
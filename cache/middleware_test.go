@@ -2,10 +2,17 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/jonwraymond/toolops/auth"
 )
 
 // mockExecutor tracks calls and returns configured results
@@ -415,3 +422,881 @@ func TestDefaultSkipRule(t *testing.T) {
 		})
 	}
 }
+
+// ttlRecorder is a Cache that records the TTL of the last Set.
+type ttlRecorder struct {
+	*MemoryCache
+	lastTTL time.Duration
+}
+
+func (r *ttlRecorder) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.lastTTL = ttl
+	return r.MemoryCache.Set(ctx, key, value, ttl)
+}
+
+func TestMiddleware_ContextTTLOverride(t *testing.T) {
+	policy := Policy{DefaultTTL: 5 * time.Minute, MaxTTL: time.Hour}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantTTL time.Duration
+	}{
+		{"no override", context.Background(), 5 * time.Minute},
+		{"shorter override", WithTTL(context.Background(), 10*time.Second), 10 * time.Second},
+		{"longer than max is clamped", WithTTL(context.Background(), 24*time.Hour), time.Hour},
+		{"non-positive override ignored", WithTTL(context.Background(), -time.Second), 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &ttlRecorder{MemoryCache: NewMemoryCache(policy)}
+			mw := NewCacheMiddleware(recorder, NewDefaultKeyer(), policy, nil)
+			executor := &mockExecutor{result: []byte("ok")}
+
+			if _, err := mw.Execute(tt.ctx, "tool", map[string]any{"q": 1}, nil, executor.execute); err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if recorder.lastTTL != tt.wantTTL {
+				t.Errorf("Set TTL = %v, want %v", recorder.lastTTL, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ShouldCacheResult(t *testing.T) {
+	cache := NewMemoryCache(DefaultPolicy())
+	keyer := NewDefaultKeyer()
+	policy := DefaultPolicy()
+
+	// Decline results whose envelope reports ok=false
+	okOnly := func(_ string, result []byte, _ error) bool {
+		var envelope struct {
+			OK *bool `json:"ok"`
+		}
+		if json.Unmarshal(result, &envelope) != nil || envelope.OK == nil {
+			return true
+		}
+		return *envelope.OK
+	}
+
+	mw := NewCacheMiddleware(cache, keyer, policy, nil, WithShouldCacheResult(okOnly))
+
+	ctx := context.Background()
+	input := map[string]any{"x": 1}
+
+	softError := &mockExecutor{result: []byte(`{"error":"quota exceeded","ok":false}`)}
+	for i := 0; i < 2; i++ {
+		if _, err := mw.Execute(ctx, "flaky-tool", input, nil, softError.execute); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if softError.calls != 2 {
+		t.Errorf("expected 2 calls (soft error not cached), got %d", softError.calls)
+	}
+
+	success := &mockExecutor{result: []byte(`{"data":1,"ok":true}`)}
+	for i := 0; i < 2; i++ {
+		if _, err := mw.Execute(ctx, "good-tool", input, nil, success.execute); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if success.calls != 1 {
+		t.Errorf("expected 1 call (cached), got %d", success.calls)
+	}
+}
+
+func TestMiddleware_ServeStaleOnError(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 20 * time.Millisecond
+	policy.StaleTTL = time.Minute
+
+	var events []string
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithServeStaleOnError(true),
+		WithEventHook(func(_ context.Context, event, toolID string) {
+			events = append(events, event+":"+toolID)
+		}),
+	)
+
+	ctx := context.Background()
+	input := map[string]any{"q": "weather"}
+
+	good := &mockExecutor{result: []byte(`{"temp":21}`)}
+	if _, err := mw.Execute(ctx, "forecast", input, nil, good.execute); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+
+	// Let the fresh entry expire; the stale copy remains
+	time.Sleep(40 * time.Millisecond)
+
+	failing := &mockExecutor{err: errors.New("upstream down")}
+	result, err := mw.Execute(ctx, "forecast", input, nil, failing.execute)
+	if err != nil {
+		t.Fatalf("expected stale value instead of error, got: %v", err)
+	}
+	if string(result) != `{"temp":21}` {
+		t.Errorf("result = %s, want stale value", result)
+	}
+	if failing.calls != 1 {
+		t.Errorf("expected executor to be tried once, got %d", failing.calls)
+	}
+	if len(events) != 1 || events[0] != EventServedStaleOnError+":forecast" {
+		t.Errorf("events = %v, want [%s:forecast]", events, EventServedStaleOnError)
+	}
+}
+
+func TestMiddleware_ServeStaleOnErrorWithoutStale(t *testing.T) {
+	tests := []struct {
+		name   string
+		stale  time.Duration
+		enable bool
+		warm   bool
+	}{
+		{"no cached value", time.Minute, true, false},
+		{"option disabled", time.Minute, false, true},
+		{"no stale retention", 0, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultPolicy()
+			policy.DefaultTTL = 20 * time.Millisecond
+			policy.StaleTTL = tt.stale
+
+			mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+				WithServeStaleOnError(tt.enable))
+
+			ctx := context.Background()
+			input := map[string]any{"q": "weather"}
+
+			if tt.warm {
+				good := &mockExecutor{result: []byte(`{"temp":21}`)}
+				if _, err := mw.Execute(ctx, "forecast", input, nil, good.execute); err != nil {
+					t.Fatalf("warm-up call failed: %v", err)
+				}
+				time.Sleep(40 * time.Millisecond)
+			}
+
+			upstreamErr := errors.New("upstream down")
+			failing := &mockExecutor{err: upstreamErr}
+			if _, err := mw.Execute(ctx, "forecast", input, nil, failing.execute); !errors.Is(err, upstreamErr) {
+				t.Errorf("Execute() error = %v, want upstream error", err)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Stats(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	const goroutines = 8
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			exec := &mockExecutor{result: []byte("v")}
+			// Each goroutine uses its own tool: one miss, then hits
+			toolID := "tool-" + string(rune('a'+g))
+			for i := 0; i < 5; i++ {
+				_, _ = mw.Execute(ctx, toolID, nil, nil, exec.execute)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Skipped tools are not counted
+	_, _ = mw.Execute(ctx, "deleter", nil, []string{"delete"}, (&mockExecutor{}).execute)
+
+	want := MiddlewareStats{Hits: goroutines * 4, Misses: goroutines}
+	if got := mw.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	mw.ResetStats()
+	if got := mw.Stats(); got != (MiddlewareStats{}) {
+		t.Errorf("Stats() after reset = %+v, want zero", got)
+	}
+}
+
+func TestMiddleware_SingleflightColdKey(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	executor := func(_ context.Context, _ string, _ any) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("result"), nil
+	}
+
+	const goroutines = 50
+	var started, done sync.WaitGroup
+	results := make([][]byte, goroutines)
+	started.Add(goroutines)
+	done.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			results[i], _ = mw.Execute(ctx, "search", map[string]any{"q": "popular"}, nil, executor)
+		}(i)
+	}
+
+	// Give every goroutine time to join the in-flight call
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("executor calls = %d, want 1", got)
+	}
+	for i, r := range results {
+		if string(r) != "result" {
+			t.Fatalf("results[%d] = %q, want \"result\"", i, r)
+		}
+	}
+}
+
+func TestMiddleware_SingleflightErrorNotCached(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	upstreamErr := errors.New("upstream down")
+	var calls atomic.Int32
+	release := make(chan struct{})
+	failing := func(_ context.Context, _ string, _ any) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return nil, upstreamErr
+	}
+
+	const goroutines = 10
+	var started, done sync.WaitGroup
+	errs := make([]error, goroutines)
+	started.Add(goroutines)
+	done.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			_, errs[i] = mw.Execute(ctx, "search", nil, nil, failing)
+		}(i)
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, upstreamErr) {
+			t.Errorf("errs[%d] = %v, want upstream error", i, err)
+		}
+	}
+
+	// The error was shared, not cached: the next call executes again
+	ok := &mockExecutor{result: []byte("recovered")}
+	if result, err := mw.Execute(ctx, "search", nil, nil, ok.execute); err != nil || string(result) != "recovered" {
+		t.Errorf("Execute() = %q, %v; want recovered", result, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("failing executor calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestMiddleware_SingleflightLeaderCanceled(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+
+	var calls atomic.Int32
+	leaderStarted := make(chan struct{})
+	executor := func(ctx context.Context, _ string, _ any) ([]byte, error) {
+		if calls.Add(1) == 1 {
+			close(leaderStarted)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []byte("result"), nil
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := mw.Execute(leaderCtx, "search", nil, nil, executor)
+		leaderErr <- err
+	}()
+	<-leaderStarted
+
+	waiterResult := make(chan []byte, 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		result, err := mw.Execute(context.Background(), "search", nil, nil, executor)
+		waiterResult <- result
+		waiterErr <- err
+	}()
+
+	// Let the waiter join the in-flight call, then cancel the leader
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+	if result, err := <-waiterResult, <-waiterErr; err != nil || string(result) != "result" {
+		t.Errorf("waiter Execute() = %q, %v; want result", result, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("executor calls = %d, want 2", got)
+	}
+}
+
+func TestMiddleware_PerToolTTL(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.PerTool = map[string]time.Duration{
+		"weather.current":   20 * time.Millisecond,
+		"github.list_repos": time.Hour,
+	}
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	weather := &mockExecutor{result: []byte("sunny")}
+	repos := &mockExecutor{result: []byte("[]")}
+	call := func() {
+		_, _ = mw.Execute(ctx, "weather.current", nil, nil, weather.execute)
+		_, _ = mw.Execute(ctx, "github.list_repos", nil, nil, repos.execute)
+	}
+
+	call()
+	call()
+	if weather.calls != 1 || repos.calls != 1 {
+		t.Fatalf("calls = %d/%d, want 1/1 (both cached)", weather.calls, repos.calls)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	call()
+
+	if weather.calls != 2 {
+		t.Errorf("weather calls = %d, want 2 (expired)", weather.calls)
+	}
+	if repos.calls != 1 {
+		t.Errorf("repos calls = %d, want 1 (still cached)", repos.calls)
+	}
+}
+
+// waitFor polls cond until it returns true or the timeout elapses.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestMiddleware_StaleWhileRevalidate(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 20 * time.Millisecond
+	policy.StaleWhileRevalidate = time.Minute
+	cache := NewMemoryCache(policy)
+	mw := NewCacheMiddleware(cache, NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	var version atomic.Int32
+	version.Store(1)
+	var calls atomic.Int32
+	executor := func(_ context.Context, _ string, _ any) ([]byte, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond) // slow backend
+		return []byte(fmt.Sprintf("v%d", version.Load())), nil
+	}
+
+	if result, _ := mw.Execute(ctx, "report", nil, nil, executor); string(result) != "v1" {
+		t.Fatalf("first call = %q, want v1", result)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	version.Store(2)
+
+	start := time.Now()
+	result, err := mw.Execute(ctx, "report", nil, nil, executor)
+	if err != nil || string(result) != "v1" {
+		t.Fatalf("Execute() = %q, %v; want stale v1", result, err)
+	}
+	if elapsed := time.Since(start); elapsed >= 10*time.Millisecond {
+		t.Errorf("stale read took %v, should not wait for the refresh", elapsed)
+	}
+
+	key, _ := NewDefaultKeyer().Key("report", nil)
+	refreshed := waitFor(t, time.Second, func() bool {
+		v, ok := cache.Get(ctx, key)
+		return ok && string(v) == "v2"
+	})
+	if !refreshed {
+		t.Fatal("cache was not refreshed in the background")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("executor calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestMiddleware_StaleWhileRevalidateKeepsStaleOnError(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 20 * time.Millisecond
+	policy.StaleWhileRevalidate = time.Minute
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	good := &mockExecutor{result: []byte("v1")}
+	_, _ = mw.Execute(ctx, "report", nil, nil, good.execute)
+	time.Sleep(40 * time.Millisecond)
+
+	var failures atomic.Int32
+	failing := func(_ context.Context, _ string, _ any) ([]byte, error) {
+		failures.Add(1)
+		return nil, errors.New("upstream down")
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := mw.Execute(ctx, "report", nil, nil, failing)
+		if err != nil || string(result) != "v1" {
+			t.Fatalf("call %d = %q, %v; want stale v1", i, result, err)
+		}
+		if !waitFor(t, time.Second, func() bool { return failures.Load() == int32(i+1) }) {
+			t.Fatalf("background refresh %d did not run", i+1)
+		}
+	}
+}
+
+func TestMiddleware_StaleWhileRevalidateCacheableError(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 20 * time.Millisecond
+	policy.StaleWhileRevalidate = time.Minute
+	policy.NegativeTTL = time.Minute
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithCacheableError(func(string, error) bool { return true }))
+	ctx := context.Background()
+
+	good := &mockExecutor{result: []byte("v1")}
+	_, _ = mw.Execute(ctx, "report", nil, nil, good.execute)
+	time.Sleep(40 * time.Millisecond)
+
+	var failures atomic.Int32
+	failing := func(_ context.Context, _ string, _ any) ([]byte, error) {
+		failures.Add(1)
+		return nil, errNotFound
+	}
+
+	// The background refresh fails with a cacheable error
+	if result, err := mw.Execute(ctx, "report", nil, nil, failing); err != nil || string(result) != "v1" {
+		t.Fatalf("Execute() = %q, %v; want stale v1", result, err)
+	}
+	if !waitFor(t, time.Second, func() bool { return failures.Load() == 1 }) {
+		t.Fatal("background refresh did not run")
+	}
+	time.Sleep(10 * time.Millisecond) // let the refresh finish storing
+
+	// The failure was not cached over the key: callers still get stale data
+	if result, err := mw.Execute(ctx, "report", nil, nil, failing); err != nil || string(result) != "v1" {
+		t.Errorf("Execute() after failed refresh = %q, %v; want stale v1", result, err)
+	}
+}
+
+func TestMiddleware_StaleWhileRevalidateOutsideWindow(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 20 * time.Millisecond
+	policy.StaleWhileRevalidate = 10 * time.Millisecond
+	policy.StaleTTL = time.Minute // retain longer for stale-on-error only
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	_, _ = mw.Execute(ctx, "report", nil, nil, (&mockExecutor{result: []byte("v1")}).execute)
+	time.Sleep(50 * time.Millisecond)
+
+	fresh := &mockExecutor{result: []byte("v2")}
+	if result, _ := mw.Execute(ctx, "report", nil, nil, fresh.execute); string(result) != "v2" {
+		t.Errorf("Execute() = %q, want v2 (stale window passed)", result)
+	}
+	if fresh.calls != 1 {
+		t.Errorf("executor calls = %d, want 1", fresh.calls)
+	}
+}
+
+func TestMiddleware_InvalidateTool(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.StaleTTL = time.Minute
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	search := &mockExecutor{result: []byte("results")}
+	fetch := &mockExecutor{result: []byte("page")}
+	call := func() {
+		_, _ = mw.Execute(ctx, "search", map[string]any{"q": "a"}, nil, search.execute)
+		_, _ = mw.Execute(ctx, "search", map[string]any{"q": "b"}, nil, search.execute)
+		_, _ = mw.Execute(ctx, "fetch", nil, nil, fetch.execute)
+	}
+
+	call()
+	n, err := mw.InvalidateTool(ctx, "search")
+	if err != nil {
+		t.Fatalf("InvalidateTool() error = %v", err)
+	}
+	// Two results plus their stale copies
+	if n != 4 {
+		t.Errorf("InvalidateTool() = %d, want 4", n)
+	}
+
+	call()
+	if search.calls != 4 {
+		t.Errorf("search calls = %d, want 4 (invalidated)", search.calls)
+	}
+	if fetch.calls != 1 {
+		t.Errorf("fetch calls = %d, want 1 (still cached)", fetch.calls)
+	}
+}
+
+func TestMiddleware_InvalidateToolUnsupported(t *testing.T) {
+	policy := DefaultPolicy()
+	tests := []struct {
+		name  string
+		cache Cache
+		keyer Keyer
+	}{
+		{"cache without invalidator", &mockCache{}, NewDefaultKeyer()},
+		{"custom keyer", NewMemoryCache(policy), &customKeyer{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := NewCacheMiddleware(tt.cache, tt.keyer, policy, nil)
+			if _, err := mw.InvalidateTool(context.Background(), "search"); !errors.Is(err, ErrInvalidationUnsupported) {
+				t.Errorf("InvalidateTool() error = %v, want ErrInvalidationUnsupported", err)
+			}
+		})
+	}
+}
+
+// customKeyer is a custom Keyer with its own key format.
+type customKeyer struct{}
+
+func (customKeyer) Key(toolID string, _ any) (string, error) {
+	return "custom/" + toolID, nil
+}
+
+func TestMiddleware_TenantAwareKeyer(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewTenantAwareKeyer(auth.TenantIDFromContext), policy, nil)
+	input := map[string]any{"query": "invoices"}
+
+	acme := &mockExecutor{result: []byte("acme invoices")}
+	globex := &mockExecutor{result: []byte("globex invoices")}
+
+	for i := 0; i < 2; i++ {
+		if got, _ := mw.Execute(tenantContext("acme"), "billing.search", input, nil, acme.execute); string(got) != "acme invoices" {
+			t.Errorf("acme result = %q", got)
+		}
+		if got, _ := mw.Execute(tenantContext("globex"), "billing.search", input, nil, globex.execute); string(got) != "globex invoices" {
+			t.Errorf("globex result = %q", got)
+		}
+	}
+
+	if acme.calls != 1 || globex.calls != 1 {
+		t.Errorf("calls = %d/%d, want 1/1 (cached per tenant)", acme.calls, globex.calls)
+	}
+}
+
+var errNotFound = errors.New("repo not found")
+
+func TestMiddleware_CacheableError(t *testing.T) {
+	isNotFound := func(_ string, err error) bool { return errors.Is(err, errNotFound) }
+
+	tests := []struct {
+		name      string
+		err       error
+		ttl       time.Duration
+		wantCalls int
+	}{
+		{"cacheable error", errNotFound, time.Minute, 1},
+		{"non-cacheable error", errors.New("backend unavailable"), time.Minute, 3},
+		{"negative TTL disabled", errNotFound, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultPolicy()
+			policy.NegativeTTL = tt.ttl
+			mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+				WithCacheableError(isNotFound))
+			exec := &mockExecutor{err: tt.err}
+
+			for i := 0; i < 3; i++ {
+				_, err := mw.Execute(context.Background(), "github.get_repo", nil, nil, exec.execute)
+				if err == nil || err.Error() != tt.err.Error() {
+					t.Fatalf("call %d: Execute() error = %v, want %v", i, err, tt.err)
+				}
+			}
+			if exec.calls != tt.wantCalls {
+				t.Errorf("executor calls = %d, want %d", exec.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMiddleware_CacheableErrorReturnsCachedError(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.NegativeTTL = 20 * time.Millisecond
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithCacheableError(func(string, error) bool { return true }))
+	ctx := context.Background()
+
+	exec := &mockExecutor{err: errNotFound}
+	if _, err := mw.Execute(ctx, "github.get_repo", nil, nil, exec.execute); !errors.Is(err, errNotFound) {
+		t.Fatalf("first Execute() error = %v, want original error", err)
+	}
+
+	_, err := mw.Execute(ctx, "github.get_repo", nil, nil, exec.execute)
+	var cached *CachedError
+	if !errors.As(err, &cached) {
+		t.Fatalf("cached Execute() error = %T, want *CachedError", err)
+	}
+	if cached.ToolID != "github.get_repo" || cached.Message != errNotFound.Error() {
+		t.Errorf("CachedError = %+v", cached)
+	}
+	if stats := mw.Stats(); stats.Hits != 1 {
+		t.Errorf("Hits = %d, want 1", stats.Hits)
+	}
+
+	// After NegativeTTL the executor runs again
+	time.Sleep(40 * time.Millisecond)
+	exec.err, exec.result = nil, []byte("found")
+	if result, err := mw.Execute(ctx, "github.get_repo", nil, nil, exec.execute); err != nil || string(result) != "found" {
+		t.Errorf("Execute() after NegativeTTL = %q, %v; want found", result, err)
+	}
+}
+
+func TestMiddleware_CacheableErrorPrefersStale(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 10 * time.Millisecond
+	policy.StaleTTL = time.Minute
+	policy.NegativeTTL = time.Minute
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithServeStaleOnError(true),
+		WithCacheableError(func(string, error) bool { return true }))
+	ctx := context.Background()
+
+	good := &mockExecutor{result: []byte("cached")}
+	_, _ = mw.Execute(ctx, "tool", nil, nil, good.execute)
+	time.Sleep(20 * time.Millisecond)
+
+	failing := &mockExecutor{err: errNotFound}
+	for i := 0; i < 2; i++ {
+		if result, err := mw.Execute(ctx, "tool", nil, nil, failing.execute); err != nil || string(result) != "cached" {
+			t.Errorf("call %d: Execute() = %q, %v; want stale value", i, result, err)
+		}
+	}
+	if failing.calls != 2 {
+		t.Errorf("failing calls = %d, want 2 (error not cached while stale is served)", failing.calls)
+	}
+}
+
+func TestMiddleware_ServeStaleOnErrorWithCacheableError(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      []string
+		warm      bool
+		wantStale bool
+		wantCalls int
+	}{
+		{"stale served, error not cached", nil, true, true, 3},
+		{"no stale, error cached", nil, false, false, 1},
+		{"skipped tool, neither applies", []string{"write"}, true, false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultPolicy()
+			policy.DefaultTTL = 10 * time.Millisecond
+			policy.StaleTTL = time.Minute
+			policy.NegativeTTL = time.Minute
+			mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+				WithServeStaleOnError(true),
+				WithCacheableError(func(string, error) bool { return true }))
+			ctx := context.Background()
+
+			if tt.warm {
+				good := &mockExecutor{result: []byte("cached")}
+				_, _ = mw.Execute(ctx, "tool", nil, tt.tags, good.execute)
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			failing := &mockExecutor{err: errNotFound}
+			for i := 0; i < 3; i++ {
+				result, err := mw.Execute(ctx, "tool", nil, tt.tags, failing.execute)
+				if tt.wantStale {
+					if err != nil || string(result) != "cached" {
+						t.Errorf("call %d: Execute() = %q, %v; want stale value", i, result, err)
+					}
+				} else if err == nil || err.Error() != errNotFound.Error() {
+					t.Errorf("call %d: Execute() error = %v, want %v", i, err, errNotFound)
+				}
+			}
+			if failing.calls != tt.wantCalls {
+				t.Errorf("failing calls = %d, want %d", failing.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Bypass(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	ctx := context.Background()
+
+	exec := &mockExecutor{result: []byte("v1")}
+	_, _ = mw.Execute(ctx, "tool", nil, nil, exec.execute)
+
+	// Bypass runs the executor despite the cached entry
+	exec.result = []byte("v2")
+	result, err := mw.Execute(WithBypass(ctx), "tool", nil, nil, exec.execute)
+	if err != nil || string(result) != "v2" {
+		t.Fatalf("bypassed Execute() = %q, %v; want v2", result, err)
+	}
+	if exec.calls != 2 {
+		t.Errorf("executor calls = %d, want 2", exec.calls)
+	}
+
+	// The fresh result repopulated the cache for later callers
+	result, _ = mw.Execute(ctx, "tool", nil, nil, exec.execute)
+	if string(result) != "v2" {
+		t.Errorf("Execute() after bypass = %q, want v2", result)
+	}
+	if exec.calls != 2 {
+		t.Errorf("executor calls = %d, want 2 (served from cache)", exec.calls)
+	}
+}
+
+func TestMiddleware_BypassNeverServesStale(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.DefaultTTL = 10 * time.Millisecond
+	policy.StaleTTL = time.Minute
+	var events []string
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithServeStaleOnError(true),
+		WithEventHook(func(_ context.Context, event, _ string) { events = append(events, event) }))
+	ctx := context.Background()
+
+	good := &mockExecutor{result: []byte("v1")}
+	_, _ = mw.Execute(ctx, "tool", nil, nil, good.execute)
+	time.Sleep(20 * time.Millisecond)
+
+	upstreamErr := errors.New("upstream down")
+	failing := &mockExecutor{err: upstreamErr}
+	result, err := mw.Execute(WithBypass(ctx), "tool", nil, nil, failing.execute)
+	if !errors.Is(err, upstreamErr) {
+		t.Fatalf("bypassed Execute() = %q, %v; want upstream error", result, err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events = %v, want none", events)
+	}
+
+	// Without bypass the stale value is still served
+	if result, err := mw.Execute(ctx, "tool", nil, nil, failing.execute); err != nil || string(result) != "v1" {
+		t.Errorf("Execute() = %q, %v; want stale v1", result, err)
+	}
+}
+
+func TestBypassFromContext(t *testing.T) {
+	if BypassFromContext(context.Background()) {
+		t.Error("BypassFromContext() = true without WithBypass")
+	}
+	if !BypassFromContext(WithBypass(context.Background())) {
+		t.Error("BypassFromContext() = false after WithBypass")
+	}
+}
+
+func TestMiddleware_DeleteByInput(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.StaleTTL = time.Minute
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithServeStaleOnError(true))
+	ctx := context.Background()
+	input := map[string]any{"repo": "toolops"}
+	other := map[string]any{"repo": "other"}
+
+	exec := &mockExecutor{result: []byte("repo")}
+	_, _ = mw.Execute(ctx, "github.get_repo", input, nil, exec.execute)
+	_, _ = mw.Execute(ctx, "github.get_repo", other, nil, exec.execute)
+
+	if err := mw.DeleteByInput(ctx, "github.get_repo", map[string]any{"repo": "toolops"}); err != nil {
+		t.Fatalf("DeleteByInput() error = %v", err)
+	}
+
+	// The deleted read misses, and its stale copy is gone too
+	failing := &mockExecutor{err: errors.New("backend down")}
+	if _, err := mw.Execute(ctx, "github.get_repo", input, nil, failing.execute); err == nil {
+		t.Error("Execute() served a deleted entry")
+	}
+	if failing.calls != 1 {
+		t.Errorf("executor calls = %d, want 1 (miss)", failing.calls)
+	}
+
+	// Other inputs are untouched
+	if _, err := mw.Execute(ctx, "github.get_repo", other, nil, failing.execute); err != nil {
+		t.Errorf("Execute(other) error = %v, want cached hit", err)
+	}
+}
+
+func TestMiddleware_DeleteByInputKeyError(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil)
+	err := mw.DeleteByInput(context.Background(), "tool", map[string]any{"ch": make(chan int)})
+	if !errors.Is(err, ErrUnsupportedInput) {
+		t.Errorf("DeleteByInput() error = %v, want ErrUnsupportedInput", err)
+	}
+}
+
+func TestMiddleware_Hooks(t *testing.T) {
+	policy := DefaultPolicy()
+	var hits, misses []string
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithHooks(Hooks{
+			OnHit:  func(toolID string) { hits = append(hits, toolID) },
+			OnMiss: func(toolID string) { misses = append(misses, toolID) },
+		}))
+	ctx := context.Background()
+	exec := &mockExecutor{result: []byte("v")}
+
+	_, _ = mw.Execute(ctx, "search", nil, nil, exec.execute)
+	_, _ = mw.Execute(ctx, "search", nil, nil, exec.execute)
+	_, _ = mw.Execute(ctx, "fetch", nil, nil, exec.execute)
+	_, _ = mw.Execute(ctx, "delete_repo", nil, []string{"delete"}, exec.execute)
+
+	if want := []string{"search"}; !slices.Equal(hits, want) {
+		t.Errorf("OnHit calls = %v, want %v", hits, want)
+	}
+	if want := []string{"search", "fetch"}; !slices.Equal(misses, want) {
+		t.Errorf("OnMiss calls = %v, want %v (skipped tool fires neither)", misses, want)
+	}
+}
+
+func TestMiddleware_HooksNil(t *testing.T) {
+	policy := DefaultPolicy()
+	mw := NewCacheMiddleware(NewMemoryCache(policy), NewDefaultKeyer(), policy, nil,
+		WithHooks(Hooks{OnHit: func(string) {}}))
+	exec := &mockExecutor{result: []byte("v")}
+
+	for i := 0; i < 2; i++ {
+		if _, err := mw.Execute(context.Background(), "search", nil, nil, exec.execute); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+}
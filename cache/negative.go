@@ -0,0 +1,40 @@
+package cache
+
+import "bytes"
+
+// negativeMarker prefixes cached error entries. Tool results are expected
+// to be JSON or text, which never begin with a NUL byte.
+var negativeMarker = []byte("\x00cache:negative\x00")
+
+// CachedError is returned by CacheMiddleware.Execute when a call is served
+// from a negatively cached error (see WithCacheableError). Only the
+// message of the original error survives caching, so match it with
+// errors.As rather than errors.Is against the original sentinel.
+type CachedError struct {
+	// ToolID is the tool whose error was cached.
+	ToolID string
+	// Message is the original error's message.
+	Message string
+}
+
+// Error returns the original error message.
+func (e *CachedError) Error() string {
+	return e.Message
+}
+
+// encodeNegative builds the cache value recording err.
+func encodeNegative(err error) []byte {
+	msg := err.Error()
+	buf := make([]byte, 0, len(negativeMarker)+len(msg))
+	buf = append(buf, negativeMarker...)
+	return append(buf, msg...)
+}
+
+// decodeNegative reports whether value is a cached error and returns it.
+func decodeNegative(toolID string, value []byte) (*CachedError, bool) {
+	msg, ok := bytes.CutPrefix(value, negativeMarker)
+	if !ok {
+		return nil, false
+	}
+	return &CachedError{ToolID: toolID, Message: string(msg)}, true
+}
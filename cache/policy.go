@@ -1,6 +1,9 @@
 package cache
 
-import "time"
+import (
+	"math/rand/v2"
+	"time"
+)
 
 // Policy configures caching behavior.
 type Policy struct {
@@ -8,12 +11,57 @@ type Policy struct {
 	// If zero, caching is disabled by default.
 	DefaultTTL time.Duration
 
+	// PerTool overrides DefaultTTL for specific tools, keyed by exact tool
+	// ID (e.g., "github.list_repos"). Values are still clamped to MaxTTL.
+	// Only consulted when caching is enabled (DefaultTTL > 0).
+	PerTool map[string]time.Duration
+
 	// MaxTTL is the maximum allowed TTL. Override TTLs are clamped to this.
 	// If zero, no maximum is enforced.
 	MaxTTL time.Duration
 
+	// JitterPct randomizes each computed TTL within ±JitterPct of its value
+	// (0.0–1.0) so entries written together do not expire together.
+	// MaxTTL remains an absolute ceiling.
+	// If zero, TTLs are exact.
+	JitterPct float64
+
 	// AllowUnsafe permits caching tools with unsafe tags (write, danger, etc.)
 	AllowUnsafe bool
+
+	// MaxEntries bounds the number of entries a MemoryCache holds, evicting
+	// the least-recently-used entry when full.
+	// If zero, the cache is unbounded.
+	MaxEntries int
+
+	// SweepInterval makes a MemoryCache remove expired entries in the
+	// background at this interval, instead of only when they are next read.
+	// Call MemoryCache.Close to stop the sweeper.
+	// If zero, expired entries are removed lazily.
+	SweepInterval time.Duration
+
+	// CompressThreshold makes a MemoryCache gzip values larger than this
+	// many bytes, trading CPU on Set and Get for memory. Compression is
+	// invisible to callers, who always see the original bytes.
+	// If zero, values are stored as-is.
+	CompressThreshold int
+
+	// StaleTTL is how long CacheMiddleware retains a result past its TTL
+	// for stale serving (see WithServeStaleOnError). Retention costs one
+	// extra cache entry per result.
+	// If zero, no stale copies are kept.
+	StaleTTL time.Duration
+
+	// NegativeTTL is how long CacheMiddleware caches an executor error that
+	// its WithCacheableError predicate accepts. Clamped to MaxTTL.
+	// If zero, errors are never cached.
+	NegativeTTL time.Duration
+
+	// StaleWhileRevalidate lets CacheMiddleware return a result up to this
+	// long past its TTL immediately, refreshing it with a background
+	// executor call. Shares stale retention with StaleTTL.
+	// If zero, expired results are refreshed synchronously.
+	StaleWhileRevalidate time.Duration
 }
 
 // DefaultPolicy returns the default caching policy.
@@ -40,7 +88,8 @@ func (p Policy) ShouldCache() bool {
 	return p.DefaultTTL > 0
 }
 
-// EffectiveTTL returns the TTL to use, applying defaults and clamping.
+// EffectiveTTL returns the TTL to use, applying defaults, clamping, and
+// jitter.
 func (p Policy) EffectiveTTL(override time.Duration) time.Duration {
 	// Use default if no override (or negative override)
 	ttl := override
@@ -53,5 +102,34 @@ func (p Policy) EffectiveTTL(override time.Duration) time.Duration {
 		ttl = p.MaxTTL
 	}
 
-	return ttl
+	return p.jitter(ttl)
+}
+
+// jitter spreads ttl by up to ±JitterPct, never exceeding MaxTTL.
+func (p Policy) jitter(ttl time.Duration) time.Duration {
+	pct := min(p.JitterPct, 1.0)
+	if pct <= 0 || ttl <= 0 {
+		return ttl
+	}
+
+	// #nosec G404 -- jitter is non-cryptographic timing variance.
+	factor := 1 + pct*(2*rand.Float64()-1)
+	jittered := time.Duration(float64(ttl) * factor)
+
+	if p.MaxTTL > 0 && jittered > p.MaxTTL {
+		jittered = p.MaxTTL
+	}
+	if jittered <= 0 {
+		jittered = 1
+	}
+	return jittered
+}
+
+// TTLForTool returns the TTL for a tool's result: override if positive,
+// else the PerTool entry for toolID, else DefaultTTL, clamped to MaxTTL.
+func (p Policy) TTLForTool(toolID string, override time.Duration) time.Duration {
+	if override <= 0 {
+		override = p.PerTool[toolID]
+	}
+	return p.EffectiveTTL(override)
 }
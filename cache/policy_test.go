@@ -211,3 +211,78 @@ func TestPolicy_ShouldCache(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_TTLForTool(t *testing.T) {
+	p := Policy{
+		DefaultTTL: 5 * time.Minute,
+		MaxTTL:     time.Hour,
+		PerTool: map[string]time.Duration{
+			"github.list_repos": time.Hour,
+			"weather.current":   time.Minute,
+			"huge":              24 * time.Hour,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		toolID   string
+		override time.Duration
+		want     time.Duration
+	}{
+		{"per-tool", "weather.current", 0, time.Minute},
+		{"per-tool long", "github.list_repos", 0, time.Hour},
+		{"clamped to MaxTTL", "huge", 0, time.Hour},
+		{"default for unknown tool", "other", 0, 5 * time.Minute},
+		{"exact match only", "weather", 0, 5 * time.Minute},
+		{"override wins", "weather.current", 10 * time.Second, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.TTLForTool(tt.toolID, tt.override); got != tt.want {
+				t.Errorf("TTLForTool(%q, %v) = %v, want %v", tt.toolID, tt.override, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Jitter(t *testing.T) {
+	p := Policy{
+		DefaultTTL: 100 * time.Second,
+		JitterPct:  0.2,
+	}
+
+	lo, hi := 80*time.Second, 120*time.Second
+	var below, above int
+	for i := 0; i < 1000; i++ {
+		got := p.EffectiveTTL(0)
+		if got < lo || got > hi {
+			t.Fatalf("EffectiveTTL(0) = %v, want within [%v, %v]", got, lo, hi)
+		}
+		switch {
+		case got < 90*time.Second:
+			below++
+		case got > 110*time.Second:
+			above++
+		}
+	}
+
+	// Samples should spread across both halves of the range
+	if below == 0 || above == 0 {
+		t.Errorf("jitter not spread: %d samples below 90s, %d above 110s", below, above)
+	}
+}
+
+func TestPolicy_JitterRespectsMaxTTL(t *testing.T) {
+	p := Policy{
+		DefaultTTL: time.Minute,
+		MaxTTL:     time.Minute,
+		JitterPct:  0.5,
+	}
+
+	for i := 0; i < 1000; i++ {
+		if got := p.EffectiveTTL(0); got > time.Minute || got < 30*time.Second {
+			t.Fatalf("EffectiveTTL(0) = %v, want within [30s, 1m]", got)
+		}
+	}
+}
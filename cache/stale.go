@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+)
+
+// staleKeySuffix marks the longer-lived copy of a result kept for stale serving.
+const staleKeySuffix = ":stale"
+
+// staleHeaderLen is the size of the fresh-expiry timestamp prefixed to
+// stale copies.
+const staleHeaderLen = 8
+
+// staleRetention is how long a stale copy outlives its fresh entry: long
+// enough for both stale-on-error and stale-while-revalidate windows.
+func (p Policy) staleRetention() time.Duration {
+	return max(p.StaleTTL, p.StaleWhileRevalidate)
+}
+
+// putStale stores a stale copy of value that outlives the fresh entry by
+// the policy's stale retention. The copy records when the fresh entry
+// expires so each stale window can be checked separately.
+func (m *CacheMiddleware) putStale(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	retention := m.policy.staleRetention()
+	if retention <= 0 {
+		return
+	}
+
+	buf := make([]byte, staleHeaderLen+len(value))
+	binary.BigEndian.PutUint64(buf, uint64(time.Now().Add(ttl).UnixNano())) // #nosec G115 -- timestamps after 1970 are positive.
+	copy(buf[staleHeaderLen:], value)
+	_ = m.cache.Set(ctx, key+staleKeySuffix, buf, ttl+retention)
+}
+
+// getStale returns the stale copy of key if its fresh entry expired no
+// more than window ago.
+func (m *CacheMiddleware) getStale(ctx context.Context, key string, window time.Duration) ([]byte, bool) {
+	if window <= 0 {
+		return nil, false
+	}
+
+	buf, ok := m.cache.Get(ctx, key+staleKeySuffix)
+	if !ok || len(buf) < staleHeaderLen {
+		return nil, false
+	}
+
+	freshUntil := time.Unix(0, int64(binary.BigEndian.Uint64(buf))) // #nosec G115 -- written by putStale.
+	if time.Since(freshUntil) > window {
+		return nil, false
+	}
+	return buf[staleHeaderLen:], true
+}
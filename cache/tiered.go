@@ -0,0 +1,242 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TieredCacheConfig configures a TieredCache.
+type TieredCacheConfig struct {
+	// L1 is the fast, node-local cache (typically a MemoryCache). Required.
+	L1 Cache
+
+	// L2 is the shared cache visible to all nodes (e.g., Redis). Required.
+	L2 Cache
+
+	// MaxStaleness caps the TTL of L1 entries. It bounds how long a node can
+	// keep serving a value from L1 after another node has overwritten or
+	// deleted it in L2.
+	// Default: 30 seconds
+	MaxStaleness time.Duration
+
+	// OnInvalidate is called with the key after this node sets or deletes it.
+	// Use it to broadcast invalidations to peer nodes (e.g., via Redis
+	// pub/sub); peers should pass received keys to Invalidate.
+	// Default: nil (no broadcast)
+	OnInvalidate func(key string)
+}
+
+// Breaker guards calls to the remote tier of a TieredCache.
+// resilience.CircuitBreaker satisfies this interface.
+type Breaker interface {
+	// Execute runs op unless the breaker is open, in which case it returns
+	// an error without calling op.
+	Execute(ctx context.Context, op func(context.Context) error) error
+
+	// IsOpen reports whether the breaker is rejecting calls.
+	IsOpen() bool
+}
+
+// TieredCacheOption configures optional TieredCache behavior.
+type TieredCacheOption func(*TieredCache)
+
+// WithBreaker guards the L2 tier with a circuit breaker. While the breaker
+// is open, L2 reads are treated as misses and L2 calls are skipped, so a
+// failing remote cache costs nothing on the request path. L1 continues to
+// serve and store entries, but Set, Delete, and Clear return an error
+// wrapping ErrBreakerOpen because L2 and peers were not updated.
+func WithBreaker(b Breaker) TieredCacheOption {
+	return func(c *TieredCache) {
+		c.breaker = b
+	}
+}
+
+// TieredCache layers a node-local L1 cache over a shared L2 cache.
+//
+// Consistency model:
+//   - Writes and deletes go to L2 first, then L1, on the calling node.
+//   - Reads are served from L1 when present; L2 hits are copied into L1,
+//     for no longer than the L2 entry has left when L2 is a TTLCache.
+//   - L1 entries live at most MaxStaleness, so peer nodes observe a write
+//     within MaxStaleness even without invalidation broadcasts.
+//   - With OnInvalidate wired to a transport that calls Invalidate on peers,
+//     staleness drops to the broadcast latency.
+//
+// Contract:
+//   - Concurrency: safe for concurrent use if L1 and L2 are.
+//   - Errors: Get never errors; Set, Delete, and Clear return L2 errors.
+//     When the breaker is open they still apply to this node's L1 and
+//     return an error wrapping ErrBreakerOpen: the write or invalidation
+//     did not reach L2, so other nodes may serve the old value until it
+//     expires there.
+type TieredCache struct {
+	l1           Cache
+	l2           Cache
+	maxStaleness time.Duration
+	onInvalidate func(key string)
+	breaker      Breaker
+}
+
+// NewTieredCache creates a tiered cache.
+// Returns ErrNilCache if L1 or L2 is nil.
+func NewTieredCache(config TieredCacheConfig, opts ...TieredCacheOption) (*TieredCache, error) {
+	if config.L1 == nil || config.L2 == nil {
+		return nil, ErrNilCache
+	}
+	if config.MaxStaleness <= 0 {
+		config.MaxStaleness = 30 * time.Second
+	}
+
+	c := &TieredCache{
+		l1:           config.L1,
+		l2:           config.L2,
+		maxStaleness: config.MaxStaleness,
+		onInvalidate: config.OnInvalidate,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Get returns the value from L1, falling back to L2.
+// An L2 hit is stored in L1 for up to MaxStaleness, or for the L2 entry's
+// remaining TTL if L2 reports a shorter one.
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	if value, ok := c.l1.Get(ctx, key); ok {
+		return value, true
+	}
+
+	value, remaining, ok := c.getL2(ctx, key)
+	if !ok {
+		return nil, false
+	}
+
+	_ = c.l1.Set(ctx, key, value, c.l1TTL(remaining))
+	return value, true
+}
+
+// Set stores the value in L2 with ttl and in L1 with ttl capped at
+// MaxStaleness, then calls OnInvalidate. If the breaker is open, only L1 is
+// written and an error wrapping ErrBreakerOpen is returned.
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := c.guard(ctx, func(ctx context.Context) error {
+		return c.l2.Set(ctx, key, value, ttl)
+	})
+	if errors.Is(err, ErrBreakerOpen) {
+		_ = c.l1.Set(ctx, key, value, c.l1TTL(ttl))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	_ = c.l1.Set(ctx, key, value, c.l1TTL(ttl))
+	c.notify(key)
+	return nil
+}
+
+// Delete removes the key from L2 and L1, then calls OnInvalidate. If the
+// breaker is open, only L1 is cleared and an error wrapping ErrBreakerOpen
+// is returned, since L2 and peers still hold the value.
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	err := c.guard(ctx, func(ctx context.Context) error {
+		return c.l2.Delete(ctx, key)
+	})
+	if errors.Is(err, ErrBreakerOpen) {
+		_ = c.l1.Delete(ctx, key)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	_ = c.l1.Delete(ctx, key)
+	c.notify(key)
+	return nil
+}
+
+// Clear removes all entries from L2 and this node's L1. Peers' L1 entries
+// expire within MaxStaleness. An L2 failure (including an open breaker) is
+// returned; L1 is cleared regardless.
+func (c *TieredCache) Clear(ctx context.Context) error {
+	err := c.guard(ctx, func(ctx context.Context) error {
+		return c.l2.Clear(ctx)
+	})
+	_ = c.l1.Clear(ctx)
+	return err
+}
+
+// Invalidate removes the key from this node's L1 only.
+// It is the receiving end of an invalidation broadcast and does not call
+// OnInvalidate.
+func (c *TieredCache) Invalidate(key string) {
+	_ = c.l1.Delete(context.Background(), key)
+}
+
+// BreakerOpen reports whether the L2 breaker is open, for health checks.
+// Returns false when no breaker is configured.
+func (c *TieredCache) BreakerOpen() bool {
+	return c.breaker != nil && c.breaker.IsOpen()
+}
+
+// getL2 reads from L2 through the breaker. Backend failures reported by a
+// RemoteCache count against the breaker; an open breaker reads as a miss.
+// The remaining TTL is MaxStaleness unless L2 is a TTLCache.
+func (c *TieredCache) getL2(ctx context.Context, key string) ([]byte, time.Duration, bool) {
+	var value []byte
+	var ok bool
+	remaining := c.maxStaleness
+	err := c.guard(ctx, func(ctx context.Context) error {
+		if remote, isRemote := c.l2.(RemoteCache); isRemote {
+			var err error
+			value, ok, err = remote.Fetch(ctx, key)
+			return err
+		}
+		if ttlCache, isTTL := c.l2.(TTLCache); isTTL {
+			value, remaining, ok = ttlCache.GetWithTTL(ctx, key)
+			return nil
+		}
+		value, ok = c.l2.Get(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, false
+	}
+	return value, remaining, ok
+}
+
+// guard runs op through the breaker, if configured. An error returned
+// without op having run is a breaker rejection and wraps ErrBreakerOpen.
+func (c *TieredCache) guard(ctx context.Context, op func(context.Context) error) error {
+	if c.breaker == nil {
+		return op(ctx)
+	}
+	ran := false
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		ran = true
+		return op(ctx)
+	})
+	if err != nil && !ran {
+		return fmt.Errorf("%w: %w", ErrBreakerOpen, err)
+	}
+	return err
+}
+
+func (c *TieredCache) l1TTL(ttl time.Duration) time.Duration {
+	if ttl > c.maxStaleness {
+		return c.maxStaleness
+	}
+	return ttl
+}
+
+func (c *TieredCache) notify(key string) {
+	if c.onInvalidate != nil {
+		c.onInvalidate(key)
+	}
+}
+
+// Ensure TieredCache implements Cache
+var _ Cache = (*TieredCache)(nil)
@@ -0,0 +1,359 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolops/resilience"
+)
+
+// flakyRemote is a RemoteCache whose backend can be switched into failure.
+type flakyRemote struct {
+	*MemoryCache
+	failing atomic.Bool
+	calls   atomic.Int32
+}
+
+var errRemoteDown = errors.New("remote down")
+
+func newFlakyRemote() *flakyRemote {
+	return &flakyRemote{MemoryCache: NewMemoryCache(DefaultPolicy())}
+}
+
+func (r *flakyRemote) Fetch(ctx context.Context, key string) ([]byte, bool, error) {
+	r.calls.Add(1)
+	if r.failing.Load() {
+		return nil, false, errRemoteDown
+	}
+	v, ok := r.MemoryCache.Get(ctx, key)
+	return v, ok, nil
+}
+
+func (r *flakyRemote) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.calls.Add(1)
+	if r.failing.Load() {
+		return errRemoteDown
+	}
+	return r.MemoryCache.Set(ctx, key, value, ttl)
+}
+
+func TestNewTieredCache_NilTier(t *testing.T) {
+	_, err := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy())})
+	if !errors.Is(err, ErrNilCache) {
+		t.Errorf("NewTieredCache() error = %v, want ErrNilCache", err)
+	}
+}
+
+func TestTieredCache_GetFallsBackToL2(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewMemoryCache(DefaultPolicy())
+	l2 := NewMemoryCache(DefaultPolicy())
+	tc, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	_ = l2.Set(ctx, "k", []byte("v"), time.Minute)
+
+	got, ok := tc.Get(ctx, "k")
+	if !ok || string(got) != "v" {
+		t.Fatalf("Get() = %q, %v; want \"v\", true", got, ok)
+	}
+	if _, ok := l1.Get(ctx, "k"); !ok {
+		t.Error("L2 hit should populate L1")
+	}
+}
+
+func TestTieredCache_L1TTLCappedAtMaxStaleness(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewMemoryCache(DefaultPolicy())
+	l2 := NewMemoryCache(DefaultPolicy())
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2, MaxStaleness: 20 * time.Millisecond})
+
+	if err := tc.Set(ctx, "k", []byte("v"), time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if _, ok := l1.Get(ctx, "k"); ok {
+		t.Error("L1 entry should expire after MaxStaleness")
+	}
+	if _, ok := l2.Get(ctx, "k"); !ok {
+		t.Error("L2 entry should keep the full TTL")
+	}
+}
+
+func TestTieredCache_PeerStalenessBounded(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryCache(DefaultPolicy())
+	nodeA, _ := NewTieredCache(TieredCacheConfig{
+		L1: NewMemoryCache(DefaultPolicy()), L2: shared, MaxStaleness: 20 * time.Millisecond,
+	})
+	nodeB, _ := NewTieredCache(TieredCacheConfig{
+		L1: NewMemoryCache(DefaultPolicy()), L2: shared, MaxStaleness: 20 * time.Millisecond,
+	})
+
+	_ = nodeA.Set(ctx, "k", []byte("old"), time.Hour)
+	if got, _ := nodeB.Get(ctx, "k"); string(got) != "old" {
+		t.Fatalf("nodeB Get() = %q, want \"old\"", got)
+	}
+
+	_ = nodeA.Set(ctx, "k", []byte("new"), time.Hour)
+	if got, _ := nodeB.Get(ctx, "k"); string(got) != "old" {
+		t.Errorf("nodeB Get() = %q, want stale \"old\" within bound", got)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if got, _ := nodeB.Get(ctx, "k"); string(got) != "new" {
+		t.Errorf("nodeB Get() = %q, want \"new\" after MaxStaleness", got)
+	}
+}
+
+func TestTieredCache_OnInvalidateBroadcast(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryCache(DefaultPolicy())
+	nodeB, _ := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy()), L2: shared})
+
+	var broadcast []string
+	nodeA, _ := NewTieredCache(TieredCacheConfig{
+		L1: NewMemoryCache(DefaultPolicy()),
+		L2: shared,
+		OnInvalidate: func(key string) {
+			broadcast = append(broadcast, key)
+			nodeB.Invalidate(key)
+		},
+	})
+
+	_ = nodeA.Set(ctx, "k", []byte("old"), time.Hour)
+	_, _ = nodeB.Get(ctx, "k")
+
+	_ = nodeA.Set(ctx, "k", []byte("new"), time.Hour)
+	if got, _ := nodeB.Get(ctx, "k"); string(got) != "new" {
+		t.Errorf("nodeB Get() = %q, want \"new\" after invalidation", got)
+	}
+
+	_ = nodeA.Delete(ctx, "k")
+	if _, ok := nodeB.Get(ctx, "k"); ok {
+		t.Error("nodeB Get() should miss after broadcast delete")
+	}
+
+	if len(broadcast) != 3 {
+		t.Errorf("OnInvalidate calls = %d, want 3", len(broadcast))
+	}
+}
+
+func TestTieredCache_BreakerOpensOnRemoteFailures(t *testing.T) {
+	ctx := context.Background()
+	remote := newFlakyRemote()
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{
+		MaxFailures:  2,
+		ResetTimeout: time.Hour,
+	})
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy()), L2: remote}, WithBreaker(cb))
+
+	remote.failing.Store(true)
+	for i := 0; i < 2; i++ {
+		if _, ok := tc.Get(ctx, "k"); ok {
+			t.Fatal("Get() should miss while remote is failing")
+		}
+	}
+
+	if !tc.BreakerOpen() {
+		t.Fatal("BreakerOpen() = false, want true")
+	}
+
+	before := remote.calls.Load()
+
+	if _, ok := tc.Get(ctx, "k"); ok {
+		t.Error("Get() should miss while breaker is open")
+	}
+	if err := tc.Set(ctx, "k", []byte("v"), time.Minute); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("Set() error = %v, want ErrBreakerOpen while breaker is open", err)
+	}
+	if got := remote.calls.Load(); got != before {
+		t.Errorf("remote calls = %d, want %d (short-circuited)", got, before)
+	}
+
+	// L1 keeps working during the outage
+	if got, ok := tc.Get(ctx, "k"); !ok || string(got) != "v" {
+		t.Errorf("Get() = %q, %v; want L1 value during outage", got, ok)
+	}
+}
+
+func TestTieredCache_BreakerSetErrorPropagatesWhileClosed(t *testing.T) {
+	ctx := context.Background()
+	remote := newFlakyRemote()
+	remote.failing.Store(true)
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{MaxFailures: 5})
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy()), L2: remote}, WithBreaker(cb))
+
+	if err := tc.Set(ctx, "k", []byte("v"), time.Minute); !errors.Is(err, errRemoteDown) {
+		t.Errorf("Set() error = %v, want errRemoteDown", err)
+	}
+	if tc.BreakerOpen() {
+		t.Error("BreakerOpen() = true, want false")
+	}
+}
+
+func TestTieredCache_BreakerOpenWithoutBreaker(t *testing.T) {
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy()), L2: NewMemoryCache(DefaultPolicy())})
+	if tc.BreakerOpen() {
+		t.Error("BreakerOpen() = true, want false")
+	}
+}
+
+func TestTieredCache_DeleteBreakerOpen(t *testing.T) {
+	ctx := context.Background()
+	remote := newFlakyRemote()
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Hour})
+	l1 := NewMemoryCache(DefaultPolicy())
+	var broadcast []string
+	tc, _ := NewTieredCache(TieredCacheConfig{
+		L1:           l1,
+		L2:           remote,
+		OnInvalidate: func(key string) { broadcast = append(broadcast, key) },
+	}, WithBreaker(cb))
+
+	_ = l1.Set(ctx, "k", []byte("v"), time.Minute)
+	remote.failing.Store(true)
+	_, _ = tc.Get(ctx, "missing") // trips the breaker
+
+	err := tc.Delete(ctx, "k")
+	if !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("Delete() error = %v, want ErrBreakerOpen", err)
+	}
+	if !errors.Is(err, resilience.ErrCircuitOpen) {
+		t.Errorf("Delete() error = %v, want the breaker's error wrapped", err)
+	}
+	if _, ok := l1.Get(ctx, "k"); ok {
+		t.Error("L1 Get() should miss after Delete")
+	}
+	if len(broadcast) != 0 {
+		t.Errorf("OnInvalidate calls = %d, want 0 when L2 was not updated", len(broadcast))
+	}
+}
+
+// fakeBreaker is a Breaker that rejects every call while open.
+type fakeBreaker struct{ open bool }
+
+func (b *fakeBreaker) Execute(ctx context.Context, op func(context.Context) error) error {
+	if b.open {
+		return errors.New("fake breaker open")
+	}
+	return op(ctx)
+}
+
+func (b *fakeBreaker) IsOpen() bool { return b.open }
+
+func TestTieredCache_CustomBreaker(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewMemoryCache(DefaultPolicy())
+	b := &fakeBreaker{}
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: NewMemoryCache(DefaultPolicy()), L2: l2}, WithBreaker(b))
+
+	if err := tc.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	b.open = true
+	if !tc.BreakerOpen() {
+		t.Error("BreakerOpen() = false, want true")
+	}
+	if err := tc.Set(ctx, "b", []byte("2"), time.Minute); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("Set() error = %v, want ErrBreakerOpen", err)
+	}
+	if _, ok := l2.Get(ctx, "b"); ok {
+		t.Error("L2 should not be written while the breaker is open")
+	}
+}
+
+func TestTieredCache_Clear(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewMemoryCache(DefaultPolicy())
+	l2 := NewMemoryCache(DefaultPolicy())
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+
+	_ = tc.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = tc.Set(ctx, "b", []byte("2"), time.Minute)
+
+	if err := tc.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if l1.Len() != 0 || l2.Len() != 0 {
+		t.Errorf("Len() = %d/%d, want 0/0", l1.Len(), l2.Len())
+	}
+}
+
+func TestTieredCache_ClearBreakerOpen(t *testing.T) {
+	ctx := context.Background()
+	remote := newFlakyRemote()
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Hour})
+	l1 := NewMemoryCache(DefaultPolicy())
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: l1, L2: remote}, WithBreaker(cb))
+
+	_ = l1.Set(ctx, "a", []byte("1"), time.Minute)
+	remote.failing.Store(true)
+	_, _ = tc.Get(ctx, "missing") // trips the breaker
+
+	if err := tc.Clear(ctx); !errors.Is(err, ErrBreakerOpen) || !errors.Is(err, resilience.ErrCircuitOpen) {
+		t.Errorf("Clear() error = %v, want ErrBreakerOpen wrapping ErrCircuitOpen", err)
+	}
+	if l1.Len() != 0 {
+		t.Errorf("L1 Len() = %d, want 0", l1.Len())
+	}
+}
+
+func TestTieredCache_WriteThroughAndDelete(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewMemoryCache(DefaultPolicy())
+	l2 := NewMemoryCache(DefaultPolicy())
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+
+	if err := tc.Set(ctx, "k", []byte("v"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	for name, tier := range map[string]*MemoryCache{"L1": l1, "L2": l2} {
+		if got, ok := tier.Get(ctx, "k"); !ok || string(got) != "v" {
+			t.Errorf("%s Get() = %q, %v; want written through", name, got, ok)
+		}
+	}
+
+	if err := tc.Delete(ctx, "k"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	for name, tier := range map[string]*MemoryCache{"L1": l1, "L2": l2} {
+		if _, ok := tier.Get(ctx, "k"); ok {
+			t.Errorf("%s Get() hit after Delete", name)
+		}
+	}
+}
+
+func TestTieredCache_PromotionBoundedByL2TTL(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewMemoryCache(DefaultPolicy())
+	l2 := NewMemoryCache(DefaultPolicy())
+	tc, _ := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2, MaxStaleness: time.Minute})
+
+	_ = l2.Set(ctx, "k", []byte("v"), 30*time.Millisecond)
+	if _, ok := tc.Get(ctx, "k"); !ok {
+		t.Fatal("expected L2 hit")
+	}
+
+	_, ttl, ok := l1.GetWithTTL(ctx, "k")
+	if !ok {
+		t.Fatal("L2 hit should populate L1")
+	}
+	if ttl > 30*time.Millisecond {
+		t.Errorf("promoted L1 TTL = %v, want at most the L2 remaining TTL", ttl)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := tc.Get(ctx, "k"); ok {
+		t.Error("promoted entry outlived its L2 entry")
+	}
+}
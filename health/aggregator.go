@@ -2,10 +2,47 @@ package health
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// AggregateLatencyCheck is the name of the synthetic result CheckAll adds
+// when AggregatorConfig.TotalBudget is exceeded.
+const AggregateLatencyCheck = "__aggregate_latency__"
+
+// AggregationStrategy selects how OverallStatus combines check results.
+type AggregationStrategy int
+
+const (
+	// AggregateWorstCase reports the worst status of any check: one
+	// Unhealthy critical check makes the overall status Unhealthy.
+	AggregateWorstCase AggregationStrategy = iota
+	// AggregateWeighted reports a status from the weighted fraction of
+	// failing checks, compared against AggregatorConfig.UnhealthyThreshold
+	// and DegradedThreshold, so one minor check flapping among many does
+	// not fail the whole service.
+	AggregateWeighted
+)
+
+// String returns the string representation of the strategy.
+func (s AggregationStrategy) String() string {
+	switch s {
+	case AggregateWorstCase:
+		return "worst-case"
+	case AggregateWeighted:
+		return "weighted"
+	default:
+		return "unknown"
+	}
+}
+
+// errFailFast is the cancellation cause when FailFast stops a CheckAll.
+var errFailFast = errors.New("health: stopped after unhealthy check")
+
 // AggregatorConfig configures the health aggregator.
 type AggregatorConfig struct {
 	// Timeout is the maximum time to wait for all checks.
@@ -15,14 +52,102 @@ type AggregatorConfig struct {
 	// Parallel runs health checks in parallel when true.
 	// Default: true
 	Parallel bool
+
+	// MaxConcurrency bounds the number of checks run at once when Parallel
+	// is true. Checks beyond the limit wait for a free worker.
+	// Default: 0 (unlimited, one goroutine per checker)
+	MaxConcurrency int
+
+	// FailFast stops CheckAll at the first Unhealthy result from a critical
+	// checker (see CheckerOptions). Sequential checks after it are skipped;
+	// in parallel mode the context passed to in-flight checks is cancelled.
+	// The result map is partial, trading completeness for speed; its
+	// overall status is still Unhealthy. Ignored with AggregateWeighted,
+	// where one failure does not decide the overall status.
+	// Default: false (run every check)
+	FailFast bool
+
+	// Strategy selects how OverallStatus combines results.
+	// Default: AggregateWorstCase
+	Strategy AggregationStrategy
+
+	// UnhealthyThreshold is, for AggregateWeighted, the weighted fraction
+	// of Unhealthy critical checks at or above which the overall status is
+	// Unhealthy. Value should be between 0 and 1. Default: 0.5 (50%)
+	UnhealthyThreshold float64
+
+	// DegradedThreshold is, for AggregateWeighted, the weighted fraction of
+	// checks that are not Healthy at or above which the overall status is
+	// Degraded. Value should be between 0 and 1. Default: 0.1 (10%)
+	DegradedThreshold float64
+
+	// TotalBudget is the wall-clock budget for a whole CheckAll. When it is
+	// exceeded, the results include an AggregateLatencyCheck entry with the
+	// measured total, flagging systemic slowness even when every check is
+	// individually within its timeout.
+	// Default: 0 (no budget)
+	TotalBudget time.Duration
+
+	// TotalBudgetUnhealthy reports an exceeded TotalBudget as Unhealthy
+	// instead of Degraded, so readiness fails.
+	// Default: false (Degraded)
+	TotalBudgetUnhealthy bool
+
+	// CacheTTL makes CheckAll reuse the last results while they are younger
+	// than the TTL, so frequent probes do not re-run expensive checks on
+	// every request. Concurrent calls while results are being refreshed
+	// share a single run. Check is never cached.
+	// Default: 0 (no caching)
+	CacheTTL time.Duration
+
+	// HistorySize is the number of recent results kept per registered
+	// checker for History and the detailed endpoint, oldest dropped first.
+	// Default: 0 (no history)
+	HistorySize int
+}
+
+// CheckerOptions configures how a registered checker affects overall status.
+type CheckerOptions struct {
+	// Critical makes an Unhealthy result from this checker make the overall
+	// status Unhealthy. A non-critical checker's Unhealthy result, such as
+	// from an optional cache, only makes the overall status Degraded.
+	// Checkers added with Register are critical.
+	Critical bool
+
+	// Weight is the checker's share of the total under AggregateWeighted;
+	// a checker with Weight 2 counts as much as two with Weight 1. Ignored
+	// by AggregateWorstCase.
+	// Default: 1
+	Weight float64
 }
 
+// defaultCheckerOptions apply to checkers added with Register and to
+// results, such as AggregateLatencyCheck, that have no registered checker.
+var defaultCheckerOptions = CheckerOptions{Critical: true, Weight: 1}
+
 // Aggregator combines multiple health checkers into a single composite check.
 type Aggregator struct {
 	config   AggregatorConfig
 	mu       sync.RWMutex
 	checkers map[string]Checker
-	order    []string // Maintains registration order
+	order    []string                           // Maintains registration order
+	options  map[string]CheckerOptions          // Per-checker options, defaults applied
+	onResult []func(name string, result Result) // Added by OnResult
+	started  atomic.Bool                        // Set by MarkStarted
+
+	cacheMu  sync.Mutex
+	cached   map[string]Result // Last CheckAll results when CacheTTL is set
+	cachedAt time.Time
+	inflight *checkAllCall // Run in progress that concurrent callers share
+
+	historyMu sync.Mutex
+	history   map[string]*resultRing // Recent results when HistorySize is set
+}
+
+// checkAllCall is a CheckAll run shared by concurrent callers.
+type checkAllCall struct {
+	done    chan struct{}
+	results map[string]Result
 }
 
 // NewAggregator creates a new health aggregator.
@@ -36,17 +161,38 @@ func NewAggregator(config ...AggregatorConfig) *Aggregator {
 		if cfg.Timeout <= 0 {
 			cfg.Timeout = 10 * time.Second
 		}
+		if cfg.MaxConcurrency < 0 {
+			cfg.MaxConcurrency = 0
+		}
+		if cfg.HistorySize < 0 {
+			cfg.HistorySize = 0
+		}
+	}
+	if cfg.UnhealthyThreshold <= 0 || cfg.UnhealthyThreshold > 1 {
+		cfg.UnhealthyThreshold = 0.5
+	}
+	if cfg.DegradedThreshold <= 0 || cfg.DegradedThreshold > 1 {
+		cfg.DegradedThreshold = 0.1
 	}
 
 	return &Aggregator{
 		config:   cfg,
 		checkers: make(map[string]Checker),
 		order:    make([]string, 0),
+		options:  make(map[string]CheckerOptions),
+		history:  make(map[string]*resultRing),
 	}
 }
 
-// Register adds a health checker to the aggregator.
+// Register adds a critical health checker with weight 1 to the aggregator.
 func (a *Aggregator) Register(name string, checker Checker) {
+	a.RegisterWithOptions(name, checker, defaultCheckerOptions)
+}
+
+// RegisterWithOptions adds a health checker to the aggregator with the
+// given options, replacing any checker and options already registered
+// under name.
+func (a *Aggregator) RegisterWithOptions(name string, checker Checker, opts CheckerOptions) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -54,6 +200,12 @@ func (a *Aggregator) Register(name string, checker Checker) {
 		a.order = append(a.order, name)
 	}
 	a.checkers[name] = checker
+	if opts.Weight <= 0 {
+		opts.Weight = 1
+	}
+	a.options[name] = opts
+	a.invalidateCache()
+	a.clearHistory(name)
 }
 
 // Unregister removes a health checker from the aggregator.
@@ -62,6 +214,7 @@ func (a *Aggregator) Unregister(name string) {
 	defer a.mu.Unlock()
 
 	delete(a.checkers, name)
+	delete(a.options, name)
 
 	// Remove from order
 	for i, n := range a.order {
@@ -70,6 +223,30 @@ func (a *Aggregator) Unregister(name string) {
 			break
 		}
 	}
+	a.invalidateCache()
+	a.clearHistory(name)
+}
+
+// invalidateCache discards cached CheckAll results so the next call sees
+// the current set of checkers. A run already in progress still answers its
+// callers but is not cached.
+func (a *Aggregator) invalidateCache() {
+	a.cacheMu.Lock()
+	a.cached = nil
+	a.inflight = nil
+	a.cacheMu.Unlock()
+}
+
+// MarkStarted records that initialization has completed. Until it is
+// called, StartupHandler responds 503; afterwards it reports readiness.
+// Calling it more than once has no further effect.
+func (a *Aggregator) MarkStarted() {
+	a.started.Store(true)
+}
+
+// Started reports whether MarkStarted has been called.
+func (a *Aggregator) Started() bool {
+	return a.started.Load()
 }
 
 // CheckerNames returns the names of all registered checkers.
@@ -92,68 +269,323 @@ func (a *Aggregator) Check(ctx context.Context, name string) (Result, error) {
 		return Result{}, ErrCheckerNotFound
 	}
 
-	return a.runCheck(ctx, checker), nil
+	result := a.runCheck(ctx, checker)
+	a.observe(name, result)
+	return result, nil
+}
+
+// OnResult adds fn to the functions called with each result a registered
+// checker produces, from both Check and CheckAll, such as to export
+// metrics. Cached CheckAll results do not trigger it again. fn is called
+// without locks held, possibly concurrently from parallel checks.
+func (a *Aggregator) OnResult(fn func(name string, result Result)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.onResult = append(a.onResult, fn)
+}
+
+// observe records a checker's result in its history and passes it to the
+// OnResult functions.
+func (a *Aggregator) observe(name string, result Result) {
+	a.recordHistory(name, result)
+
+	a.mu.RLock()
+	onResult := a.onResult
+	a.mu.RUnlock()
+
+	for _, fn := range onResult {
+		fn(name, result)
+	}
+}
+
+// History returns the most recent results of the named checker, oldest
+// first, from both Check and CheckAll. Cached CheckAll results are not
+// recorded again. It returns nil when AggregatorConfig.HistorySize is zero
+// or the checker has not run since it was registered.
+func (a *Aggregator) History(name string) []Result {
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	ring, ok := a.history[name]
+	if !ok {
+		return nil
+	}
+	return ring.results()
+}
+
+// recordHistory appends result to the named checker's history.
+func (a *Aggregator) recordHistory(name string, result Result) {
+	if a.config.HistorySize == 0 {
+		return
+	}
+
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	ring, ok := a.history[name]
+	if !ok {
+		ring = &resultRing{buf: make([]Result, 0, a.config.HistorySize)}
+		a.history[name] = ring
+	}
+	ring.add(result)
+}
+
+// clearHistory discards the named checker's history.
+func (a *Aggregator) clearHistory(name string) {
+	a.historyMu.Lock()
+	delete(a.history, name)
+	a.historyMu.Unlock()
+}
+
+// resultRing is a fixed-capacity ring buffer of results.
+type resultRing struct {
+	buf  []Result
+	next int // Index of the oldest result once buf is full
+}
+
+// add appends r, overwriting the oldest result when full.
+func (r *resultRing) add(result Result) {
+	if len(r.buf) < cap(r.buf) {
+		r.buf = append(r.buf, result)
+		return
+	}
+	r.buf[r.next] = result
+	r.next = (r.next + 1) % len(r.buf)
+}
+
+// results returns a copy of the buffer, oldest first.
+func (r *resultRing) results() []Result {
+	out := make([]Result, 0, len(r.buf))
+	out = append(out, r.buf[r.next:]...)
+	return append(out, r.buf[:r.next]...)
 }
 
 // CheckAll runs all registered health checks and returns the results.
+//
+// With FailFast set, the returned map may be partial: it stops at the first
+// Unhealthy result from a critical checker and omits checks that were
+// skipped or cancelled.
+//
+// With CacheTTL set, results younger than the TTL are returned without
+// running any checks.
 func (a *Aggregator) CheckAll(ctx context.Context) map[string]Result {
+	if a.config.CacheTTL > 0 {
+		return a.checkAllCached(ctx)
+	}
+	return a.checkAll(ctx)
+}
+
+// checkAllCached serves CheckAll from the cache, refreshing stale results
+// with one run shared by every concurrent caller. The shared run ignores
+// the cancellation of the caller that started it; a caller whose context
+// ends first gets a timeout result for each checker.
+func (a *Aggregator) checkAllCached(ctx context.Context) map[string]Result {
+	a.cacheMu.Lock()
+	if a.cached != nil && time.Since(a.cachedAt) < a.config.CacheTTL {
+		results := maps.Clone(a.cached)
+		a.cacheMu.Unlock()
+		return results
+	}
+	call := a.inflight
+	if call == nil {
+		call = &checkAllCall{done: make(chan struct{})}
+		a.inflight = call
+		go a.refresh(context.WithoutCancel(ctx), call)
+	}
+	a.cacheMu.Unlock()
+
+	start := time.Now()
+	select {
+	case <-call.done:
+		return maps.Clone(call.results)
+	case <-ctx.Done():
+		names := a.CheckerNames()
+		results := make(map[string]Result, len(names))
+		for _, name := range names {
+			results[name] = timeoutResult(start)
+		}
+		return results
+	}
+}
+
+// refresh runs every check for call and caches the results.
+func (a *Aggregator) refresh(ctx context.Context, call *checkAllCall) {
+	results := a.checkAll(ctx)
+
+	a.cacheMu.Lock()
+	if a.inflight == call {
+		a.cached = results
+		a.cachedAt = time.Now()
+		a.inflight = nil
+	}
+	a.cacheMu.Unlock()
+
+	call.results = results
+	close(call.done)
+}
+
+// checkAll runs every registered check.
+func (a *Aggregator) checkAll(ctx context.Context) map[string]Result {
 	a.mu.RLock()
+	names := make([]string, len(a.order))
+	copy(names, a.order)
 	checkers := make(map[string]Checker, len(a.checkers))
 	for name, checker := range a.checkers {
 		checkers[name] = checker
 	}
+	options := maps.Clone(a.options)
 	a.mu.RUnlock()
 
 	if len(checkers) == 0 {
 		return make(map[string]Result)
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
 	defer cancel()
 
+	failFast := a.config.FailFast && a.config.Strategy == AggregateWorstCase
+	stop := func(error) {}
+	if failFast {
+		var cancelCause context.CancelCauseFunc
+		ctx, cancelCause = context.WithCancelCause(ctx)
+		defer cancelCause(nil)
+		stop = cancelCause
+	}
+
 	results := make(map[string]Result, len(checkers))
+	var mu sync.Mutex
+	stopped := false
+	record := func(name string, result Result) {
+		mu.Lock()
+		if stopped {
+			mu.Unlock()
+			return
+		}
+		results[name] = result
+		if failFast && result.Status == StatusUnhealthy && options[name].Critical {
+			stopped = true
+			stop(errFailFast)
+		}
+		mu.Unlock()
 
-	if a.config.Parallel {
+		a.observe(name, result)
+	}
+
+	if a.config.Parallel && a.config.MaxConcurrency > 0 && a.config.MaxConcurrency < len(checkers) {
+		a.checkAllPooled(ctx, names, checkers, record)
+	} else if a.config.Parallel {
 		var wg sync.WaitGroup
-		var mu sync.Mutex
 
-		for name, checker := range checkers {
+		for _, name := range names {
 			wg.Add(1)
 			go func(name string, checker Checker) {
 				defer wg.Done()
-				result := a.runCheck(ctx, checker)
-				mu.Lock()
-				results[name] = result
-				mu.Unlock()
-			}(name, checker)
+				record(name, a.runCheck(ctx, checker))
+			}(name, checkers[name])
 		}
 
 		wg.Wait()
 	} else {
-		for name, checker := range checkers {
-			results[name] = a.runCheck(ctx, checker)
+		for _, name := range names {
+			if errors.Is(context.Cause(ctx), errFailFast) {
+				break
+			}
+			record(name, a.runCheck(ctx, checkers[name]))
 		}
 	}
 
+	if total := time.Since(start); a.config.TotalBudget > 0 && total > a.config.TotalBudget {
+		mu.Lock()
+		results[AggregateLatencyCheck] = a.budgetResult(start, total)
+		mu.Unlock()
+	}
+
 	return results
 }
 
+// budgetResult is the synthetic result reported when CheckAll exceeds
+// TotalBudget.
+func (a *Aggregator) budgetResult(start time.Time, total time.Duration) Result {
+	message := fmt.Sprintf("checks took %s, over budget %s", total, a.config.TotalBudget)
+	result := Degraded(message)
+	if a.config.TotalBudgetUnhealthy {
+		result = Unhealthy(message, ErrLatencyBudgetExceeded)
+	}
+	result.Duration = total
+	result.Timestamp = start
+	return result.WithDetails(map[string]any{
+		"total_ms":  total.Milliseconds(),
+		"budget_ms": a.config.TotalBudget.Milliseconds(),
+	})
+}
+
+// checkAllPooled runs checks on a fixed pool of MaxConcurrency workers.
+// Checks still queued when ctx is done are reported as timed out without
+// being started.
+func (a *Aggregator) checkAllPooled(ctx context.Context, names []string, checkers map[string]Checker, record func(string, Result)) {
+	type job struct {
+		name    string
+		checker Checker
+	}
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+
+	for i := 0; i < a.config.MaxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if ctx.Err() != nil {
+					record(j.name, timeoutResult(time.Now()))
+					continue
+				}
+				record(j.name, a.runCheck(ctx, j.checker))
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- job{name: name, checker: checkers[name]}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
 // OverallStatus computes the overall health status from a set of results.
-// Returns Unhealthy if any check is unhealthy.
-// Returns Degraded if any check is degraded but none are unhealthy.
+//
+// With AggregateWorstCase (the default):
+// Returns Unhealthy if any critical check is unhealthy.
+// Returns Degraded if any check is degraded, or a non-critical check is
+// unhealthy, but no critical check is unhealthy.
 // Returns Healthy if all checks are healthy.
+//
+// With AggregateWeighted, see weightedStatus.
 func (a *Aggregator) OverallStatus(results map[string]Result) Status {
 	if len(results) == 0 {
 		return StatusHealthy
 	}
 
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.config.Strategy == AggregateWeighted {
+		return a.weightedStatus(results)
+	}
+
 	hasUnhealthy := false
 	hasDegraded := false
 
-	for _, result := range results {
+	for name, result := range results {
 		switch result.Status {
 		case StatusUnhealthy:
-			hasUnhealthy = true
+			if a.optionsFor(name).Critical {
+				hasUnhealthy = true
+			} else {
+				hasDegraded = true
+			}
 		case StatusDegraded:
 			hasDegraded = true
 		}
@@ -168,6 +600,46 @@ func (a *Aggregator) OverallStatus(results map[string]Result) Status {
 	return StatusHealthy
 }
 
+// weightedStatus returns Unhealthy when the weighted fraction of Unhealthy
+// critical checks reaches UnhealthyThreshold, Degraded when the weighted
+// fraction of checks that are not Healthy reaches DegradedThreshold, and
+// Healthy otherwise. An Unhealthy non-critical check counts as Degraded.
+// The caller must hold a.mu.
+func (a *Aggregator) weightedStatus(results map[string]Result) Status {
+	var total, unhealthy, degraded float64
+	for name, result := range results {
+		opts := a.optionsFor(name)
+		total += opts.Weight
+		switch result.Status {
+		case StatusUnhealthy:
+			if opts.Critical {
+				unhealthy += opts.Weight
+			} else {
+				degraded += opts.Weight
+			}
+		case StatusDegraded:
+			degraded += opts.Weight
+		}
+	}
+
+	if unhealthy/total >= a.config.UnhealthyThreshold {
+		return StatusUnhealthy
+	}
+	if (unhealthy+degraded)/total >= a.config.DegradedThreshold {
+		return StatusDegraded
+	}
+	return StatusHealthy
+}
+
+// optionsFor returns the options for the named checker. The caller must
+// hold a.mu.
+func (a *Aggregator) optionsFor(name string) CheckerOptions {
+	if opts, ok := a.options[name]; ok {
+		return opts
+	}
+	return defaultCheckerOptions
+}
+
 func (a *Aggregator) runCheck(ctx context.Context, checker Checker) Result {
 	start := time.Now()
 
@@ -175,7 +647,8 @@ func (a *Aggregator) runCheck(ctx context.Context, checker Checker) Result {
 	resultCh := make(chan Result, 1)
 
 	go func() {
-		result := checker.Check(ctx)
+		// Recover so a buggy checker cannot crash this goroutine
+		result := Recover(checker).Check(ctx)
 		result.Duration = time.Since(start)
 		if result.Timestamp.IsZero() {
 			result.Timestamp = start
@@ -187,13 +660,18 @@ func (a *Aggregator) runCheck(ctx context.Context, checker Checker) Result {
 	case result := <-resultCh:
 		return result
 	case <-ctx.Done():
-		return Result{
-			Status:    StatusUnhealthy,
-			Message:   "check timed out",
-			Error:     ErrCheckTimeout,
-			Duration:  time.Since(start),
-			Timestamp: start,
-		}
+		return timeoutResult(start)
+	}
+}
+
+// timeoutResult is the result reported for a check cut off by the deadline.
+func timeoutResult(start time.Time) Result {
+	return Result{
+		Status:    StatusUnhealthy,
+		Message:   "check timed out",
+		Error:     ErrCheckTimeout,
+		Duration:  time.Since(start),
+		Timestamp: start,
 	}
 }
 
@@ -2,6 +2,10 @@ package health
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -15,6 +19,15 @@ func TestNewAggregator(t *testing.T) {
 	if !agg.config.Parallel {
 		t.Error("Default Parallel should be true")
 	}
+	if agg.config.Strategy != AggregateWorstCase {
+		t.Errorf("Default Strategy = %v, want worst-case", agg.config.Strategy)
+	}
+	if agg.config.UnhealthyThreshold != 0.5 {
+		t.Errorf("Default UnhealthyThreshold = %v, want 0.5", agg.config.UnhealthyThreshold)
+	}
+	if agg.config.DegradedThreshold != 0.1 {
+		t.Errorf("Default DegradedThreshold = %v, want 0.1", agg.config.DegradedThreshold)
+	}
 }
 
 func TestNewAggregator_WithConfig(t *testing.T) {
@@ -166,6 +179,478 @@ func TestAggregator_CheckAllTimeout(t *testing.T) {
 	}
 }
 
+func TestAggregator_CheckAllMaxConcurrency(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:        5 * time.Second,
+		Parallel:       true,
+		MaxConcurrency: 3,
+	})
+
+	var active, peak atomic.Int32
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("check%d", i)
+		agg.Register(name, NewCheckerFunc(name, func(ctx context.Context) Result {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+			return Healthy("ok")
+		}))
+	}
+
+	results := agg.CheckAll(context.Background())
+
+	if len(results) != 20 {
+		t.Fatalf("len(results) = %d, want 20", len(results))
+	}
+	for name, result := range results {
+		if result.Status != StatusHealthy {
+			t.Errorf("%s status = %v, want StatusHealthy", name, result.Status)
+		}
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", got)
+	}
+}
+
+func TestAggregator_CheckAllMaxConcurrencyTimeout(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:        50 * time.Millisecond,
+		Parallel:       true,
+		MaxConcurrency: 1,
+	})
+
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("slow%d", i)
+		agg.Register(name, NewCheckerFunc(name, func(ctx context.Context) Result {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			return Healthy("ok")
+		}))
+	}
+
+	start := time.Now()
+	results := agg.CheckAll(context.Background())
+	elapsed := time.Since(start)
+
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("CheckAll took %v, want prompt return after timeout", elapsed)
+	}
+	if len(results) != 4 {
+		t.Fatalf("len(results) = %d, want 4", len(results))
+	}
+	for name, result := range results {
+		if result.Error != ErrCheckTimeout {
+			t.Errorf("%s error = %v, want ErrCheckTimeout", name, result.Error)
+		}
+	}
+}
+
+func TestAggregator_FailFastSequential(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: false,
+		FailFast: true,
+	})
+
+	var laterRan atomic.Bool
+	agg.Register("first", NewCheckerFunc("first", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.Register("broken", NewCheckerFunc("broken", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	agg.Register("later", NewCheckerFunc("later", func(ctx context.Context) Result {
+		laterRan.Store(true)
+		return Healthy("ok")
+	}))
+
+	results := agg.CheckAll(context.Background())
+
+	if laterRan.Load() {
+		t.Error("checker after the unhealthy one should not run")
+	}
+	if len(results) != 2 {
+		t.Errorf("len(results) = %d, want 2", len(results))
+	}
+	if _, ok := results["later"]; ok {
+		t.Error("results should not include skipped checker")
+	}
+	if status := agg.OverallStatus(results); status != StatusUnhealthy {
+		t.Errorf("OverallStatus = %v, want StatusUnhealthy", status)
+	}
+}
+
+func TestAggregator_FailFastParallelCancelsInFlight(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  5 * time.Second,
+		Parallel: true,
+		FailFast: true,
+	})
+
+	cancelled := make(chan struct{})
+	agg.Register("broken", NewCheckerFunc("broken", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	agg.Register("slow", NewCheckerFunc("slow", func(ctx context.Context) Result {
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+			return Unhealthy("cancelled", ctx.Err())
+		case <-time.After(5 * time.Second):
+			return Healthy("ok")
+		}
+	}))
+
+	start := time.Now()
+	results := agg.CheckAll(context.Background())
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CheckAll took %v, want fail-fast return", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("in-flight checker context was not cancelled")
+	}
+	if results["broken"].Status != StatusUnhealthy {
+		t.Errorf("broken status = %v, want StatusUnhealthy", results["broken"].Status)
+	}
+	if _, ok := results["slow"]; ok {
+		t.Error("results should not include cancelled checker")
+	}
+}
+
+func TestAggregator_CheckAllRecoversPanics(t *testing.T) {
+	for _, parallel := range []bool{true, false} {
+		t.Run(fmt.Sprintf("parallel=%v", parallel), func(t *testing.T) {
+			agg := NewAggregator(AggregatorConfig{Parallel: parallel})
+			agg.Register("ok", NewCheckerFunc("ok", func(ctx context.Context) Result {
+				return Healthy("ok")
+			}))
+			agg.Register("buggy", NewCheckerFunc("buggy", func(ctx context.Context) Result {
+				panic("nil map write")
+			}))
+
+			results := agg.CheckAll(context.Background())
+
+			buggy := results["buggy"]
+			if buggy.Status != StatusUnhealthy {
+				t.Errorf("buggy Status = %v, want StatusUnhealthy", buggy.Status)
+			}
+			if !errors.Is(buggy.Error, ErrCheckPanic) {
+				t.Errorf("buggy Error = %v, want ErrCheckPanic", buggy.Error)
+			}
+			if buggy.Details["panic"] != "nil map write" {
+				t.Errorf("Details[panic] = %v, want 'nil map write'", buggy.Details["panic"])
+			}
+			if stack, _ := buggy.Details["stack"].(string); stack == "" {
+				t.Error("Details[stack] should not be empty")
+			}
+			if results["ok"].Status != StatusHealthy {
+				t.Errorf("ok Status = %v, want StatusHealthy", results["ok"].Status)
+			}
+			if got := agg.OverallStatus(results); got != StatusUnhealthy {
+				t.Errorf("OverallStatus() = %v, want StatusUnhealthy", got)
+			}
+		})
+	}
+}
+
+func TestAggregator_CheckRecoversPanic(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("buggy", NewCheckerFunc("buggy", func(ctx context.Context) Result {
+		panic(errors.New("boom"))
+	}))
+
+	result, err := agg.Check(context.Background(), "buggy")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.Status != StatusUnhealthy || !errors.Is(result.Error, ErrCheckPanic) {
+		t.Errorf("Check() = %v, %v; want Unhealthy with ErrCheckPanic", result.Status, result.Error)
+	}
+}
+
+func TestAggregator_TotalBudget(t *testing.T) {
+	// Each check finishes well within Timeout, but together they are slow
+	slow := func(ctx context.Context) Result {
+		time.Sleep(30 * time.Millisecond)
+		return Healthy("ok")
+	}
+
+	tests := []struct {
+		name      string
+		config    AggregatorConfig
+		want      Status
+		wantEntry bool
+	}{
+		{"under budget", AggregatorConfig{Timeout: time.Second, TotalBudget: time.Second}, StatusHealthy, false},
+		{"over budget", AggregatorConfig{Timeout: time.Second, TotalBudget: 50 * time.Millisecond}, StatusDegraded, true},
+		{"over budget unhealthy", AggregatorConfig{Timeout: time.Second, TotalBudget: 50 * time.Millisecond, TotalBudgetUnhealthy: true}, StatusUnhealthy, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregator(tt.config)
+			for i := 0; i < 3; i++ {
+				name := fmt.Sprintf("check%d", i)
+				agg.Register(name, NewCheckerFunc(name, slow))
+			}
+
+			results := agg.CheckAll(context.Background())
+
+			entry, ok := results[AggregateLatencyCheck]
+			if ok != tt.wantEntry {
+				t.Fatalf("%s present = %v, want %v", AggregateLatencyCheck, ok, tt.wantEntry)
+			}
+			if ok {
+				if total, _ := entry.Details["total_ms"].(int64); total < 90 {
+					t.Errorf("Details[total_ms] = %v, want >= 90", entry.Details["total_ms"])
+				}
+				if entry.Details["budget_ms"] != int64(50) {
+					t.Errorf("Details[budget_ms] = %v, want 50", entry.Details["budget_ms"])
+				}
+			}
+			if got := agg.OverallStatus(results); got != tt.want {
+				t.Errorf("OverallStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_CacheTTLBurst(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: true,
+		CacheTTL: time.Hour,
+	})
+
+	var calls atomic.Int32
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return Healthy("ok")
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results := agg.CheckAll(context.Background())
+			if results["db"].Status != StatusHealthy {
+				t.Errorf("Status = %v, want StatusHealthy", results["db"].Status)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_ = agg.CheckAll(context.Background())
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("checker called %d times, want 1 within one TTL window", got)
+	}
+}
+
+func TestAggregator_CacheTTLExpires(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: true,
+		CacheTTL: 30 * time.Millisecond,
+	})
+
+	var calls atomic.Int32
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		calls.Add(1)
+		return Healthy("ok")
+	}))
+
+	_ = agg.CheckAll(context.Background())
+	_ = agg.CheckAll(context.Background())
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("checker called %d times before expiry, want 1", got)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	_ = agg.CheckAll(context.Background())
+	if got := calls.Load(); got != 2 {
+		t.Errorf("checker called %d times after expiry, want 2", got)
+	}
+}
+
+func TestAggregator_CacheInvalidatedOnRegister(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: true,
+		CacheTTL: time.Hour,
+	})
+	agg.Register("a", NewCheckerFunc("a", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	_ = agg.CheckAll(context.Background())
+
+	agg.Register("b", NewCheckerFunc("b", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	results := agg.CheckAll(context.Background())
+	if _, ok := results["b"]; !ok {
+		t.Fatal("results should include checker registered after caching")
+	}
+
+	agg.Unregister("b")
+	results = agg.CheckAll(context.Background())
+	if _, ok := results["b"]; ok {
+		t.Error("results should not include unregistered checker")
+	}
+}
+
+func TestAggregator_CacheCallerContextCancelled(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: true,
+		CacheTTL: time.Hour,
+	})
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	agg.Register("slow", NewCheckerFunc("slow", func(ctx context.Context) Result {
+		calls.Add(1)
+		<-release
+		return Healthy("ok")
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	results := agg.CheckAll(ctx)
+	if !errors.Is(results["slow"].Error, ErrCheckTimeout) {
+		t.Errorf("Error = %v, want ErrCheckTimeout", results["slow"].Error)
+	}
+
+	// The shared run continues and its results are cached
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		results = agg.CheckAll(context.Background())
+		if results["slow"].Status == StatusHealthy || time.Now().After(deadline) {
+			break
+		}
+	}
+	if results["slow"].Status != StatusHealthy {
+		t.Errorf("Status = %v, want StatusHealthy", results["slow"].Status)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("checker called %d times, want 1", got)
+	}
+}
+
+func TestAggregator_History(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{HistorySize: 3})
+
+	var runs atomic.Int32
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy(fmt.Sprintf("run-%d", runs.Add(1)))
+	}))
+
+	if got := agg.History("test"); got != nil {
+		t.Errorf("History() before any run = %v, want nil", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := agg.Check(context.Background(), "test"); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+	}
+	_ = agg.CheckAll(context.Background())
+	_ = agg.CheckAll(context.Background())
+
+	history := agg.History("test")
+	if len(history) != 3 {
+		t.Fatalf("len(History()) = %d, want 3", len(history))
+	}
+	for i, want := range []string{"run-3", "run-4", "run-5"} {
+		if history[i].Message != want {
+			t.Errorf("History()[%d].Message = %v, want %v", i, history[i].Message, want)
+		}
+		if history[i].Timestamp.IsZero() {
+			t.Errorf("History()[%d].Timestamp is zero", i)
+		}
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i].Timestamp.Before(history[i-1].Timestamp) {
+			t.Errorf("History() not ordered oldest first at %d", i)
+		}
+	}
+
+	// The returned slice is a copy
+	history[0].Message = "changed"
+	if agg.History("test")[0].Message != "run-3" {
+		t.Error("modifying History() result should not affect the aggregator")
+	}
+}
+
+func TestAggregator_HistoryDisabledAndCleared(t *testing.T) {
+	disabled := NewAggregator()
+	disabled.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	_ = disabled.CheckAll(context.Background())
+	if got := disabled.History("test"); got != nil {
+		t.Errorf("History() with HistorySize 0 = %v, want nil", got)
+	}
+
+	agg := NewAggregator(AggregatorConfig{HistorySize: 5})
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	_ = agg.CheckAll(context.Background())
+	if got := len(agg.History("test")); got != 1 {
+		t.Fatalf("len(History()) = %d, want 1", got)
+	}
+
+	agg.Unregister("test")
+	if got := agg.History("test"); got != nil {
+		t.Errorf("History() after Unregister = %v, want nil", got)
+	}
+}
+
+func TestAggregator_OnResult(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{CacheTTL: time.Hour})
+	agg.Register("a", NewCheckerFunc("a", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.Register("b", NewCheckerFunc("b", func(ctx context.Context) Result {
+		return Degraded("slow")
+	}))
+
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	agg.OnResult(func(name string, result Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[name]++
+	})
+
+	_ = agg.CheckAll(context.Background())
+	_ = agg.CheckAll(context.Background()) // Cached, not observed again
+	if _, err := agg.Check(context.Background(), "a"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if seen["a"] != 2 || seen["b"] != 1 {
+		t.Errorf("observed = %v, want a:2 b:1", seen)
+	}
+}
+
 func TestAggregator_OverallStatus(t *testing.T) {
 	agg := NewAggregator()
 
@@ -223,6 +708,211 @@ func TestAggregator_OverallStatus(t *testing.T) {
 	}
 }
 
+func TestAggregator_NonCriticalChecker(t *testing.T) {
+	down := NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	})
+	ok := NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	})
+
+	tests := []struct {
+		name     string
+		critical bool
+		want     Status
+	}{
+		{"critical unhealthy", true, StatusUnhealthy},
+		{"non-critical unhealthy", false, StatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregator()
+			agg.Register("db", ok)
+			agg.RegisterWithOptions("cache", down, CheckerOptions{Critical: tt.critical})
+
+			results := agg.CheckAll(context.Background())
+			if got := results["cache"].Status; got != StatusUnhealthy {
+				t.Errorf("cache Status = %v, want StatusUnhealthy", got)
+			}
+			if got := agg.OverallStatus(results); got != tt.want {
+				t.Errorf("OverallStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_NonCriticalDoesNotMaskCritical(t *testing.T) {
+	agg := NewAggregator()
+	agg.RegisterWithOptions("cache", NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}), CheckerOptions{})
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+
+	if got := agg.OverallStatus(agg.CheckAll(context.Background())); got != StatusUnhealthy {
+		t.Errorf("OverallStatus() = %v, want StatusUnhealthy", got)
+	}
+}
+
+func TestAggregator_ReregisterChangesCriticality(t *testing.T) {
+	agg := NewAggregator()
+	down := NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	})
+
+	agg.RegisterWithOptions("cache", down, CheckerOptions{})
+	agg.Register("cache", down)
+	if got := agg.OverallStatus(agg.CheckAll(context.Background())); got != StatusUnhealthy {
+		t.Errorf("after Register: OverallStatus() = %v, want StatusUnhealthy", got)
+	}
+
+	agg.Unregister("cache")
+	agg.RegisterWithOptions("cache", down, CheckerOptions{Critical: false})
+	if got := agg.OverallStatus(agg.CheckAll(context.Background())); got != StatusDegraded {
+		t.Errorf("after RegisterWithOptions: OverallStatus() = %v, want StatusDegraded", got)
+	}
+}
+
+func TestAggregator_FailFastIgnoresNonCritical(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: false,
+		FailFast: true,
+	})
+
+	var laterRan atomic.Bool
+	agg.RegisterWithOptions("cache", NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}), CheckerOptions{})
+	agg.Register("later", NewCheckerFunc("later", func(ctx context.Context) Result {
+		laterRan.Store(true)
+		return Healthy("ok")
+	}))
+
+	results := agg.CheckAll(context.Background())
+
+	if !laterRan.Load() {
+		t.Error("non-critical failure should not stop later checks")
+	}
+	if got := agg.OverallStatus(results); got != StatusDegraded {
+		t.Errorf("OverallStatus() = %v, want StatusDegraded", got)
+	}
+}
+
+func TestAggregator_WeightedVersusWorstCase(t *testing.T) {
+	healthy := func(name string) Checker {
+		return NewCheckerFunc(name, func(ctx context.Context) Result { return Healthy("ok") })
+	}
+
+	// One minor checker failing among twenty
+	results := make(map[string]Result, 20)
+	for i := 0; i < 19; i++ {
+		results[fmt.Sprintf("check-%d", i)] = Healthy("ok")
+	}
+	results["minor"] = Unhealthy("flapping", nil)
+
+	tests := []struct {
+		name   string
+		config AggregatorConfig
+		minor  CheckerOptions
+		want   Status
+	}{
+		{
+			name:   "worst-case fails on one check",
+			config: AggregatorConfig{},
+			minor:  CheckerOptions{Critical: true},
+			want:   StatusUnhealthy,
+		},
+		{
+			name:   "weighted tolerates one check",
+			config: AggregatorConfig{Strategy: AggregateWeighted},
+			minor:  CheckerOptions{Critical: true},
+			want:   StatusHealthy,
+		},
+		{
+			name:   "weighted counts heavy check",
+			config: AggregatorConfig{Strategy: AggregateWeighted},
+			minor:  CheckerOptions{Critical: true, Weight: 3},
+			want:   StatusDegraded,
+		},
+		{
+			name:   "weighted crosses unhealthy threshold",
+			config: AggregatorConfig{Strategy: AggregateWeighted},
+			minor:  CheckerOptions{Critical: true, Weight: 19},
+			want:   StatusUnhealthy,
+		},
+		{
+			name:   "weighted non-critical counts as degraded",
+			config: AggregatorConfig{Strategy: AggregateWeighted},
+			minor:  CheckerOptions{Critical: false, Weight: 19},
+			want:   StatusDegraded,
+		},
+		{
+			name:   "weighted custom thresholds",
+			config: AggregatorConfig{Strategy: AggregateWeighted, UnhealthyThreshold: 0.05},
+			minor:  CheckerOptions{Critical: true},
+			want:   StatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregator(tt.config)
+			for name := range results {
+				agg.Register(name, healthy(name))
+			}
+			agg.RegisterWithOptions("minor", healthy("minor"), tt.minor)
+
+			if got := agg.OverallStatus(results); got != tt.want {
+				t.Errorf("OverallStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_WeightedIgnoresFailFast(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{
+		Timeout:  time.Second,
+		Parallel: false,
+		FailFast: true,
+		Strategy: AggregateWeighted,
+	})
+
+	var laterRan atomic.Bool
+	agg.Register("broken", NewCheckerFunc("broken", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	agg.Register("later", NewCheckerFunc("later", func(ctx context.Context) Result {
+		laterRan.Store(true)
+		return Healthy("ok")
+	}))
+
+	_ = agg.CheckAll(context.Background())
+
+	if !laterRan.Load() {
+		t.Error("FailFast should not skip checks with AggregateWeighted")
+	}
+}
+
+func TestAggregationStrategy_String(t *testing.T) {
+	tests := []struct {
+		strategy AggregationStrategy
+		want     string
+	}{
+		{AggregateWorstCase, "worst-case"},
+		{AggregateWeighted, "weighted"},
+		{AggregationStrategy(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.strategy.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
 func TestAggregator_Checker(t *testing.T) {
 	agg := NewAggregator()
 
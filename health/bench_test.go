@@ -77,6 +77,34 @@ func BenchmarkAggregator_CheckAll_Parallel(b *testing.B) {
 	}
 }
 
+// BenchmarkAggregator_CheckAll_ManyCheckers compares unbounded and bounded
+// parallel aggregation across many checkers.
+func BenchmarkAggregator_CheckAll_ManyCheckers(b *testing.B) {
+	for _, maxConcurrency := range []int{0, 8, 32} {
+		b.Run(fmt.Sprintf("max=%d", maxConcurrency), func(b *testing.B) {
+			agg := NewAggregator(AggregatorConfig{
+				Timeout:        10 * time.Second,
+				Parallel:       true,
+				MaxConcurrency: maxConcurrency,
+			})
+
+			for i := 0; i < 500; i++ {
+				name := fmt.Sprintf("check%d", i)
+				agg.Register(name, NewCheckerFunc(name, func(ctx context.Context) Result {
+					return Healthy("ok")
+				}))
+			}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = agg.CheckAll(ctx)
+			}
+		})
+	}
+}
+
 // BenchmarkAggregator_OverallStatus measures status computation.
 func BenchmarkAggregator_OverallStatus(b *testing.B) {
 	agg := NewAggregator()
@@ -2,6 +2,8 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
 	"time"
 )
 
@@ -122,6 +124,36 @@ func (f *CheckerFunc) Check(ctx context.Context) Result {
 	return f.fn(ctx)
 }
 
+// Recover wraps a checker so that a panic during Check is reported as an
+// Unhealthy result instead of crashing the caller. The result's Error wraps
+// ErrCheckPanic, and Details carries the recovered value ("panic") and the
+// goroutine stack ("stack"). The Aggregator applies it to every checker.
+func Recover(checker Checker) Checker {
+	if _, ok := checker.(*recoverChecker); ok {
+		return checker
+	}
+	return &recoverChecker{Checker: checker}
+}
+
+// recoverChecker converts panics in the wrapped checker to Unhealthy results.
+type recoverChecker struct {
+	Checker
+}
+
+// Check performs the health check, recovering from panics.
+func (c *recoverChecker) Check(ctx context.Context) (result Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Unhealthy("check panicked", fmt.Errorf("%w: %v", ErrCheckPanic, r)).
+				WithDetails(map[string]any{
+					"panic": fmt.Sprint(r),
+					"stack": string(debug.Stack()),
+				})
+		}
+	}()
+	return c.Checker.Check(ctx)
+}
+
 // PingChecker is a simple checker that can be pinged.
 type PingChecker interface {
 	Checker
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jonwraymond/toolops/resilience"
+)
+
+// CircuitBreakerChecker adapts a circuit breaker to the Checker interface so
+// it can be registered with an Aggregator. A closed circuit reports Healthy,
+// a half-open circuit (probing for recovery) reports Degraded, and an open
+// circuit reports Unhealthy with resilience.ErrCircuitOpen, including one
+// forced open for maintenance. Details carry the breaker's state, whether
+// it is forced, and failure counts.
+func CircuitBreakerChecker(name string, cb *resilience.CircuitBreaker) Checker {
+	return NewCheckerFunc(name, func(ctx context.Context) Result {
+		if cb == nil {
+			return Unhealthy("circuit breaker is nil", ErrCheckFailed)
+		}
+
+		m := cb.Metrics()
+		details := map[string]any{
+			"state":     m.State.String(),
+			"failures":  m.Failures,
+			"successes": m.Successes,
+			"forced":    m.Forced,
+		}
+		if !m.LastFailure.IsZero() {
+			details["last_failure"] = m.LastFailure
+		}
+		if m.WindowRequests > 0 {
+			details["window_requests"] = m.WindowRequests
+			details["window_failures"] = m.WindowFailures
+		}
+
+		switch m.State {
+		case resilience.StateOpen:
+			return Unhealthy(
+				fmt.Sprintf("circuit open after %d failures", m.Failures),
+				resilience.ErrCircuitOpen,
+			).WithDetails(details)
+		case resilience.StateHalfOpen:
+			return Degraded("circuit half-open, probing for recovery").WithDetails(details)
+		default:
+			return Healthy("circuit closed").WithDetails(details)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolops/resilience"
+)
+
+// tripBreaker fails enough calls to open cb.
+func tripBreaker(t *testing.T, cb *resilience.CircuitBreaker, failures int) {
+	t.Helper()
+	errFail := errors.New("downstream failure")
+	for i := 0; i < failures; i++ {
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error { return errFail })
+	}
+}
+
+func TestCircuitBreakerChecker_States(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, cb *resilience.CircuitBreaker)
+		wantStatus Status
+		wantState  string
+		wantErr    error
+	}{
+		{
+			name:       "closed is healthy",
+			setup:      func(t *testing.T, cb *resilience.CircuitBreaker) {},
+			wantStatus: StatusHealthy,
+			wantState:  "closed",
+		},
+		{
+			name: "open is unhealthy",
+			setup: func(t *testing.T, cb *resilience.CircuitBreaker) {
+				tripBreaker(t, cb, 2)
+			},
+			wantStatus: StatusUnhealthy,
+			wantState:  "open",
+			wantErr:    resilience.ErrCircuitOpen,
+		},
+		{
+			name: "half-open is degraded",
+			setup: func(t *testing.T, cb *resilience.CircuitBreaker) {
+				tripBreaker(t, cb, 2)
+				time.Sleep(30 * time.Millisecond) // Past ResetTimeout
+			},
+			wantStatus: StatusDegraded,
+			wantState:  "half-open",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{
+				MaxFailures:  2,
+				ResetTimeout: 20 * time.Millisecond,
+			})
+			tt.setup(t, cb)
+
+			checker := CircuitBreakerChecker("payments", cb)
+			result := checker.Check(context.Background())
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if got := result.Details["state"]; got != tt.wantState {
+				t.Errorf("Details[state] = %v, want %q", got, tt.wantState)
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", result.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerChecker_Details(t *testing.T) {
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{
+		MaxFailures:  5,
+		ResetTimeout: time.Minute,
+	})
+	tripBreaker(t, cb, 3)
+
+	result := CircuitBreakerChecker("payments", cb).Check(context.Background())
+
+	if result.Status != StatusHealthy {
+		t.Errorf("Status = %v, want healthy below MaxFailures", result.Status)
+	}
+	if got := result.Details["failures"]; got != 3 {
+		t.Errorf("Details[failures] = %v, want 3", got)
+	}
+	if _, ok := result.Details["last_failure"]; !ok {
+		t.Error("Details missing last_failure")
+	}
+}
+
+func TestCircuitBreakerChecker_Name(t *testing.T) {
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{})
+	if got := CircuitBreakerChecker("payments", cb).Name(); got != "payments" {
+		t.Errorf("Name() = %q, want payments", got)
+	}
+}
+
+func TestCircuitBreakerChecker_Aggregator(t *testing.T) {
+	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{
+		MaxFailures:  1,
+		ResetTimeout: time.Minute,
+	})
+	tripBreaker(t, cb, 1)
+
+	agg := NewAggregator()
+	agg.Register("payments", CircuitBreakerChecker("payments", cb))
+
+	results := agg.CheckAll(context.Background())
+	if got := agg.OverallStatus(results); got != StatusUnhealthy {
+		t.Errorf("OverallStatus() = %v, want unhealthy with open circuit", got)
+	}
+}
+
+func TestCircuitBreakerChecker_NilBreaker(t *testing.T) {
+	result := CircuitBreakerChecker("payments", nil).Check(context.Background())
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want unhealthy", result.Status)
+	}
+}
@@ -0,0 +1,114 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// DiskCheckerConfig configures the disk usage health checker.
+type DiskCheckerConfig struct {
+	// Path is any path on the filesystem to check, such as a data volume's
+	// mount point.
+	// Default: "/"
+	Path string
+
+	// WarningThreshold is the fraction of used capacity that triggers degraded status.
+	// Value should be between 0 and 1. Default: 0.8 (80%)
+	WarningThreshold float64
+
+	// CriticalThreshold is the fraction of used capacity that triggers unhealthy status.
+	// Value should be between 0 and 1. Default: 0.95 (95%)
+	CriticalThreshold float64
+}
+
+// diskUsage is a filesystem's capacity in bytes.
+type diskUsage struct {
+	total     uint64 // Filesystem size
+	free      uint64 // Free space, including space reserved for root
+	available uint64 // Free space available to unprivileged users
+}
+
+// DiskChecker checks filesystem usage health.
+//
+// Usage is computed like df: used / (used + available), so space reserved
+// for root counts as unavailable.
+type DiskChecker struct {
+	config DiskCheckerConfig
+	statfs func(path string) (diskUsage, error) // Replaced in tests
+}
+
+// NewDiskChecker creates a new disk usage health checker.
+func NewDiskChecker(config DiskCheckerConfig) *DiskChecker {
+	if config.Path == "" {
+		config.Path = "/"
+	}
+	if config.WarningThreshold <= 0 || config.WarningThreshold >= 1 {
+		config.WarningThreshold = 0.8
+	}
+	if config.CriticalThreshold <= 0 || config.CriticalThreshold >= 1 {
+		config.CriticalThreshold = 0.95
+	}
+	if config.CriticalThreshold < config.WarningThreshold {
+		config.CriticalThreshold = config.WarningThreshold + 0.1
+		if config.CriticalThreshold > 1 {
+			config.CriticalThreshold = 0.99
+		}
+	}
+
+	return &DiskChecker{config: config, statfs: statfs}
+}
+
+// Name returns the name of this checker.
+func (d *DiskChecker) Name() string {
+	return "disk"
+}
+
+// Check performs the disk usage health check.
+func (d *DiskChecker) Check(ctx context.Context) Result {
+	// Check context first
+	select {
+	case <-ctx.Done():
+		return Unhealthy("context cancelled", ctx.Err())
+	default:
+	}
+
+	usage, err := d.statfs(d.config.Path)
+	if err != nil {
+		return Unhealthy(
+			fmt.Sprintf("disk stats unavailable for %s", d.config.Path),
+			fmt.Errorf("%w: %w", ErrCheckFailed, err),
+		).WithDetails(map[string]any{"path": d.config.Path})
+	}
+
+	used := usage.total - usage.free
+	capacity := used + usage.available
+	usageRatio := 0.0
+	if capacity > 0 {
+		usageRatio = float64(used) / float64(capacity)
+	}
+
+	details := map[string]any{
+		"path":            d.config.Path,
+		"total_bytes":     usage.total,
+		"used_bytes":      used,
+		"available_bytes": usage.available,
+		"usage_percent":   usageRatio * 100,
+	}
+
+	if usageRatio >= d.config.CriticalThreshold {
+		return Unhealthy(
+			fmt.Sprintf("disk usage critical on %s: %.1f%%", d.config.Path, usageRatio*100),
+			ErrCheckFailed,
+		).WithDetails(details)
+	}
+
+	if usageRatio >= d.config.WarningThreshold {
+		return Degraded(
+			fmt.Sprintf("disk usage high on %s: %.1f%%", d.config.Path, usageRatio*100),
+		).WithDetails(details)
+	}
+
+	return Healthy(
+		fmt.Sprintf("disk usage normal on %s: %.1f%%", d.config.Path, usageRatio*100),
+	).WithDetails(details)
+}
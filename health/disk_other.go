@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || freebsd)
+
+package health
+
+import (
+	"errors"
+	"runtime"
+)
+
+// statfs reports that disk usage is unavailable on this platform.
+func statfs(path string) (diskUsage, error) {
+	return diskUsage{}, errors.New("disk usage not supported on " + runtime.GOOS)
+}
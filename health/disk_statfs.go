@@ -0,0 +1,21 @@
+//go:build linux || darwin || freebsd
+
+package health
+
+import "syscall"
+
+// statfs reads filesystem usage for path.
+func statfs(path string) (diskUsage, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return diskUsage{}, err
+	}
+
+	// Field widths vary by platform; all fit in uint64
+	bsize := uint64(st.Bsize)
+	return diskUsage{
+		total:     uint64(st.Blocks) * bsize,
+		free:      uint64(st.Bfree) * bsize,
+		available: uint64(st.Bavail) * bsize,
+	}, nil
+}
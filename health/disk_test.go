@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubStatfs returns a stat function reporting a filesystem of total bytes
+// with used bytes consumed and no root reservation.
+func stubStatfs(total, used uint64) func(string) (diskUsage, error) {
+	return func(string) (diskUsage, error) {
+		return diskUsage{total: total, free: total - used, available: total - used}, nil
+	}
+}
+
+func TestNewDiskChecker(t *testing.T) {
+	checker := NewDiskChecker(DiskCheckerConfig{})
+
+	if checker.config.Path != "/" {
+		t.Errorf("Path = %q, want /", checker.config.Path)
+	}
+	if checker.config.WarningThreshold != 0.8 {
+		t.Errorf("WarningThreshold = %v, want 0.8", checker.config.WarningThreshold)
+	}
+	if checker.config.CriticalThreshold != 0.95 {
+		t.Errorf("CriticalThreshold = %v, want 0.95", checker.config.CriticalThreshold)
+	}
+	if checker.Name() != "disk" {
+		t.Errorf("Name() = %v, want 'disk'", checker.Name())
+	}
+}
+
+func TestDiskChecker_Thresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		used       uint64
+		wantStatus Status
+	}{
+		{"below warning", 50, StatusHealthy},
+		{"at warning", 80, StatusDegraded},
+		{"between thresholds", 90, StatusDegraded},
+		{"at critical", 95, StatusUnhealthy},
+		{"full", 100, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewDiskChecker(DiskCheckerConfig{Path: "/data"})
+			checker.statfs = stubStatfs(100, tt.used)
+
+			result := checker.Check(context.Background())
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (%s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if got := result.Details["used_bytes"]; got != tt.used {
+				t.Errorf("Details[used_bytes] = %v, want %d", got, tt.used)
+			}
+			if got := result.Details["total_bytes"]; got != uint64(100) {
+				t.Errorf("Details[total_bytes] = %v, want 100", got)
+			}
+			if got := result.Details["path"]; got != "/data" {
+				t.Errorf("Details[path] = %v, want /data", got)
+			}
+		})
+	}
+}
+
+func TestDiskChecker_RootReservation(t *testing.T) {
+	checker := NewDiskChecker(DiskCheckerConfig{})
+
+	// 90 of 100 bytes used, the last 10 reserved for root: 90% of total,
+	// but df reports 90 / (90 + 0) = 100% for unprivileged users
+	checker.statfs = func(string) (diskUsage, error) {
+		return diskUsage{total: 100, free: 10, available: 0}, nil
+	}
+
+	result := checker.Check(context.Background())
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want unhealthy (%s)", result.Status, result.Message)
+	}
+	if got := result.Details["available_bytes"]; got != uint64(0) {
+		t.Errorf("Details[available_bytes] = %v, want 0", got)
+	}
+}
+
+func TestDiskChecker_StatError(t *testing.T) {
+	checker := NewDiskChecker(DiskCheckerConfig{Path: "/missing"})
+	errStat := errors.New("no such file or directory")
+	checker.statfs = func(string) (diskUsage, error) { return diskUsage{}, errStat }
+
+	result := checker.Check(context.Background())
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want unhealthy", result.Status)
+	}
+	if !errors.Is(result.Error, ErrCheckFailed) || !errors.Is(result.Error, errStat) {
+		t.Errorf("Error = %v, want ErrCheckFailed wrapping the stat error", result.Error)
+	}
+}
+
+func TestDiskChecker_CheckContextCancelled(t *testing.T) {
+	checker := NewDiskChecker(DiskCheckerConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := checker.Check(ctx)
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy for cancelled context", result.Status)
+	}
+	if result.Error != context.Canceled {
+		t.Errorf("Error = %v, want context.Canceled", result.Error)
+	}
+}
+
+func TestDiskChecker_RealFilesystem(t *testing.T) {
+	checker := NewDiskChecker(DiskCheckerConfig{Path: t.TempDir()})
+
+	result := checker.Check(context.Background())
+
+	if result.Error != nil && !errors.Is(result.Error, ErrCheckFailed) {
+		t.Errorf("Error = %v, want nil or ErrCheckFailed", result.Error)
+	}
+	if total, ok := result.Details["total_bytes"].(uint64); ok && total == 0 {
+		t.Error("Details[total_bytes] = 0 for a real filesystem")
+	}
+}
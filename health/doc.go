@@ -44,7 +44,12 @@
 //   - [CheckerFunc]: Adapter for function-based checkers
 //   - [Result]: Health check outcome with status, message, details, duration
 //   - [Aggregator]: Combines multiple checkers into composite health
-//   - [MemoryChecker]: Built-in checker for memory usage thresholds
+//   - [MemoryChecker]: Built-in checker for memory usage thresholds, against the Go heap or
+//     the container's cgroup limit ([MemorySourceCgroup])
+//   - [DiskChecker]: Built-in checker for filesystem usage thresholds
+//   - [GoroutineChecker]: Built-in checker for goroutine leaks
+//   - [TCPChecker]: Built-in checker that a TCP port accepts connections
+//   - [CircuitBreakerChecker]: Adapts a resilience.CircuitBreaker to a Checker
 //
 // # Quick Start
 //
@@ -75,7 +80,8 @@
 // The package provides Kubernetes-compatible HTTP handlers:
 //
 //   - [LivenessHandler]: Simple /healthz endpoint - always returns 200 if running
-//   - [ReadinessHandler]: Runs all checks, returns 503 if any unhealthy
+//   - [ReadinessHandler]: Runs all checks, returns 503 if any critical check is unhealthy
+//   - [StartupHandler]: /startupz endpoint - returns 503 until [Aggregator.MarkStarted], then readiness
 //   - [DetailedHandler]: Returns JSON with full check details
 //   - [SingleCheckHandler]: Check a specific component by name
 //   - [RegisterHandlers]: Convenience function to register all handlers
@@ -84,24 +90,101 @@
 //
 //	mux := http.NewServeMux()
 //	health.RegisterHandlers(mux, aggregator)
-//	// Registers: /healthz, /readyz, /health
+//	// Registers: /healthz, /readyz, /startupz, /health
+//
+//	// After slow initialization (caches warmed, migrations run):
+//	aggregator.MarkStarted()
+//
+// Handlers respond 200 for Healthy and Degraded and 503 for Unhealthy. For
+// load balancers that expect other codes, supply a mapper:
+//
+//	health.RegisterHandlers(mux, aggregator, health.WithStatusCodeMapper(func(s health.Status) int {
+//	    if s == health.StatusDegraded {
+//	        return http.StatusTooManyRequests
+//	    }
+//	    return health.DefaultStatusCode(s)
+//	}))
+//
+// [WithMetadata] adds static fields such as a build version or instance ID
+// to the [DetailedHandler] JSON, nested under "metadata".
+//
+// # gRPC Health Protocol
+//
+// [GRPCServer] serves the standard grpc.health.v1.Health service from an
+// Aggregator, for service meshes and grpc_health_probe. The empty service
+// name reports overall status; other names are registered checker names.
+// Unhealthy maps to NOT_SERVING and anything else to SERVING:
+//
+//	healthpb.RegisterHealthServer(grpcServer, health.NewGRPCServer(aggregator, health.GRPCServerConfig{}))
+//
+// Watch polls every GRPCServerConfig.WatchInterval and streams changes.
+//
+// # Prometheus Metrics
+//
+// [RegisterPrometheus] exports each checker's latest result as gauges, so
+// alerts can fire without parsing JSON:
+//
+//	if err := health.RegisterPrometheus(aggregator, registry); err != nil {
+//	    return err
+//	}
+//	// toolops_health_check_status{check="database"} 0|1|2
+//	// toolops_health_check_duration_seconds{check="database"}
+//
+// The gauges update whenever checks run; [Aggregator.OnResult] offers the
+// same hook for other metrics systems.
 //
 // # Aggregation Behavior
 //
-// The [Aggregator] computes overall status using worst-case logic:
+// By default the [Aggregator] computes overall status using worst-case logic:
 //
-//   - If ANY check is Unhealthy → overall Unhealthy
-//   - If ANY check is Degraded (and none Unhealthy) → overall Degraded
+//   - If ANY critical check is Unhealthy → overall Unhealthy
+//   - If ANY check is Degraded, or non-critical and Unhealthy (and no
+//     critical check is Unhealthy) → overall Degraded
 //   - If ALL checks are Healthy → overall Healthy
 //
+// Register adds critical checkers. For an optional dependency whose failure
+// should not fail readiness, register it as non-critical; its Unhealthy
+// result counts as Degraded:
+//
+//	agg.RegisterWithOptions("cache", cacheCheck, health.CheckerOptions{Critical: false})
+//
+// With many checkers, worst-case logic lets one minor flapping check fail
+// the whole service. Set AggregatorConfig.Strategy to [AggregateWeighted] to
+// derive the overall status from the weighted fraction of failing checks
+// instead, compared against UnhealthyThreshold (default 50%) and
+// DegradedThreshold (default 10%). CheckerOptions.Weight sets each
+// checker's share:
+//
+//	agg := health.NewAggregator(health.AggregatorConfig{Strategy: health.AggregateWeighted})
+//	agg.RegisterWithOptions("database", dbCheck, health.CheckerOptions{Critical: true, Weight: 5})
+//
 // Checks can run in parallel (default) or sequentially via [AggregatorConfig].
+// AggregatorConfig.MaxConcurrency caps parallel fan-out with a worker pool.
+// AggregatorConfig.FailFast stops at the first Unhealthy result, cancelling
+// in-flight checks; it trades a complete result map for a faster answer.
+// AggregatorConfig.TotalBudget bounds the wall-clock time of a whole
+// CheckAll: when it is exceeded, an [AggregateLatencyCheck] result reports
+// Degraded (or Unhealthy) even if every check met its own timeout.
+// AggregatorConfig.CacheTTL reuses recent CheckAll results so a load
+// balancer polling /readyz many times per second does not re-run expensive
+// checks; concurrent requests during a refresh share one run.
+// AggregatorConfig.HistorySize keeps the last N results of each checker,
+// available from [Aggregator.History] and in the [DetailedHandler] JSON, to
+// help debug intermittent failures.
+//
+// A checker that panics is reported as Unhealthy rather than crashing the
+// aggregator; [Recover] applies the same protection to a standalone checker.
 //
 // # Thread Safety
 //
 // All exported types are safe for concurrent use:
 //
-//   - [Aggregator]: sync.RWMutex protects registration and check execution
+//   - [Aggregator]: sync.RWMutex protects registration and check execution; MarkStarted() is atomic
 //   - [MemoryChecker]: Stateless, concurrent-safe
+//   - [DiskChecker]: Stateless, concurrent-safe
+//   - [GoroutineChecker]: Stateless, concurrent-safe
+//   - [TCPChecker]: Stateless, concurrent-safe
+//   - [GRPCServer]: Stateless, concurrent-safe; each Watch polls independently
 //   - [CheckerFunc]: Delegates to user function, ensure your function is safe
 //   - [Result]: Immutable after creation
 //
@@ -113,12 +196,15 @@
 //   - [ErrCheckTimeout]: Check exceeded timeout
 //   - [ErrCheckerNotFound]: Named checker not registered
 //   - [ErrNoCheckers]: No checkers registered in aggregator
+//   - [ErrCheckPanic]: Checker panicked; see [Recover]
+//   - [ErrLatencyBudgetExceeded]: CheckAll exceeded TotalBudget
 //
 // # Integration with ApertureStack
 //
 // health integrates with other ApertureStack packages:
 //
-//   - resilience: Use CircuitBreaker.State() for health status
+//   - resilience: Register breakers via [CircuitBreakerChecker] (closed is
+//     healthy, half-open degraded, open unhealthy)
 //   - observe: Log health check results via observability middleware
 //   - MCP servers: Expose health endpoints for tool server monitoring
 package health
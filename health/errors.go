@@ -12,6 +12,12 @@ var (
 	// ErrCheckerNotFound indicates a checker was not found.
 	ErrCheckerNotFound = errors.New("health: checker not found")
 
+	// ErrLatencyBudgetExceeded indicates CheckAll exceeded its total budget.
+	ErrLatencyBudgetExceeded = errors.New("health: total check latency over budget")
+
+	// ErrCheckPanic indicates a health check panicked.
+	ErrCheckPanic = errors.New("health: check panicked")
+
 	// ErrNoCheckers indicates no checkers are registered.
 	ErrNoCheckers = errors.New("health: no checkers registered")
 )
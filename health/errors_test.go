@@ -13,6 +13,8 @@ func TestSentinelErrors(t *testing.T) {
 		{"ErrCheckTimeout", ErrCheckTimeout},
 		{"ErrCheckerNotFound", ErrCheckerNotFound},
 		{"ErrNoCheckers", ErrNoCheckers},
+		{"ErrCheckPanic", ErrCheckPanic},
+		{"ErrLatencyBudgetExceeded", ErrLatencyBudgetExceeded},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// GoroutineCheckerConfig configures the goroutine count health checker.
+type GoroutineCheckerConfig struct {
+	// MaxGoroutines is the goroutine count above which the process is
+	// considered unhealthy, typically because goroutines are leaking.
+	// Default: 10000
+	MaxGoroutines int
+
+	// WarningThreshold is the fraction of MaxGoroutines that triggers degraded status.
+	// Value should be between 0 and 1. Default: 0.8 (80%)
+	WarningThreshold float64
+}
+
+// GoroutineChecker checks the number of running goroutines, flagging
+// goroutine leaks before they exhaust memory.
+type GoroutineChecker struct {
+	config GoroutineCheckerConfig
+}
+
+// NewGoroutineChecker creates a new goroutine count health checker.
+func NewGoroutineChecker(config GoroutineCheckerConfig) *GoroutineChecker {
+	if config.MaxGoroutines <= 0 {
+		config.MaxGoroutines = 10000
+	}
+	if config.WarningThreshold <= 0 || config.WarningThreshold >= 1 {
+		config.WarningThreshold = 0.8
+	}
+
+	return &GoroutineChecker{config: config}
+}
+
+// Name returns the name of this checker.
+func (g *GoroutineChecker) Name() string {
+	return "goroutines"
+}
+
+// Check performs the goroutine count health check.
+func (g *GoroutineChecker) Check(ctx context.Context) Result {
+	// Check context first
+	select {
+	case <-ctx.Done():
+		return Unhealthy("context cancelled", ctx.Err())
+	default:
+	}
+
+	count := runtime.NumGoroutine()
+	warning := int(float64(g.config.MaxGoroutines) * g.config.WarningThreshold)
+
+	details := map[string]any{
+		"goroutines":     count,
+		"max_goroutines": g.config.MaxGoroutines,
+		"warning_at":     warning,
+	}
+
+	if count > g.config.MaxGoroutines {
+		return Unhealthy(
+			fmt.Sprintf("goroutine count critical: %d (max %d)", count, g.config.MaxGoroutines),
+			ErrCheckFailed,
+		).WithDetails(details)
+	}
+
+	if count >= warning {
+		return Degraded(
+			fmt.Sprintf("goroutine count high: %d (max %d)", count, g.config.MaxGoroutines),
+		).WithDetails(details)
+	}
+
+	return Healthy(
+		fmt.Sprintf("goroutine count normal: %d", count),
+	).WithDetails(details)
+}
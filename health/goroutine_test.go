@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// parkGoroutines starts n goroutines blocked until the returned release
+// function is called, which waits for them to exit.
+func parkGoroutines(n int) (release func()) {
+	stop := make(chan struct{})
+	var started, done sync.WaitGroup
+	for i := 0; i < n; i++ {
+		started.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			started.Done()
+			<-stop
+		}()
+	}
+	started.Wait()
+	return func() {
+		close(stop)
+		done.Wait()
+	}
+}
+
+func TestNewGoroutineChecker(t *testing.T) {
+	checker := NewGoroutineChecker(GoroutineCheckerConfig{})
+
+	if checker.config.MaxGoroutines != 10000 {
+		t.Errorf("MaxGoroutines = %d, want 10000", checker.config.MaxGoroutines)
+	}
+	if checker.config.WarningThreshold != 0.8 {
+		t.Errorf("WarningThreshold = %v, want 0.8", checker.config.WarningThreshold)
+	}
+	if checker.Name() != "goroutines" {
+		t.Errorf("Name() = %v, want 'goroutines'", checker.Name())
+	}
+}
+
+func TestGoroutineChecker_StatusTransitions(t *testing.T) {
+	base := runtime.NumGoroutine()
+	checker := NewGoroutineChecker(GoroutineCheckerConfig{
+		MaxGoroutines:    base + 40,
+		WarningThreshold: 0.5,
+	})
+	ctx := context.Background()
+
+	if result := checker.Check(ctx); result.Status != StatusHealthy {
+		t.Errorf("baseline Status = %v, want healthy (%s)", result.Status, result.Message)
+	}
+
+	// At the limit: past the warning level but not the hard limit
+	release := parkGoroutines(40)
+	result := checker.Check(ctx)
+	if result.Status != StatusDegraded {
+		t.Errorf("Status = %v at the limit, want degraded (%s)", result.Status, result.Message)
+	}
+	if count, _ := result.Details["goroutines"].(int); count < base+40-1 {
+		t.Errorf("Details[goroutines] = %v, want about %d", result.Details["goroutines"], base+40)
+	}
+
+	// Past the limit
+	releaseMore := parkGoroutines(10)
+	if result := checker.Check(ctx); result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v past the limit, want unhealthy (%s)", result.Status, result.Message)
+	}
+
+	// Recovers once the goroutines exit
+	releaseMore()
+	release()
+	if result := checker.Check(ctx); result.Status != StatusHealthy {
+		t.Errorf("Status = %v after cleanup, want healthy (%s)", result.Status, result.Message)
+	}
+}
+
+func TestGoroutineChecker_CheckContextCancelled(t *testing.T) {
+	checker := NewGoroutineChecker(GoroutineCheckerConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := checker.Check(ctx)
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy for cancelled context", result.Status)
+	}
+	if result.Error != context.Canceled {
+		t.Errorf("Error = %v, want context.Canceled", result.Error)
+	}
+}
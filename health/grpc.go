@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServerConfig configures the gRPC health server.
+type GRPCServerConfig struct {
+	// Timeout bounds the checks run for each Check or List request and
+	// each Watch poll.
+	// Default: 5 seconds
+	Timeout time.Duration
+
+	// WatchInterval is how often Watch re-evaluates status to detect
+	// transitions. Combine with AggregatorConfig.CacheTTL to keep many
+	// watchers from multiplying check load.
+	// Default: 5 seconds
+	WatchInterval time.Duration
+}
+
+// GRPCServer implements the gRPC Health Checking Protocol
+// (grpc.health.v1.Health) on top of an Aggregator, for service meshes and
+// grpc_health_probe.
+//
+// The empty service name reports overall status: SERVING unless
+// OverallStatus is Unhealthy, matching ReadinessHandler. Any other service
+// name is a registered checker name, reported SERVING unless that check is
+// Unhealthy.
+//
+// Register it on a gRPC server with:
+//
+//	healthpb.RegisterHealthServer(srv, health.NewGRPCServer(agg, health.GRPCServerConfig{}))
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+
+	agg    *Aggregator
+	config GRPCServerConfig
+}
+
+// NewGRPCServer creates a gRPC health server backed by agg.
+func NewGRPCServer(agg *Aggregator, config GRPCServerConfig) *GRPCServer {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.WatchInterval <= 0 {
+		config.WatchInterval = 5 * time.Second
+	}
+
+	return &GRPCServer{agg: agg, config: config}
+}
+
+// Check returns the serving status of the requested service, or
+// codes.NotFound if no checker is registered under that name.
+func (s *GRPCServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, err := s.servingStatus(ctx, req.GetService())
+	if errors.Is(err, ErrCheckerNotFound) {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// List returns the serving status of every registered checker, plus the
+// overall status under the empty service name.
+func (s *GRPCServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	results := s.agg.CheckAll(ctx)
+
+	statuses := make(map[string]*healthpb.HealthCheckResponse, len(results)+1)
+	statuses[""] = &healthpb.HealthCheckResponse{Status: toServingStatus(s.agg.OverallStatus(results))}
+	for name, result := range results {
+		statuses[name] = &healthpb.HealthCheckResponse{Status: toServingStatus(result.Status)}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch sends the requested service's serving status, then sends it again
+// each time it changes, polling every WatchInterval until the client
+// cancels. An unregistered service is reported as SERVICE_UNKNOWN and
+// keeps being watched, as the protocol requires.
+func (s *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.config.WatchInterval)
+	defer ticker.Stop()
+
+	var last healthpb.HealthCheckResponse_ServingStatus
+	sent := false
+	for {
+		st, err := s.servingStatus(ctx, req.GetService())
+		if errors.Is(err, ErrCheckerNotFound) {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if ctx.Err() != nil {
+			return status.Error(codes.Canceled, "stream has ended")
+		}
+		if !sent || st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last, sent = st, true
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-ticker.C:
+		}
+	}
+}
+
+// servingStatus runs the checks for service ("" for overall status).
+func (s *GRPCServer) servingStatus(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	if service == "" {
+		results := s.agg.CheckAll(ctx)
+		return toServingStatus(s.agg.OverallStatus(results)), nil
+	}
+
+	result, err := s.agg.Check(ctx, service)
+	if err != nil {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, err
+	}
+	return toServingStatus(result.Status), nil
+}
+
+// toServingStatus maps a Status to a gRPC serving status. Degraded is
+// SERVING, as with ReadinessHandler.
+func toServingStatus(s Status) healthpb.HealthCheckResponse_ServingStatus {
+	if s == StatusUnhealthy {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// Ensure GRPCServer implements the health service
+var _ healthpb.HealthServer = (*GRPCServer)(nil)
@@ -0,0 +1,215 @@
+package health
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGRPCHealthClient serves agg over an in-memory connection and returns
+// a client for it.
+func newGRPCHealthClient(t *testing.T, agg *Aggregator, config GRPCServerConfig) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, NewGRPCServer(agg, config))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestNewGRPCServer(t *testing.T) {
+	srv := NewGRPCServer(NewAggregator(), GRPCServerConfig{})
+
+	if srv.config.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", srv.config.Timeout)
+	}
+	if srv.config.WatchInterval != 5*time.Second {
+		t.Errorf("WatchInterval = %v, want 5s", srv.config.WatchInterval)
+	}
+}
+
+func TestGRPCServer_Check(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.Register("slow", NewCheckerFunc("slow", func(ctx context.Context) Result {
+		return Degraded("slow")
+	}))
+	agg.Register("broker", NewCheckerFunc("broker", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	client := newGRPCHealthClient(t, agg, GRPCServerConfig{})
+
+	tests := []struct {
+		service string
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"", healthpb.HealthCheckResponse_NOT_SERVING},
+		{"db", healthpb.HealthCheckResponse_SERVING},
+		{"slow", healthpb.HealthCheckResponse_SERVING},
+		{"broker", healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if resp.GetStatus() != tt.want {
+				t.Errorf("Status = %v, want %v", resp.GetStatus(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_CheckOverallServing(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.RegisterWithOptions("cache", NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}), CheckerOptions{})
+	client := newGRPCHealthClient(t, agg, GRPCServerConfig{})
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Status = %v, want SERVING when only a non-critical check fails", resp.GetStatus())
+	}
+}
+
+func TestGRPCServer_CheckUnknownService(t *testing.T) {
+	client := newGRPCHealthClient(t, NewAggregator(), GRPCServerConfig{})
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Check() code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestGRPCServer_List(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.Register("broker", NewCheckerFunc("broker", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	client := newGRPCHealthClient(t, agg, GRPCServerConfig{})
+
+	resp, err := client.List(context.Background(), &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":       healthpb.HealthCheckResponse_NOT_SERVING,
+		"db":     healthpb.HealthCheckResponse_SERVING,
+		"broker": healthpb.HealthCheckResponse_NOT_SERVING,
+	}
+	if len(resp.GetStatuses()) != len(want) {
+		t.Errorf("len(Statuses) = %d, want %d", len(resp.GetStatuses()), len(want))
+	}
+	for name, st := range want {
+		if got := resp.GetStatuses()[name].GetStatus(); got != st {
+			t.Errorf("Statuses[%q] = %v, want %v", name, got, st)
+		}
+	}
+}
+
+func TestGRPCServer_WatchTransitions(t *testing.T) {
+	var down atomic.Bool
+	agg := NewAggregator()
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		if down.Load() {
+			return Unhealthy("down", nil)
+		}
+		return Healthy("ok")
+	}))
+	client := newGRPCHealthClient(t, agg, GRPCServerConfig{WatchInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	want := []healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_SERVING,
+		healthpb.HealthCheckResponse_NOT_SERVING,
+		healthpb.HealthCheckResponse_SERVING,
+	}
+	for i, st := range want {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() #%d error = %v", i, err)
+		}
+		if resp.GetStatus() != st {
+			t.Fatalf("Recv() #%d Status = %v, want %v", i, resp.GetStatus(), st)
+		}
+		down.Store(!down.Load())
+	}
+}
+
+func TestGRPCServer_WatchUnknownService(t *testing.T) {
+	agg := NewAggregator()
+	client := newGRPCHealthClient(t, agg, GRPCServerConfig{WatchInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "db"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		t.Errorf("Status = %v, want SERVICE_UNKNOWN", resp.GetStatus())
+	}
+
+	// Registering the service later is reported on the same stream
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	resp, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Status = %v, want SERVING", resp.GetStatus())
+	}
+}
@@ -3,10 +3,67 @@ package health
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"net/http"
 	"time"
 )
 
+// StatusCodeMapper maps an overall or per-check status to the HTTP status
+// code a handler responds with.
+type StatusCodeMapper func(Status) int
+
+// DefaultStatusCode is the StatusCodeMapper handlers use unless configured
+// otherwise: 200 for Healthy and Degraded, 503 for Unhealthy.
+func DefaultStatusCode(s Status) int {
+	switch s {
+	case StatusHealthy, StatusDegraded:
+		return http.StatusOK
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
+
+// HandlerOption configures a health HTTP handler.
+type HandlerOption func(*handlerConfig)
+
+// handlerConfig holds the settings applied by HandlerOptions.
+type handlerConfig struct {
+	statusCode StatusCodeMapper
+	metadata   map[string]any
+}
+
+// newHandlerConfig applies opts over the defaults.
+func newHandlerConfig(opts []HandlerOption) handlerConfig {
+	cfg := handlerConfig{statusCode: DefaultStatusCode}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithStatusCodeMapper makes a handler respond with the HTTP status code
+// mapper returns for each status, e.g. 429 for Degraded or 500 instead of
+// 503 for Unhealthy, to suit how a load balancer interprets codes. A nil
+// mapper keeps DefaultStatusCode.
+func WithStatusCodeMapper(mapper StatusCodeMapper) HandlerOption {
+	return func(c *handlerConfig) {
+		if mapper != nil {
+			c.statusCode = mapper
+		}
+	}
+}
+
+// WithMetadata adds static fields, such as a build version or instance ID,
+// to the DetailedHandler JSON under "metadata". Nesting them keeps them
+// from colliding with status, timestamp, and checks. Other handlers ignore
+// it. The map is copied.
+func WithMetadata(metadata map[string]any) HandlerOption {
+	metadata = maps.Clone(metadata)
+	return func(c *handlerConfig) {
+		c.metadata = metadata
+	}
+}
+
 // LivenessHandler returns an HTTP handler for liveness probes.
 // This is a simple check that the service is running.
 func LivenessHandler() http.HandlerFunc {
@@ -18,28 +75,50 @@ func LivenessHandler() http.HandlerFunc {
 }
 
 // ReadinessHandler returns an HTTP handler for readiness probes.
-// This runs all health checks in the aggregator.
-func ReadinessHandler(agg *Aggregator) http.HandlerFunc {
+// This runs all health checks in the aggregator and responds 503 only when
+// a critical check is unhealthy; see CheckerOptions.
+func ReadinessHandler(agg *Aggregator, opts ...HandlerOption) http.HandlerFunc {
+	cfg := newHandlerConfig(opts)
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		results := agg.CheckAll(ctx)
-		status := agg.OverallStatus(results)
-
-		w.Header().Set("Content-Type", "text/plain")
+		serveReadiness(w, r, agg, cfg)
+	}
+}
 
-		switch status {
-		case StatusHealthy:
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("OK"))
-		case StatusDegraded:
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("DEGRADED"))
-		default:
+// StartupHandler returns an HTTP handler for startup probes.
+// It responds 503 until Aggregator.MarkStarted is called, then behaves like
+// ReadinessHandler. Kubernetes holds off liveness and readiness probes
+// until the startup probe passes, giving slow initialization time to finish.
+func StartupHandler(agg *Aggregator, opts ...HandlerOption) http.HandlerFunc {
+	cfg := newHandlerConfig(opts)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !agg.Started() {
+			w.Header().Set("Content-Type", "text/plain")
 			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("UNHEALTHY"))
+			_, _ = w.Write([]byte("STARTING"))
+			return
 		}
+		serveReadiness(w, r, agg, cfg)
+	}
+}
+
+// serveReadiness runs all checks and writes the readiness response.
+func serveReadiness(w http.ResponseWriter, r *http.Request, agg *Aggregator, cfg handlerConfig) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	results := agg.CheckAll(ctx)
+	status := agg.OverallStatus(results)
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(cfg.statusCode(status))
+
+	switch status {
+	case StatusHealthy:
+		_, _ = w.Write([]byte("OK"))
+	case StatusDegraded:
+		_, _ = w.Write([]byte("DEGRADED"))
+	default:
+		_, _ = w.Write([]byte("UNHEALTHY"))
 	}
 }
 
@@ -48,6 +127,7 @@ type HealthResponse struct {
 	Status    string                   `json:"status"`
 	Timestamp string                   `json:"timestamp"`
 	Checks    map[string]CheckResponse `json:"checks,omitempty"`
+	Metadata  map[string]any           `json:"metadata,omitempty"`
 }
 
 // CheckResponse is the JSON response for a single health check.
@@ -57,10 +137,24 @@ type CheckResponse struct {
 	Duration string         `json:"duration,omitempty"`
 	Details  map[string]any `json:"details,omitempty"`
 	Error    string         `json:"error,omitempty"`
+
+	// History holds the checker's recent results, oldest first, when
+	// AggregatorConfig.HistorySize is set. Only DetailedHandler fills it.
+	History []HistoryEntry `json:"history,omitempty"`
+}
+
+// HistoryEntry is the JSON response for one past health check result.
+type HistoryEntry struct {
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Timestamp string `json:"timestamp"`
 }
 
 // DetailedHandler returns an HTTP handler that provides detailed health information.
-func DetailedHandler(agg *Aggregator) http.HandlerFunc {
+func DetailedHandler(agg *Aggregator, opts ...HandlerOption) http.HandlerFunc {
+	cfg := newHandlerConfig(opts)
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 		defer cancel()
@@ -72,6 +166,7 @@ func DetailedHandler(agg *Aggregator) http.HandlerFunc {
 			Status:    status.String(),
 			Timestamp: time.Now().UTC().Format(time.RFC3339),
 			Checks:    make(map[string]CheckResponse, len(results)),
+			Metadata:  cfg.metadata,
 		}
 
 		for name, result := range results {
@@ -84,26 +179,31 @@ func DetailedHandler(agg *Aggregator) http.HandlerFunc {
 			if result.Error != nil {
 				check.Error = result.Error.Error()
 			}
+			for _, past := range agg.History(name) {
+				entry := HistoryEntry{
+					Status:    past.Status.String(),
+					Message:   past.Message,
+					Duration:  past.Duration.String(),
+					Timestamp: past.Timestamp.UTC().Format(time.RFC3339Nano),
+				}
+				if past.Error != nil {
+					entry.Error = past.Error.Error()
+				}
+				check.History = append(check.History, entry)
+			}
 			response.Checks[name] = check
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-
-		switch status {
-		case StatusHealthy:
-			w.WriteHeader(http.StatusOK)
-		case StatusDegraded:
-			w.WriteHeader(http.StatusOK)
-		default:
-			w.WriteHeader(http.StatusServiceUnavailable)
-		}
+		w.WriteHeader(cfg.statusCode(status))
 
 		_ = json.NewEncoder(w).Encode(response)
 	}
 }
 
 // SingleCheckHandler returns an HTTP handler for checking a single component.
-func SingleCheckHandler(agg *Aggregator, name string) http.HandlerFunc {
+func SingleCheckHandler(agg *Aggregator, name string, opts ...HandlerOption) http.HandlerFunc {
+	cfg := newHandlerConfig(opts)
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 		defer cancel()
@@ -129,23 +229,17 @@ func SingleCheckHandler(agg *Aggregator, name string) http.HandlerFunc {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-
-		switch result.Status {
-		case StatusHealthy:
-			w.WriteHeader(http.StatusOK)
-		case StatusDegraded:
-			w.WriteHeader(http.StatusOK)
-		default:
-			w.WriteHeader(http.StatusServiceUnavailable)
-		}
+		w.WriteHeader(cfg.statusCode(result.Status))
 
 		_ = json.NewEncoder(w).Encode(response)
 	}
 }
 
 // RegisterHandlers registers all health check handlers on the given mux.
-func RegisterHandlers(mux *http.ServeMux, agg *Aggregator) {
+// opts apply to every handler that reports check status.
+func RegisterHandlers(mux *http.ServeMux, agg *Aggregator, opts ...HandlerOption) {
 	mux.HandleFunc("/healthz", LivenessHandler())
-	mux.HandleFunc("/readyz", ReadinessHandler(agg))
-	mux.HandleFunc("/health", DetailedHandler(agg))
+	mux.HandleFunc("/readyz", ReadinessHandler(agg, opts...))
+	mux.HandleFunc("/startupz", StartupHandler(agg, opts...))
+	mux.HandleFunc("/health", DetailedHandler(agg, opts...))
 }
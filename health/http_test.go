@@ -91,6 +91,83 @@ func TestReadinessHandler_Unhealthy(t *testing.T) {
 	}
 }
 
+func TestReadinessHandler_NonCriticalUnhealthy(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("db", NewCheckerFunc("db", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+	agg.RegisterWithOptions("cache", NewCheckerFunc("cache", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}), CheckerOptions{Critical: false})
+
+	handler := ReadinessHandler(agg)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Status = %d, want %d (non-critical failure should not fail readiness)", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != "DEGRADED" {
+		t.Errorf("Body = %v, want 'DEGRADED'", rec.Body.String())
+	}
+}
+
+func TestStartupHandler(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+
+	handler := StartupHandler(agg)
+
+	req := httptest.NewRequest(http.MethodGet, "/startupz", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("before MarkStarted: Status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if rec.Body.String() != "STARTING" {
+		t.Errorf("before MarkStarted: Body = %v, want 'STARTING'", rec.Body.String())
+	}
+
+	agg.MarkStarted()
+
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("after MarkStarted: Status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != "OK" {
+		t.Errorf("after MarkStarted: Body = %v, want 'OK'", rec.Body.String())
+	}
+}
+
+func TestStartupHandler_StartedButUnhealthy(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Unhealthy("down", nil)
+	}))
+	agg.MarkStarted()
+
+	handler := StartupHandler(agg)
+
+	req := httptest.NewRequest(http.MethodGet, "/startupz", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if rec.Body.String() != "UNHEALTHY" {
+		t.Errorf("Body = %v, want 'UNHEALTHY'", rec.Body.String())
+	}
+}
+
 func TestDetailedHandler_Healthy(t *testing.T) {
 	agg := NewAggregator()
 	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
@@ -131,6 +208,119 @@ func TestDetailedHandler_Healthy(t *testing.T) {
 	}
 }
 
+func TestDetailedHandler_History(t *testing.T) {
+	agg := NewAggregator(AggregatorConfig{HistorySize: 2})
+	runs := 0
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		runs++
+		if runs == 1 {
+			return Unhealthy("down", ErrCheckFailed)
+		}
+		return Healthy("ok")
+	}))
+
+	handler := DetailedHandler(agg)
+
+	var response HealthResponse
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+	}
+
+	history := response.Checks["test"].History
+	if len(history) != 2 {
+		t.Fatalf("len(History) = %d, want 2", len(history))
+	}
+	if history[0].Status != "unhealthy" || history[0].Error != ErrCheckFailed.Error() {
+		t.Errorf("History[0] = %+v, want the earlier unhealthy result", history[0])
+	}
+	if history[1].Status != "healthy" {
+		t.Errorf("History[1].Status = %v, want 'healthy'", history[1].Status)
+	}
+	if history[1].Timestamp == "" {
+		t.Error("History[1].Timestamp should not be empty")
+	}
+}
+
+func TestDetailedHandler_NoHistoryByDefault(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	DetailedHandler(agg)(rec, req)
+
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	check := response["checks"].(map[string]any)["test"].(map[string]any)
+	if _, ok := check["history"]; ok {
+		t.Error("history should be omitted when HistorySize is zero")
+	}
+}
+
+func TestDetailedHandler_Metadata(t *testing.T) {
+	agg := NewAggregator()
+	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+		return Healthy("ok")
+	}))
+
+	metadata := map[string]any{
+		"version":     "1.4.2",
+		"instance_id": "pod-7",
+		"status":      "colliding",
+	}
+	handler := DetailedHandler(agg, WithMetadata(metadata))
+	metadata["version"] = "changed" // The handler keeps its own copy
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response["status"] != "healthy" {
+		t.Errorf("status = %v, want 'healthy' (metadata must not override it)", response["status"])
+	}
+	got, ok := response["metadata"].(map[string]any)
+	if !ok {
+		t.Fatalf("metadata = %v, want an object", response["metadata"])
+	}
+	want := map[string]any{"version": "1.4.2", "instance_id": "pod-7", "status": "colliding"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("metadata[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestDetailedHandler_NoMetadataByDefault(t *testing.T) {
+	agg := NewAggregator()
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	DetailedHandler(agg)(rec, req)
+
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if _, ok := response["metadata"]; ok {
+		t.Error("metadata should be omitted when not configured")
+	}
+}
+
 func TestDetailedHandler_Unhealthy(t *testing.T) {
 	agg := NewAggregator()
 	agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
@@ -246,6 +436,20 @@ func TestRegisterHandlers(t *testing.T) {
 		t.Errorf("/readyz Status = %d, want %d", rec.Code, http.StatusOK)
 	}
 
+	// Test /startupz before and after MarkStarted
+	req = httptest.NewRequest(http.MethodGet, "/startupz", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("/startupz Status = %d, want %d before MarkStarted", rec.Code, http.StatusServiceUnavailable)
+	}
+	agg.MarkStarted()
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("/startupz Status = %d, want %d after MarkStarted", rec.Code, http.StatusOK)
+	}
+
 	// Test /health
 	req = httptest.NewRequest(http.MethodGet, "/health", nil)
 	rec = httptest.NewRecorder()
@@ -255,6 +459,75 @@ func TestRegisterHandlers(t *testing.T) {
 	}
 }
 
+func TestHandlers_StatusCodeMapper(t *testing.T) {
+	mapper := func(s Status) int {
+		switch s {
+		case StatusHealthy:
+			return http.StatusNoContent
+		case StatusDegraded:
+			return http.StatusTooManyRequests
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+
+	results := map[Status]Result{
+		StatusHealthy:   Healthy("ok"),
+		StatusDegraded:  Degraded("slow"),
+		StatusUnhealthy: Unhealthy("down", nil),
+	}
+
+	handlers := map[string]func(*Aggregator, ...HandlerOption) http.HandlerFunc{
+		"readiness": ReadinessHandler,
+		"detailed":  DetailedHandler,
+		"single": func(agg *Aggregator, opts ...HandlerOption) http.HandlerFunc {
+			return SingleCheckHandler(agg, "test", opts...)
+		},
+	}
+
+	for handlerName, newHandler := range handlers {
+		for status, result := range results {
+			t.Run(handlerName+"/"+status.String(), func(t *testing.T) {
+				agg := NewAggregator()
+				agg.Register("test", NewCheckerFunc("test", func(ctx context.Context) Result {
+					return result
+				}))
+
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				newHandler(agg, WithStatusCodeMapper(mapper))(rec, req)
+				if rec.Code != mapper(status) {
+					t.Errorf("custom Status = %d, want %d", rec.Code, mapper(status))
+				}
+
+				rec = httptest.NewRecorder()
+				newHandler(agg, WithStatusCodeMapper(nil))(rec, req)
+				if rec.Code != DefaultStatusCode(status) {
+					t.Errorf("nil mapper Status = %d, want %d", rec.Code, DefaultStatusCode(status))
+				}
+			})
+		}
+	}
+}
+
+func TestDefaultStatusCode(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   int
+	}{
+		{StatusHealthy, http.StatusOK},
+		{StatusDegraded, http.StatusOK},
+		{StatusUnhealthy, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultStatusCode(tt.status); got != tt.want {
+			t.Errorf("DefaultStatusCode(%v) = %d, want %d", tt.status, got, tt.want)
+		}
+	}
+}
+
 func TestDetailedHandler_Timeout(t *testing.T) {
 	agg := NewAggregator(AggregatorConfig{
 		Timeout: 50 * time.Millisecond,
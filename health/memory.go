@@ -6,6 +6,32 @@ import (
 	"runtime"
 )
 
+// MemorySource selects what MemoryChecker measures.
+type MemorySource int
+
+const (
+	// MemorySourceHeap compares the Go heap allocation (runtime.MemStats)
+	// to MaxAlloc.
+	MemorySourceHeap MemorySource = iota
+	// MemorySourceCgroup compares the container's memory working set to
+	// its cgroup (v1 or v2) memory limit, which is what the OOM killer
+	// enforces. It falls back to MemorySourceHeap when no cgroup limit is
+	// found, such as outside a container.
+	MemorySourceCgroup
+)
+
+// String returns the string representation of the source.
+func (s MemorySource) String() string {
+	switch s {
+	case MemorySourceHeap:
+		return "heap"
+	case MemorySourceCgroup:
+		return "cgroup"
+	default:
+		return "unknown"
+	}
+}
+
 // MemoryCheckerConfig configures the memory health checker.
 type MemoryCheckerConfig struct {
 	// WarningThreshold is the percentage of allocated memory that triggers degraded status.
@@ -20,11 +46,17 @@ type MemoryCheckerConfig struct {
 	// If zero, uses the system's total memory (approximated).
 	// Default: 0 (auto-detect)
 	MaxAlloc uint64
+
+	// Source selects what is measured against the thresholds. The source
+	// actually used is reported in Result.Details["source"].
+	// Default: MemorySourceHeap
+	Source MemorySource
 }
 
 // MemoryChecker checks memory usage health.
 type MemoryChecker struct {
 	config MemoryCheckerConfig
+	cgroup func() (cgroupMemory, error) // Replaced in tests
 }
 
 // NewMemoryChecker creates a new memory health checker.
@@ -42,7 +74,7 @@ func NewMemoryChecker(config MemoryCheckerConfig) *MemoryChecker {
 		}
 	}
 
-	return &MemoryChecker{config: config}
+	return &MemoryChecker{config: config, cgroup: readCgroupMemory}
 }
 
 // Name returns the name of this checker.
@@ -59,6 +91,18 @@ func (m *MemoryChecker) Check(ctx context.Context) Result {
 	default:
 	}
 
+	if m.config.Source == MemorySourceCgroup {
+		if mem, err := m.cgroup(); err == nil {
+			usageRatio := float64(mem.usage) / float64(mem.limit)
+			return m.result(usageRatio, map[string]any{
+				"source":        mem.source(),
+				"usage_bytes":   mem.usage,
+				"limit_bytes":   mem.limit,
+				"usage_percent": usageRatio * 100,
+			})
+		}
+	}
+
 	var stats runtime.MemStats
 	runtime.ReadMemStats(&stats)
 
@@ -71,6 +115,7 @@ func (m *MemoryChecker) Check(ctx context.Context) Result {
 
 	if maxAlloc == 0 {
 		return Healthy("memory stats unavailable").WithDetails(map[string]any{
+			"source":      MemorySourceHeap.String(),
 			"alloc":       stats.Alloc,
 			"total_alloc": stats.TotalAlloc,
 			"sys":         stats.Sys,
@@ -81,6 +126,7 @@ func (m *MemoryChecker) Check(ctx context.Context) Result {
 	usageRatio := float64(stats.Alloc) / float64(maxAlloc)
 
 	details := map[string]any{
+		"source":         MemorySourceHeap.String(),
 		"alloc_bytes":    stats.Alloc,
 		"alloc_mb":       float64(stats.Alloc) / (1024 * 1024),
 		"max_alloc":      maxAlloc,
@@ -98,6 +144,11 @@ func (m *MemoryChecker) Check(ctx context.Context) Result {
 		"goroutines":     runtime.NumGoroutine(),
 	}
 
+	return m.result(usageRatio, details)
+}
+
+// result applies the thresholds to usageRatio.
+func (m *MemoryChecker) result(usageRatio float64, details map[string]any) Result {
 	if usageRatio >= m.config.CriticalThreshold {
 		return Unhealthy(
 			fmt.Sprintf("memory usage critical: %.1f%%", usageRatio*100),
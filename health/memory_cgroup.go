@@ -0,0 +1,110 @@
+package health
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup filesystem is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupUnlimited is the smallest value treated as "no limit" in cgroup v1,
+// which reports an unset limit as a page-aligned value near MaxInt64.
+const cgroupUnlimited = 1 << 62
+
+// errNoCgroupLimit indicates the cgroup has no memory limit to compare to.
+var errNoCgroupLimit = errors.New("health: no cgroup memory limit")
+
+// cgroupMemory is a cgroup's memory usage and limit in bytes.
+type cgroupMemory struct {
+	version int    // 1 or 2
+	usage   uint64 // Working set: usage minus inactive page cache
+	limit   uint64
+}
+
+// source names the cgroup version for Result details.
+func (c cgroupMemory) source() string {
+	return fmt.Sprintf("cgroup_v%d", c.version)
+}
+
+// readCgroupMemory reads the current process's cgroup memory from the
+// cgroup filesystem mounted at cgroupRoot.
+func readCgroupMemory() (cgroupMemory, error) {
+	return readCgroupMemoryFS(os.DirFS(cgroupRoot))
+}
+
+// readCgroupMemoryFS reads cgroup memory from fsys, preferring the cgroup
+// v2 unified hierarchy and falling back to the v1 memory controller.
+//
+// Usage is reported as the working set, like the kubelet: total usage
+// minus inactive file-backed pages, which the kernel reclaims before
+// invoking the OOM killer.
+func readCgroupMemoryFS(fsys fs.FS) (cgroupMemory, error) {
+	if _, err := fs.Stat(fsys, "memory.max"); err == nil {
+		return readCgroupFiles(fsys, 2, "memory.current", "memory.max", "memory.stat", "inactive_file")
+	}
+	return readCgroupFiles(fsys, 1,
+		"memory/memory.usage_in_bytes", "memory/memory.limit_in_bytes",
+		"memory/memory.stat", "total_inactive_file")
+}
+
+// readCgroupFiles reads usage and limit from the named files, subtracting
+// the inactive file counter from statFile when it is present.
+func readCgroupFiles(fsys fs.FS, version int, usageFile, limitFile, statFile, inactiveKey string) (cgroupMemory, error) {
+	limitRaw, err := fs.ReadFile(fsys, limitFile)
+	if err != nil {
+		return cgroupMemory{}, err
+	}
+	limitStr := strings.TrimSpace(string(limitRaw))
+	if limitStr == "max" {
+		return cgroupMemory{}, errNoCgroupLimit
+	}
+	limit, err := strconv.ParseUint(limitStr, 10, 64)
+	if err != nil {
+		return cgroupMemory{}, fmt.Errorf("health: parse %s: %w", limitFile, err)
+	}
+	if limit == 0 || limit >= cgroupUnlimited {
+		return cgroupMemory{}, errNoCgroupLimit
+	}
+
+	usageRaw, err := fs.ReadFile(fsys, usageFile)
+	if err != nil {
+		return cgroupMemory{}, err
+	}
+	usage, err := strconv.ParseUint(strings.TrimSpace(string(usageRaw)), 10, 64)
+	if err != nil {
+		return cgroupMemory{}, fmt.Errorf("health: parse %s: %w", usageFile, err)
+	}
+
+	if stat, err := fs.ReadFile(fsys, statFile); err == nil {
+		if inactive, ok := statValue(stat, inactiveKey); ok {
+			if inactive < usage {
+				usage -= inactive
+			} else {
+				usage = 0
+			}
+		}
+	}
+
+	return cgroupMemory{version: version, usage: usage, limit: limit}, nil
+}
+
+// statValue returns the value of key in a memory.stat file.
+func statValue(stat []byte, key string) (uint64, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(stat))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || name != key {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
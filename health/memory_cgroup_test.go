@@ -0,0 +1,93 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadCgroupMemoryFS(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    cgroupMemory
+		wantErr error
+	}{
+		{
+			name: "v2 with working set",
+			fsys: fstest.MapFS{
+				"memory.max":     {Data: []byte("1073741824\n")},
+				"memory.current": {Data: []byte("600000000\n")},
+				"memory.stat":    {Data: []byte("anon 400000000\nfile 200000000\ninactive_file 100000000\n")},
+			},
+			want: cgroupMemory{version: 2, usage: 500000000, limit: 1073741824},
+		},
+		{
+			name: "v2 without stat",
+			fsys: fstest.MapFS{
+				"memory.max":     {Data: []byte("2000\n")},
+				"memory.current": {Data: []byte("1500\n")},
+			},
+			want: cgroupMemory{version: 2, usage: 1500, limit: 2000},
+		},
+		{
+			name: "v2 unlimited",
+			fsys: fstest.MapFS{
+				"memory.max":     {Data: []byte("max\n")},
+				"memory.current": {Data: []byte("1500\n")},
+			},
+			wantErr: errNoCgroupLimit,
+		},
+		{
+			name: "v1 with working set",
+			fsys: fstest.MapFS{
+				"memory/memory.limit_in_bytes": {Data: []byte("4000\n")},
+				"memory/memory.usage_in_bytes": {Data: []byte("3000\n")},
+				"memory/memory.stat":           {Data: []byte("cache 1000\ntotal_inactive_file 500\n")},
+			},
+			want: cgroupMemory{version: 1, usage: 2500, limit: 4000},
+		},
+		{
+			name: "v1 unlimited",
+			fsys: fstest.MapFS{
+				"memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712\n")},
+				"memory/memory.usage_in_bytes": {Data: []byte("3000\n")},
+			},
+			wantErr: errNoCgroupLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCgroupMemoryFS(tt.fsys)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("readCgroupMemoryFS() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCgroupMemoryFS() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readCgroupMemoryFS() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCgroupMemoryFS_Missing(t *testing.T) {
+	if _, err := readCgroupMemoryFS(fstest.MapFS{}); err == nil {
+		t.Error("readCgroupMemoryFS() on empty filesystem should fail")
+	}
+}
+
+func TestReadCgroupMemoryFS_Malformed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"memory.max":     {Data: []byte("lots\n")},
+		"memory.current": {Data: []byte("1500\n")},
+	}
+	if _, err := readCgroupMemoryFS(fsys); err == nil {
+		t.Error("readCgroupMemoryFS() with malformed limit should fail")
+	}
+}
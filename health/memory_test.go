@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -128,3 +129,86 @@ func TestMemoryChecker_WithMaxAlloc(t *testing.T) {
 		t.Errorf("max_alloc = %v, want 1024", result.Details["max_alloc"])
 	}
 }
+
+func TestMemoryChecker_CgroupSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage uint64
+		want  Status
+	}{
+		{"below warning", 500, StatusHealthy},
+		{"at warning", 800, StatusDegraded},
+		{"at critical", 950, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewMemoryChecker(MemoryCheckerConfig{Source: MemorySourceCgroup})
+			checker.cgroup = func() (cgroupMemory, error) {
+				return cgroupMemory{version: 2, usage: tt.usage, limit: 1000}, nil
+			}
+
+			result := checker.Check(context.Background())
+
+			if result.Status != tt.want {
+				t.Errorf("Status = %v, want %v (%s)", result.Status, tt.want, result.Message)
+			}
+			if result.Details["source"] != "cgroup_v2" {
+				t.Errorf("source = %v, want cgroup_v2", result.Details["source"])
+			}
+			if result.Details["limit_bytes"] != uint64(1000) {
+				t.Errorf("limit_bytes = %v, want 1000", result.Details["limit_bytes"])
+			}
+			if result.Details["usage_bytes"] != tt.usage {
+				t.Errorf("usage_bytes = %v, want %d", result.Details["usage_bytes"], tt.usage)
+			}
+		})
+	}
+}
+
+func TestMemoryChecker_CgroupFallsBackToHeap(t *testing.T) {
+	checker := NewMemoryChecker(MemoryCheckerConfig{Source: MemorySourceCgroup})
+	checker.cgroup = func() (cgroupMemory, error) {
+		return cgroupMemory{}, errors.New("no cgroup")
+	}
+
+	result := checker.Check(context.Background())
+
+	if result.Details["source"] != "heap" {
+		t.Errorf("source = %v, want heap", result.Details["source"])
+	}
+	if _, ok := result.Details["alloc_bytes"]; !ok {
+		t.Error("fallback should report heap details")
+	}
+}
+
+func TestMemoryChecker_HeapSourceIgnoresCgroup(t *testing.T) {
+	checker := NewMemoryChecker(MemoryCheckerConfig{})
+	checker.cgroup = func() (cgroupMemory, error) {
+		t.Error("cgroup reader should not be called for MemorySourceHeap")
+		return cgroupMemory{}, nil
+	}
+
+	result := checker.Check(context.Background())
+
+	if result.Details["source"] != "heap" {
+		t.Errorf("source = %v, want heap", result.Details["source"])
+	}
+}
+
+func TestMemorySource_String(t *testing.T) {
+	tests := []struct {
+		source MemorySource
+		want   string
+	}{
+		{MemorySourceHeap, "heap"},
+		{MemorySourceCgroup, "cgroup"},
+		{MemorySource(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.source.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterPrometheus registers gauges reporting agg's latest check results
+// with reg, or with prometheus.DefaultRegisterer if reg is nil:
+//
+//   - toolops_health_check_status{check="..."}: 0 healthy, 1 degraded, 2 unhealthy
+//   - toolops_health_check_duration_seconds{check="..."}: duration of the last run
+//
+// The gauges are updated each time a check runs through agg and are
+// reported only for registered checkers that have run at least once, so an
+// unregistered checker's series disappears.
+func RegisterPrometheus(agg *Aggregator, reg prometheus.Registerer) error {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	c := &promCollector{
+		agg:    agg,
+		latest: make(map[string]Result),
+		status: prometheus.NewDesc(
+			"toolops_health_check_status",
+			"Latest health check status: 0 healthy, 1 degraded, 2 unhealthy.",
+			[]string{"check"}, nil,
+		),
+		duration: prometheus.NewDesc(
+			"toolops_health_check_duration_seconds",
+			"Duration of the latest health check run in seconds.",
+			[]string{"check"}, nil,
+		),
+	}
+	if err := reg.Register(c); err != nil {
+		return err
+	}
+
+	agg.OnResult(c.update)
+	return nil
+}
+
+// promCollector exports the latest result of each checker.
+type promCollector struct {
+	agg      *Aggregator
+	status   *prometheus.Desc
+	duration *prometheus.Desc
+
+	mu     sync.Mutex
+	latest map[string]Result
+}
+
+// update stores result as the latest for name.
+func (c *promCollector) update(name string, result Result) {
+	c.mu.Lock()
+	c.latest[name] = result
+	c.mu.Unlock()
+}
+
+// Describe implements prometheus.Collector.
+func (c *promCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.status
+	ch <- c.duration
+}
+
+// Collect implements prometheus.Collector.
+func (c *promCollector) Collect(ch chan<- prometheus.Metric) {
+	names := c.agg.CheckerNames()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		result, ok := c.latest[name]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.status, prometheus.GaugeValue, float64(result.Status), name)
+		ch <- prometheus.MustNewConstMetric(c.duration, prometheus.GaugeValue, result.Duration.Seconds(), name)
+	}
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// gatherGauges returns the value of each gauge in family, keyed by the
+// "check" label.
+func gatherGauges(t *testing.T, reg *prometheus.Registry, family string) map[string]float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, mf := range families {
+		if mf.GetName() != family {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "check" {
+					values[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestRegisterPrometheus(t *testing.T) {
+	var down atomic.Bool
+	agg := NewAggregator()
+	agg.Register("database", NewCheckerFunc("database", func(ctx context.Context) Result {
+		if down.Load() {
+			return Unhealthy("down", nil)
+		}
+		return Healthy("ok")
+	}))
+	agg.Register("cache", NewCheckerFunc("cache", func(ctx context.Context) Result {
+		time.Sleep(10 * time.Millisecond)
+		return Degraded("slow")
+	}))
+
+	reg := prometheus.NewRegistry()
+	if err := RegisterPrometheus(agg, reg); err != nil {
+		t.Fatalf("RegisterPrometheus() error = %v", err)
+	}
+
+	if got := gatherGauges(t, reg, "toolops_health_check_status"); len(got) != 0 {
+		t.Errorf("status gauges before any run = %v, want none", got)
+	}
+
+	_ = agg.CheckAll(context.Background())
+
+	status := gatherGauges(t, reg, "toolops_health_check_status")
+	if status["database"] != 0 {
+		t.Errorf("database status = %v, want 0", status["database"])
+	}
+	if status["cache"] != 1 {
+		t.Errorf("cache status = %v, want 1", status["cache"])
+	}
+	duration := gatherGauges(t, reg, "toolops_health_check_duration_seconds")
+	if duration["cache"] < 0.01 {
+		t.Errorf("cache duration = %v, want at least 0.01", duration["cache"])
+	}
+
+	// The latest result wins
+	down.Store(true)
+	if _, err := agg.Check(context.Background(), "database"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	status = gatherGauges(t, reg, "toolops_health_check_status")
+	if status["database"] != 2 {
+		t.Errorf("database status = %v, want 2", status["database"])
+	}
+
+	// Unregistered checkers are no longer reported
+	agg.Unregister("cache")
+	status = gatherGauges(t, reg, "toolops_health_check_status")
+	if _, ok := status["cache"]; ok {
+		t.Error("status gauge should not be reported for unregistered checker")
+	}
+}
+
+func TestRegisterPrometheus_DuplicateRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if err := RegisterPrometheus(NewAggregator(), reg); err != nil {
+		t.Fatalf("RegisterPrometheus() error = %v", err)
+	}
+	if err := RegisterPrometheus(NewAggregator(), reg); err == nil {
+		t.Error("second RegisterPrometheus() on the same registry should fail")
+	}
+}
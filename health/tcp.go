@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TCPCheckerConfig configures the TCP connect health checker.
+type TCPCheckerConfig struct {
+	// Address is the host:port to connect to, such as a message broker or
+	// a database that exposes no HTTP endpoint.
+	Address string
+
+	// Name is the checker name.
+	// Default: "tcp:" + Address
+	Name string
+
+	// Timeout bounds each connection attempt. A shorter deadline on the
+	// context passed to Check takes precedence.
+	// Default: 5 seconds
+	Timeout time.Duration
+}
+
+// TCPChecker checks that a TCP connection can be established to an address.
+// The connection is closed immediately; nothing is sent.
+type TCPChecker struct {
+	config TCPCheckerConfig
+	dial   func(ctx context.Context, network, address string) (net.Conn, error) // Replaced in tests
+}
+
+// NewTCPChecker creates a new TCP connect health checker.
+func NewTCPChecker(config TCPCheckerConfig) *TCPChecker {
+	if config.Name == "" {
+		config.Name = "tcp:" + config.Address
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	var dialer net.Dialer
+	return &TCPChecker{config: config, dial: dialer.DialContext}
+}
+
+// Name returns the name of this checker.
+func (t *TCPChecker) Name() string {
+	return t.config.Name
+}
+
+// Check dials the configured address. Cancelling ctx, such as when an
+// Aggregator timeout fires, aborts a hanging dial.
+func (t *TCPChecker) Check(ctx context.Context) Result {
+	details := map[string]any{
+		"address": t.config.Address,
+	}
+
+	if t.config.Address == "" {
+		return Unhealthy("no address configured", ErrCheckFailed).WithDetails(details)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := t.dial(ctx, "tcp", t.config.Address)
+	details["dial_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		return Unhealthy(
+			fmt.Sprintf("cannot connect to %s", t.config.Address),
+			fmt.Errorf("%w: %w", ErrCheckFailed, err),
+		).WithDetails(details)
+	}
+	_ = conn.Close()
+
+	return Healthy(
+		fmt.Sprintf("connected to %s", t.config.Address),
+	).WithDetails(details)
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNewTCPChecker(t *testing.T) {
+	checker := NewTCPChecker(TCPCheckerConfig{Address: "localhost:5672"})
+
+	if checker.config.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", checker.config.Timeout)
+	}
+	if checker.Name() != "tcp:localhost:5672" {
+		t.Errorf("Name() = %v, want 'tcp:localhost:5672'", checker.Name())
+	}
+
+	named := NewTCPChecker(TCPCheckerConfig{Address: "localhost:5672", Name: "broker"})
+	if named.Name() != "broker" {
+		t.Errorf("Name() = %v, want 'broker'", named.Name())
+	}
+}
+
+func TestTCPChecker_Success(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	checker := NewTCPChecker(TCPCheckerConfig{Address: ln.Addr().String()})
+	result := checker.Check(context.Background())
+
+	if result.Status != StatusHealthy {
+		t.Errorf("Status = %v, want StatusHealthy (%s: %v)", result.Status, result.Message, result.Error)
+	}
+	if result.Details["address"] != ln.Addr().String() {
+		t.Errorf("Details[address] = %v, want %v", result.Details["address"], ln.Addr().String())
+	}
+}
+
+func TestTCPChecker_ClosedPort(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	checker := NewTCPChecker(TCPCheckerConfig{Address: addr, Timeout: time.Second})
+	result := checker.Check(context.Background())
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy", result.Status)
+	}
+	if !errors.Is(result.Error, ErrCheckFailed) {
+		t.Errorf("Error = %v, want ErrCheckFailed", result.Error)
+	}
+	var opErr *net.OpError
+	if !errors.As(result.Error, &opErr) {
+		t.Errorf("Error = %v, want it to wrap the dial error", result.Error)
+	}
+}
+
+func TestTCPChecker_NoAddress(t *testing.T) {
+	checker := NewTCPChecker(TCPCheckerConfig{})
+	result := checker.Check(context.Background())
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy", result.Status)
+	}
+}
+
+func TestTCPChecker_ContextCancelsDial(t *testing.T) {
+	checker := NewTCPChecker(TCPCheckerConfig{Address: "10.255.255.1:9", Timeout: time.Minute})
+	dialDone := make(chan struct{})
+	checker.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		defer close(dialDone)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	agg := NewAggregator(AggregatorConfig{Timeout: 20 * time.Millisecond})
+	agg.Register("broker", checker)
+
+	results := agg.CheckAll(context.Background())
+	if results["broker"].Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy", results["broker"].Status)
+	}
+
+	select {
+	case <-dialDone:
+	case <-time.After(time.Second):
+		t.Error("dial was not cancelled by the aggregator timeout")
+	}
+}
+
+func TestTCPChecker_DialTimeout(t *testing.T) {
+	checker := NewTCPChecker(TCPCheckerConfig{Address: "10.255.255.1:9", Timeout: 20 * time.Millisecond})
+	checker.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	result := checker.Check(context.Background())
+
+	if result.Status != StatusUnhealthy {
+		t.Errorf("Status = %v, want StatusUnhealthy", result.Status)
+	}
+	if !errors.Is(result.Error, context.DeadlineExceeded) {
+		t.Errorf("Error = %v, want context.DeadlineExceeded", result.Error)
+	}
+}
@@ -0,0 +1,173 @@
+package observe
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy selects what an AsyncWriter does when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Write wait for buffer space, so no entry is lost
+	// but a stalled writer slows callers down.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop discards the entry and counts it in Dropped, so callers
+	// never wait on log output.
+	OverflowDrop
+)
+
+// String returns the policy name.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+// AsyncWriterConfig configures an AsyncWriter.
+type AsyncWriterConfig struct {
+	// BufferSize is the number of entries that can be queued.
+	// Default: 1024
+	BufferSize int
+
+	// Policy decides what happens when the buffer is full.
+	// Default: OverflowBlock
+	Policy OverflowPolicy
+}
+
+// asyncItem is one queued write, or a flush request when flushed is set.
+type asyncItem struct {
+	data    []byte
+	flushed chan error
+}
+
+// AsyncWriter is an io.WriteCloser that queues writes in a bounded buffer
+// and writes them to the underlying writer from a background goroutine, so
+// loggers do not serialize on slow output under high concurrency.
+//
+// Contract:
+//   - Concurrency: All methods are safe for concurrent use.
+//   - Ordering: Entries reach the underlying writer in the order queued.
+//   - Errors: Write fails only after Close, with ErrLogWriterClosed; errors
+//     from the underlying writer are not reported.
+//   - Lifecycle: Close drains the buffer and stops the goroutine; it does
+//     not close the underlying writer and is idempotent.
+type AsyncWriter struct {
+	out    io.Writer
+	policy OverflowPolicy
+	items  chan asyncItem
+	done   chan struct{}
+
+	mu     sync.RWMutex // excludes Close while Write or Flush enqueue
+	closed bool
+
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter starts a background goroutine writing queued entries to
+// out. Call Close to stop it.
+func NewAsyncWriter(out io.Writer, config AsyncWriterConfig) *AsyncWriter {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1024
+	}
+
+	w := &AsyncWriter{
+		out:    out,
+		policy: config.Policy,
+		items:  make(chan asyncItem, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write queues a copy of p. Under OverflowDrop, a full buffer discards p
+// and still reports success.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, ErrLogWriterClosed
+	}
+
+	item := asyncItem{data: append([]byte(nil), p...)}
+	if w.policy == OverflowDrop {
+		select {
+		case w.items <- item:
+		default:
+			w.dropped.Add(1)
+		}
+		return len(p), nil
+	}
+
+	w.items <- item
+	return len(p), nil
+}
+
+// Flush waits until every entry queued before the call has been written,
+// then flushes the underlying writer if it has a Flush() error method
+// (such as RotatingFile).
+func (w *AsyncWriter) Flush() error {
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		return nil
+	}
+	flushed := make(chan error, 1)
+	w.items <- asyncItem{flushed: flushed}
+	w.mu.RUnlock()
+
+	return <-flushed
+}
+
+// Close writes every queued entry, flushes the underlying writer, and
+// stops the background goroutine.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.items)
+	w.mu.Unlock()
+
+	<-w.done
+	return w.flushOut()
+}
+
+// Dropped returns the number of entries discarded under OverflowDrop.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// run writes queued entries until the queue is closed and drained.
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	for item := range w.items {
+		if item.flushed != nil {
+			item.flushed <- w.flushOut()
+			continue
+		}
+		_, _ = w.out.Write(item.data)
+	}
+}
+
+// flushOut flushes the underlying writer if it buffers.
+func (w *AsyncWriter) flushOut() error {
+	if f, ok := w.out.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Ensure AsyncWriter implements io.WriteCloser
+var _ io.WriteCloser = (*AsyncWriter)(nil)
@@ -0,0 +1,235 @@
+package observe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowWriter records writes, optionally waiting on gate before each one.
+type slowWriter struct {
+	gate chan struct{}
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	flushes int
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	if w.gate != nil {
+		<-w.gate
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *slowWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushes++
+	return nil
+}
+
+func (w *slowWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Split(strings.TrimSuffix(w.buf.String(), "\n"), "\n")
+}
+
+// TestAsyncWriter_BlockNoLoss verifies every entry is written under the
+// block policy, even with a buffer far smaller than the number of writes.
+func TestAsyncWriter_BlockNoLoss(t *testing.T) {
+	out := &slowWriter{}
+	w := NewAsyncWriter(out, AsyncWriterConfig{BufferSize: 8, Policy: OverflowBlock})
+	logger := NewLoggerWithWriter("info", w)
+
+	const goroutines, perGoroutine = 8, 250
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				logger.Info(context.Background(), fmt.Sprintf("entry %d-%d", g, i))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := len(out.lines()); got != goroutines*perGoroutine {
+		t.Errorf("written entries = %d, want %d", got, goroutines*perGoroutine)
+	}
+	if w.Dropped() != 0 {
+		t.Errorf("Dropped() = %d, want 0", w.Dropped())
+	}
+}
+
+// TestAsyncWriter_DropBoundedLoss verifies writes never block under the
+// drop policy and that only entries beyond the buffer are lost.
+func TestAsyncWriter_DropBoundedLoss(t *testing.T) {
+	out := &slowWriter{gate: make(chan struct{})}
+	w := NewAsyncWriter(out, AsyncWriterConfig{BufferSize: 4, Policy: OverflowDrop})
+
+	const writes = 100
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < writes; i++ {
+			if _, err := fmt.Fprintf(w, "entry %d\n", i); err != nil {
+				t.Errorf("Write() error = %v", err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("writes blocked under OverflowDrop")
+	}
+
+	close(out.gate)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	written := uint64(len(out.lines()))
+	// The buffer holds 4 entries and the stalled writer holds at most 1
+	if written > 5 {
+		t.Errorf("written entries = %d, want at most 5", written)
+	}
+	if written+w.Dropped() != writes {
+		t.Errorf("written (%d) + dropped (%d) = %d, want %d", written, w.Dropped(), written+w.Dropped(), writes)
+	}
+}
+
+// TestAsyncWriter_FlushWritesQueued verifies Flush returns only after
+// earlier entries are written and the underlying writer is flushed.
+func TestAsyncWriter_FlushWritesQueued(t *testing.T) {
+	out := &slowWriter{}
+	w := NewAsyncWriter(out, AsyncWriterConfig{})
+	defer func() { _ = w.Close() }()
+
+	for i := 0; i < 50; i++ {
+		_, _ = fmt.Fprintf(w, "entry %d\n", i)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	lines := out.lines()
+	if len(lines) != 50 {
+		t.Fatalf("written entries = %d, want 50", len(lines))
+	}
+	for i, line := range lines {
+		if line != fmt.Sprintf("entry %d", i) {
+			t.Fatalf("line %d = %q, entries out of order", i, line)
+		}
+	}
+	if out.flushes != 1 {
+		t.Errorf("underlying flushes = %d, want 1", out.flushes)
+	}
+}
+
+// TestAsyncWriter_WriteAfterClose verifies Close is idempotent and later
+// writes fail with ErrLogWriterClosed.
+func TestAsyncWriter_WriteAfterClose(t *testing.T) {
+	w := NewAsyncWriter(&slowWriter{}, AsyncWriterConfig{})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Errorf("Flush() after Close error = %v", err)
+	}
+	if _, err := w.Write([]byte("late\n")); !errors.Is(err, ErrLogWriterClosed) {
+		t.Errorf("Write() after Close error = %v, want ErrLogWriterClosed", err)
+	}
+}
+
+// TestAsyncWriter_NoGoroutineLeak verifies Close stops the background
+// goroutine.
+func TestAsyncWriter_NoGoroutineLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		w := NewAsyncWriter(&slowWriter{}, AsyncWriterConfig{Policy: OverflowPolicy(i % 2)})
+		_, _ = w.Write([]byte("entry\n"))
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines = %d after Close, want at most %d", after, before)
+	}
+}
+
+// TestObserver_AsyncLogging verifies LoggingConfig.Async routes entries
+// through an AsyncWriter that ForceFlush and Shutdown drain.
+func TestObserver_AsyncLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	obs, err := NewObserver(context.Background(), Config{
+		ServiceName: "test-service",
+		Logging: LoggingConfig{
+			Enabled: true,
+			Level:   "info",
+			File:    LogFileConfig{Path: path},
+			Async:   LogAsyncConfig{Enabled: true, BufferSize: 16},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+
+	obs.Logger().Info(context.Background(), "first")
+	if err := obs.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "first") {
+		t.Errorf("entry not written after ForceFlush: %q", data)
+	}
+
+	obs.Logger().Info(context.Background(), "second")
+	if err := obs.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "second") {
+		t.Errorf("entry not written after Shutdown: %q", data)
+	}
+}
+
+// TestOverflowPolicy_String verifies policy names.
+func TestOverflowPolicy_String(t *testing.T) {
+	tests := []struct {
+		policy OverflowPolicy
+		want   string
+	}{
+		{OverflowBlock, "block"},
+		{OverflowDrop, "drop"},
+		{OverflowPolicy(99), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.String(); got != tt.want {
+			t.Errorf("OverflowPolicy(%d).String() = %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
@@ -82,6 +82,37 @@ func BenchmarkLogger_LevelFiltering(b *testing.B) {
 	}
 }
 
+// BenchmarkLogger_FilteredInfo measures a filtered Info call, which should
+// return before any field processing and allocate nothing.
+func BenchmarkLogger_FilteredInfo(b *testing.B) {
+	logger := NewLoggerWithWriter("error", io.Discard)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "filtered info")
+	}
+}
+
+// BenchmarkLogger_EnabledGuard measures skipping field construction with
+// LogEnabled on a hot path where the level is disabled.
+func BenchmarkLogger_EnabledGuard(b *testing.B) {
+	logger := NewLoggerWithWriter("error", io.Discard)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if LogEnabled(logger, LevelInfo) {
+			logger.Info(ctx, "tool executed",
+				Field{Key: "iteration", Value: i},
+				Field{Key: "payload", Value: map[string]any{"n": i}},
+			)
+		}
+	}
+}
+
 // BenchmarkToolMeta_SpanName measures span name generation.
 func BenchmarkToolMeta_SpanName(b *testing.B) {
 	meta := ToolMeta{
@@ -227,6 +258,45 @@ func BenchmarkMiddleware_Wrap(b *testing.B) {
 	}
 }
 
+// BenchmarkMiddleware_TracingMode compares per-call cost of each TracingMode.
+// MetricsOnly should allocate less than Full since no span is created.
+func BenchmarkMiddleware_TracingMode(b *testing.B) {
+	ctx := context.Background()
+	obs, err := NewObserver(ctx, Config{
+		ServiceName: "bench",
+		Tracing:     TracingConfig{Enabled: true, Exporter: "none", SamplePct: 1.0},
+		Metrics:     MetricsConfig{Enabled: true, Exporter: "none"},
+		Logging:     LoggingConfig{Enabled: false},
+	})
+	if err != nil {
+		b.Fatalf("failed to create observer: %v", err)
+	}
+	defer func() {
+		_ = obs.Shutdown(ctx)
+	}()
+
+	execFn := func(ctx context.Context, tool ToolMeta, input any) (any, error) {
+		return "result", nil
+	}
+	meta := ToolMeta{Name: "bench_tool", Namespace: "ns"}
+
+	for _, mode := range []TracingMode{TracingFull, TracingMetricsOnly, TracingOff} {
+		b.Run(mode.String(), func(b *testing.B) {
+			mw, err := MiddlewareFromObserverWithConfig(obs, MiddlewareConfig{TracingMode: mode})
+			if err != nil {
+				b.Fatalf("failed to create middleware: %v", err)
+			}
+			wrapped := mw.Wrap(execFn)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = wrapped(ctx, meta, nil)
+			}
+		})
+	}
+}
+
 // BenchmarkMiddleware_Wrap_WithLogging measures middleware with logging enabled.
 func BenchmarkMiddleware_Wrap_WithLogging(b *testing.B) {
 	ctx := context.Background()
@@ -279,6 +349,24 @@ func BenchmarkConcurrent_Logger(b *testing.B) {
 	})
 }
 
+// BenchmarkConcurrent_LoggerAsync measures concurrent logging through an
+// AsyncWriter, which moves the write off the calling goroutines.
+func BenchmarkConcurrent_LoggerAsync(b *testing.B) {
+	w := NewAsyncWriter(io.Discard, AsyncWriterConfig{BufferSize: 4096})
+	defer func() { _ = w.Close() }()
+	logger := NewLoggerWithWriter("info", w)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			logger.Info(ctx, "concurrent message", Field{Key: "iteration", Value: i})
+			i++
+		}
+	})
+}
+
 // BenchmarkConcurrent_Middleware measures concurrent middleware execution.
 func BenchmarkConcurrent_Middleware(b *testing.B) {
 	ctx := context.Background()
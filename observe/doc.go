@@ -18,6 +18,8 @@
 //   - [Metrics]: Records execution counts, errors, and duration histograms
 //   - [Logger]: Structured JSON logging with sensitive field redaction
 //   - [Middleware]: Wraps ExecuteFunc with complete observability
+//   - [RotatingFile]: Buffered, size-rotated log file writer (LoggingConfig.File)
+//   - [AsyncWriter]: Bounded background log writer (LoggingConfig.Async)
 //
 // # Quick Start
 //
@@ -42,6 +44,34 @@
 //	// Execute - automatically traced, metered, and logged
 //	result, err := wrappedExec(ctx, toolMeta, input)
 //
+// Config.ResourceAttributes tags every span and metric with static
+// deployment details such as {"deployment.environment": "prod"}.
+//
+// Short-lived batch jobs and tests should call Observer.ForceFlush before
+// exiting or asserting on exported data; unlike Shutdown, it leaves the
+// observer usable:
+//
+//	if err := obs.ForceFlush(ctx); err != nil {
+//	    log.Printf("telemetry flush: %v", err)
+//	}
+//
+// # Tracing Modes
+//
+// MiddlewareConfig.TracingMode selects per-middleware telemetry:
+// [TracingFull] (default) records spans, metrics, and logs;
+// [TracingMetricsOnly] skips span creation for high-throughput paths; and
+// [TracingOff] records logs only.
+//
+// MiddlewareConfig.RecordPayloadSizes adds tool.input_bytes and
+// tool.output_bytes to each span, sizing payloads without recording them.
+//
+// # Libraries and Defaults
+//
+// Libraries that accept an Observer should default to [NewNoopObserver]
+// rather than checking for nil at every call site. [MiddlewareFromObserver]
+// treats a nil Observer the same way, and [NewMiddleware] substitutes no-op
+// implementations for nil components.
+//
 // # Telemetry Details
 //
 // Tracing creates spans with deterministic names:
@@ -63,6 +93,45 @@
 //   - tool.exec.duration_ms (histogram): Duration distribution in milliseconds
 //
 // All metrics include labels: tool.id, tool.name, tool.namespace (if set).
+// MetricsConfig.ExtraLabels adds tool.version ([MetricLabelVersion]) or
+// tool.category ([MetricLabelCategory]) to slice error rates per deploy or
+// category. Each extra label multiplies series count; tool.version gains new
+// values with every release.
+//
+// With hundreds of tools, per-tool series overwhelm dashboards and storage
+// (each histogram alone is tools × buckets). MetricsConfig.Rollup aggregates
+// tool.exec.* by tool.namespace alone, bounding cardinality by the number of
+// namespaces:
+// [RollupAdditive] adds "<name>.by_namespace" series next to the per-tool
+// ones, and [RollupReplace] keeps only the namespace rollup.
+//
+// When tool names are dynamic or user-supplied, MetricsConfig.MaxToolIDs
+// caps the distinct tool.id values: tools past the limit share one series
+// labeled MetricsConfig.OverflowToolID (default "other").
+//
+// The default histogram boundaries (0 to 10000 ms) suit neither
+// sub-millisecond nor multi-minute tools; MetricsConfig.DurationBuckets
+// replaces them:
+//
+//	Metrics: observe.MetricsConfig{Enabled: true, DurationBuckets: []float64{0.1, 0.5, 1, 5, 25}},
+//
+// # Span Events
+//
+// Retries and cache lookups inside a tool execution are invisible in a
+// trace unless recorded. [RecordRetryAttempt], [RecordCacheHit], and
+// [RecordCacheMiss] add "retry.attempt", "cache.hit", and "cache.miss"
+// events to the span in a context, so a slow span shows why it was slow.
+//
+// # Business Events
+//
+// Observer.Event records application events such as "order_placed" through
+// the logger, so they share JSON formatting, redaction, and trace
+// correlation with other log entries. Names listed in MetricsConfig.Events
+// also increment an "event.<name>" counter. Keep that list small and static:
+// each name is a separate metric, and event fields are deliberately not
+// attached as metric attributes because IDs and free-form values would
+// create unbounded cardinality. Put high-cardinality detail in the fields,
+// where it stays in logs.
 //
 // # Sensitive Field Redaction
 //
@@ -71,39 +140,140 @@
 //   - password, secret, token
 //   - api_key, apiKey, credential
 //
-// See [RedactedFields] for the complete list.
+// See [RedactedFields] for the complete list. Keys are matched
+// case-insensitively, and keys inside map values are redacted at any depth,
+// so a "headers" field holding {"Authorization": ...} is masked too once
+// "authorization" is redacted. Extend the set with [WithRedactedFields] or
+// LoggingConfig.RedactFields; replace it by adding [WithoutDefaultRedaction]
+// or LoggingConfig.DisableDefaultRedaction:
+//
+//	logger := observe.NewLogger("info", observe.WithRedactedFields("ssn", "authorization", "cookie"))
+//
+// Secrets can also hide in values under harmless keys. [WithRedactPatterns]
+// (or LoggingConfig.RedactPatterns) masks regular expression matches in the
+// message and string values:
+//
+//	bearer := regexp.MustCompile(`Bearer\s+[A-Za-z0-9._~+/-]+=*`)
+//	logger := observe.NewLogger("info", observe.WithRedactPatterns(bearer))
+//
+// # Trace Correlation
+//
+// When a log call's context carries a valid span, the entry includes
+// trace_id and span_id fields so logs can be joined with traces. Disable
+// with [WithCorrelation] or LoggingConfig.Correlation.
+//
+// # Log Format
+//
+// Logs are JSON lines by default. For local development, [LogFormatText]
+// (LoggingConfig.Format or [WithFormat]) writes compact lines instead, with
+// the same redaction:
+//
+//	2026-01-02T15:04:05.123Z INFO  tool executed tool.name=read_file duration_ms=12
+//
+// # Runtime Log Level
+//
+// Loggers implement [LevelSetter], so verbosity can change without a
+// restart, e.g. from an admin endpoint. Tool-scoped loggers share the level
+// of the logger they came from:
+//
+//	if ls, ok := obs.Logger().(observe.LevelSetter); ok {
+//	    err := ls.SetLevel("debug")
+//	}
+//
+// Filtered calls return before touching their fields and allocate nothing.
+// Hot paths can also skip building expensive fields with [LogEnabled].
+//
+// # Asynchronous Logging
+//
+// Loggers write each entry synchronously under a mutex, so slow output
+// serializes callers. LoggingConfig.Async (or [NewAsyncWriter] around any
+// writer) queues entries in a bounded buffer written by a background
+// goroutine. With [OverflowBlock] (the default) a full buffer makes callers
+// wait and no entry is lost; with [OverflowDrop] entries are discarded and
+// counted instead. Observer.ForceFlush and Shutdown drain the buffer.
+//
+// # Log Sampling
+//
+// [WithSampling] (or LoggingConfig.SampleRate) writes only a fraction of
+// debug and info entries, capping per-call log volume under load. Warn and
+// error entries are never sampled.
+//
+// # Outgoing HTTP
+//
+// [HTTPTransport] records a client span for each outbound request and
+// injects W3C trace context headers. [NewHTTPClient] wraps an http.Client
+// with it; pass the result as auth's OAuth2Config.HTTPClient or
+// JWKSConfig.HTTPClient so introspection and JWKS fetches appear as child
+// spans of the authenticating request:
+//
+//	client := observe.NewHTTPClient(obs, &http.Client{Timeout: 10 * time.Second})
+//	authn := auth.NewOAuth2IntrospectionAuthenticator(auth.OAuth2Config{
+//	    IntrospectionEndpoint: endpoint,
+//	    HTTPClient:            client,
+//	})
+//
+// For requests sent some other way, [InjectContext] writes the headers
+// directly.
+//
+// # Incoming HTTP
+//
+// [ObserverHTTPMiddleware] extracts the caller's traceparent header and
+// runs the handler inside a server span in the same trace, so spans link
+// across service boundaries:
+//
+//	mux.Handle("/invoke", observe.ObserverHTTPMiddleware(obs, invokeHandler))
 //
 // # Exporter Configuration
 //
 // Tracing exporters:
 //   - "otlp": OTLP gRPC (requires OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT)
 //   - "jaeger": Jaeger via OTLP (requires OTEL_EXPORTER_JAEGER_ENDPOINT)
+//   - "zipkin": Zipkin over HTTP (requires OTEL_EXPORTER_ZIPKIN_ENDPOINT)
+//   - "datadog": Datadog Agent OTLP intake (requires DD_AGENT_HOST)
 //   - "stdout": Console output for development
 //   - "none" or "": Disabled (no-op)
 //
 // Metrics exporters:
 //   - "otlp": OTLP gRPC (requires OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT)
 //   - "prometheus": Prometheus scrape endpoint
+//   - "datadog": Datadog Agent OTLP intake, delta temporality (requires DD_AGENT_HOST)
 //   - "stdout": Console output for development
 //   - "none" or "": Disabled (no-op)
 //
+// The "datadog" exporters send OTLP gRPC to DD_AGENT_HOST on
+// DD_TRACE_AGENT_PORT (default 4317), which must be the Agent's OTLP
+// receiver port rather than its native 8126 intake. DD_ENV and DD_VERSION,
+// when set, fill in deployment.environment and service.version so spans and
+// metrics land under Datadog's env and version tags.
+//
 // # Thread Safety
 //
 // All exported types are safe for concurrent use after construction:
-//   - [Observer]: Tracer(), Meter(), Logger() are safe; Shutdown() is idempotent
+//   - [Observer]: Tracer(), Meter(), Logger(), ForceFlush() are safe; Shutdown() is idempotent
 //   - [Tracer]: StartSpan() and EndSpan() are safe for concurrent use
 //   - [Metrics]: RecordExecution() is safe for concurrent use
-//   - [Logger]: All logging methods are mutex-protected
+//   - [Logger]: All logging methods are mutex-protected; SetLevel() is atomic
 //   - [Middleware]: Wrap() returns a thread-safe ExecuteFunc
+//   - [AsyncWriter]: Write(), Flush(), Close() are safe; entries keep their queue order
+//   - [HTTPTransport]: Safe if the base transport is
+//   - [ObserverHTTPMiddleware]: Safe if the wrapped handler is
 //
 // # Error Handling
 //
 // Configuration errors (use errors.Is for checking):
 //   - [ErrMissingServiceName]: Config.ServiceName is empty
+//   - [ErrInvalidResourceAttribute]: Config.ResourceAttributes has an empty key
 //   - [ErrInvalidSamplePct]: Tracing.SamplePct not in [0.0, 1.0]
 //   - [ErrInvalidTracingExporter]: Unknown tracing exporter name
 //   - [ErrInvalidMetricsExporter]: Unknown metrics exporter name
+//   - [ErrInvalidMetricsRollup]: Unknown metrics rollup mode
+//   - [ErrInvalidMetricsLabel]: Unknown metrics extra label
+//   - [ErrInvalidDurationBuckets]: Metrics.DurationBuckets not positive and strictly increasing
 //   - [ErrInvalidLogLevel]: Unknown log level
+//   - [ErrInvalidLogFile]: Invalid log file rotation settings
+//   - [ErrInvalidLogFormat]: Unknown log format
+//   - [ErrInvalidLogSampleRate]: Logging.SampleRate not in [0.0, 1.0]
+//   - [ErrInvalidRedactPattern]: Logging redact pattern is not a valid regular expression
 //
 // Exporter errors:
 //   - [ErrEndpointNotConfigured]: Required endpoint env var not set
@@ -111,6 +281,8 @@
 // Runtime errors:
 //   - [ErrNilObserver]: Nil Observer passed to function
 //   - [ErrMissingToolName]: ToolMeta.Name is empty
+//   - [ErrToolPanic]: Wrapped tool panicked (with MiddlewareConfig.RecoverPanics)
+//   - [ErrLogWriterClosed]: AsyncWriter written to after Close
 //
 // Example error handling:
 //
@@ -1,12 +1,20 @@
 package observe
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/jonwraymond/toolops/observe/exporters"
+)
 
 // Configuration errors.
 var (
 	// ErrMissingServiceName indicates Config.ServiceName is empty.
 	ErrMissingServiceName = errors.New("observe: service name is required")
 
+	// ErrInvalidResourceAttribute indicates a Config.ResourceAttributes key
+	// is empty.
+	ErrInvalidResourceAttribute = errors.New("observe: invalid resource attribute")
+
 	// ErrInvalidSamplePct indicates Tracing.SamplePct is not in [0.0, 1.0].
 	ErrInvalidSamplePct = errors.New("observe: sample percentage must be between 0.0 and 1.0")
 
@@ -16,8 +24,32 @@ var (
 	// ErrInvalidMetricsExporter indicates an unknown metrics exporter name.
 	ErrInvalidMetricsExporter = errors.New("observe: invalid metrics exporter")
 
+	// ErrInvalidMetricsRollup indicates an unknown MetricsConfig.Rollup mode.
+	ErrInvalidMetricsRollup = errors.New("observe: invalid metrics rollup")
+
+	// ErrInvalidMetricsLabel indicates an unknown MetricsConfig.ExtraLabels entry.
+	ErrInvalidMetricsLabel = errors.New("observe: invalid metrics label")
+
+	// ErrInvalidDurationBuckets indicates MetricsConfig.DurationBuckets are
+	// not positive and strictly increasing.
+	ErrInvalidDurationBuckets = errors.New("observe: invalid duration buckets")
+
 	// ErrInvalidLogLevel indicates an unknown log level.
 	ErrInvalidLogLevel = errors.New("observe: invalid log level")
+
+	// ErrInvalidLogFile indicates an invalid log file configuration.
+	ErrInvalidLogFile = errors.New("observe: invalid log file configuration")
+
+	// ErrInvalidLogFormat indicates an unknown LoggingConfig.Format.
+	ErrInvalidLogFormat = errors.New("observe: invalid log format")
+
+	// ErrInvalidLogSampleRate indicates Logging.SampleRate is not in
+	// [0.0, 1.0].
+	ErrInvalidLogSampleRate = errors.New("observe: log sample rate must be between 0.0 and 1.0")
+
+	// ErrInvalidRedactPattern indicates a LoggingConfig.RedactPatterns entry
+	// is not a valid regular expression.
+	ErrInvalidRedactPattern = errors.New("observe: invalid redact pattern")
 )
 
 // Runtime errors.
@@ -27,12 +59,20 @@ var (
 
 	// ErrMissingToolName indicates ToolMeta.Name is empty.
 	ErrMissingToolName = errors.New("observe: tool name is required")
+
+	// ErrToolPanic indicates the wrapped tool panicked during execution.
+	// Returned by Middleware when MiddlewareConfig.RecoverPanics is enabled.
+	ErrToolPanic = errors.New("observe: tool panicked")
+
+	// ErrLogWriterClosed indicates a write to an AsyncWriter after Close.
+	ErrLogWriterClosed = errors.New("observe: log writer closed")
 )
 
 // Exporter errors.
 var (
 	// ErrEndpointNotConfigured indicates a required endpoint environment variable is not set.
-	ErrEndpointNotConfigured = errors.New("observe: endpoint not configured")
+	// It is the error NewObserver wraps when an exporter cannot be created.
+	ErrEndpointNotConfigured = exporters.ErrEndpointNotConfigured
 )
 
 // Validation constants.
@@ -44,16 +84,18 @@ const (
 )
 
 // ValidTracingExporters lists valid tracing exporter names.
-var ValidTracingExporters = []string{"otlp", "jaeger", "stdout", "none", ""}
+var ValidTracingExporters = []string{"otlp", "jaeger", "zipkin", "datadog", "stdout", "none", ""}
 
 // ValidMetricsExporters lists valid metrics exporter names.
-var ValidMetricsExporters = []string{"otlp", "prometheus", "stdout", "none", ""}
+var ValidMetricsExporters = []string{"otlp", "prometheus", "datadog", "stdout", "none", ""}
 
 // ValidLogLevels lists valid log level names.
 var ValidLogLevels = []string{"debug", "info", "warn", "error", ""}
 
 // RedactedFields lists field keys that are automatically redacted in logs.
 // These fields may contain sensitive information like credentials or secrets.
+// Keys are matched case-insensitively. Add keys per logger with
+// WithRedactedFields or LoggingConfig.RedactFields.
 var RedactedFields = []string{
 	"input",
 	"inputs",
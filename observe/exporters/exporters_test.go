@@ -5,6 +5,9 @@ import (
 	"errors"
 	"os"
 	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 // TestExporter_InvalidName verifies unknown exporter name returns error.
@@ -95,6 +98,124 @@ func TestExporter_JaegerMissingEndpoint(t *testing.T) {
 	}
 }
 
+// TestExporter_ZipkinMissingEndpoint verifies Zipkin without endpoint fails.
+func TestExporter_ZipkinMissingEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", "")
+
+	_, err := NewTracingExporter(context.Background(), "zipkin")
+	if err == nil {
+		t.Fatal("expected error when Zipkin endpoint not configured")
+	}
+	if !errors.Is(err, ErrEndpointNotConfigured) {
+		t.Errorf("expected ErrEndpointNotConfigured, got: %v", err)
+	}
+}
+
+// TestExporter_ZipkinWithEndpoint verifies Zipkin with endpoint env succeeds.
+func TestExporter_ZipkinWithEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", "http://localhost:9411/api/v2/spans")
+
+	exp, err := NewTracingExporter(context.Background(), "zipkin")
+	if err != nil {
+		t.Fatalf("failed to create Zipkin exporter with endpoint: %v", err)
+	}
+	if exp == nil {
+		t.Fatal("expected non-nil exporter")
+	}
+	if err := exp.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+// TestExporter_DatadogMissingEndpoint verifies Datadog without an agent
+// host fails for both traces and metrics.
+func TestExporter_DatadogMissingEndpoint(t *testing.T) {
+	t.Setenv("DD_AGENT_HOST", "")
+
+	if _, err := NewTracingExporter(context.Background(), "datadog"); !errors.Is(err, ErrEndpointNotConfigured) {
+		t.Errorf("tracing: expected ErrEndpointNotConfigured, got: %v", err)
+	}
+	if _, err := NewMetricsReader(context.Background(), "datadog"); !errors.Is(err, ErrEndpointNotConfigured) {
+		t.Errorf("metrics: expected ErrEndpointNotConfigured, got: %v", err)
+	}
+}
+
+// TestExporter_DatadogWithEndpoint verifies Datadog exporters are created
+// when the agent host is set.
+func TestExporter_DatadogWithEndpoint(t *testing.T) {
+	t.Setenv("DD_AGENT_HOST", "localhost")
+	t.Setenv("DD_TRACE_AGENT_PORT", "4317")
+
+	exp, err := NewTracingExporter(context.Background(), "datadog")
+	if err != nil {
+		t.Fatalf("failed to create Datadog tracing exporter: %v", err)
+	}
+	if exp == nil {
+		t.Fatal("expected non-nil exporter")
+	}
+	_ = exp.Shutdown(context.Background())
+
+	reader, err := NewMetricsReader(context.Background(), "datadog")
+	if err != nil {
+		t.Fatalf("failed to create Datadog metrics reader: %v", err)
+	}
+	if reader == nil {
+		t.Fatal("expected non-nil reader")
+	}
+	_ = reader.Shutdown(context.Background())
+}
+
+// TestDatadogTemporality verifies counters and histograms are exported as
+// deltas and up-down counters stay cumulative.
+func TestDatadogTemporality(t *testing.T) {
+	tests := []struct {
+		kind sdkmetric.InstrumentKind
+		want metricdata.Temporality
+	}{
+		{sdkmetric.InstrumentKindCounter, metricdata.DeltaTemporality},
+		{sdkmetric.InstrumentKindHistogram, metricdata.DeltaTemporality},
+		{sdkmetric.InstrumentKindObservableCounter, metricdata.DeltaTemporality},
+		{sdkmetric.InstrumentKindUpDownCounter, metricdata.CumulativeTemporality},
+		{sdkmetric.InstrumentKindObservableUpDownCounter, metricdata.CumulativeTemporality},
+	}
+
+	for _, tt := range tests {
+		if got := datadogTemporality(tt.kind); got != tt.want {
+			t.Errorf("datadogTemporality(%v) = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+// TestDatadogEndpoint verifies the agent host and port are combined, with
+// the OTLP gRPC port as the default.
+func TestDatadogEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		port string
+		want string
+	}{
+		{name: "default port", host: "dd-agent", want: "dd-agent:4317"},
+		{name: "custom port", host: "dd-agent", port: "4320", want: "dd-agent:4320"},
+		{name: "ipv6", host: "::1", want: "[::1]:4317"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DD_AGENT_HOST", tt.host)
+			t.Setenv("DD_TRACE_AGENT_PORT", tt.port)
+
+			got, err := DatadogEndpoint()
+			if err != nil {
+				t.Fatalf("DatadogEndpoint() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DatadogEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestExporter_PrometheusReturnsReader verifies Prometheus metrics reader.
 func TestExporter_PrometheusReturnsReader(t *testing.T) {
 	reader, err := NewMetricsReader(context.Background(), "prometheus")
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
@@ -13,7 +14,9 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/zipkin"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -32,9 +35,13 @@ var (
 //   - "stdout": Writes traces to stdout (for development)
 //   - "otlp": OTLP gRPC exporter (requires OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT)
 //   - "jaeger": Jaeger via OTLP (requires OTEL_EXPORTER_JAEGER_ENDPOINT)
+//   - "zipkin": Zipkin JSON over HTTP (requires OTEL_EXPORTER_ZIPKIN_ENDPOINT,
+//     e.g. http://localhost:9411/api/v2/spans)
+//   - "datadog": OTLP gRPC to the Datadog Agent's OTLP intake (requires
+//     DD_AGENT_HOST; see DatadogEndpoint)
 //   - "none" or "": No-op exporter (discards traces)
 //
-// Returns ErrEndpointNotConfigured if OTLP/Jaeger endpoint is not set.
+// Returns ErrEndpointNotConfigured if the OTLP/Jaeger/Zipkin/Datadog endpoint is not set.
 // Returns ErrInvalidExporter for unknown exporter names.
 func NewTracingExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
 	switch name {
@@ -61,6 +68,27 @@ func NewTracingExporter(ctx context.Context, name string) (sdktrace.SpanExporter
 		// Use OTLP exporter for Jaeger (Jaeger supports OTLP natively)
 		return otlptracegrpc.New(ctx)
 
+	case "zipkin":
+		endpoint := os.Getenv("OTEL_EXPORTER_ZIPKIN_ENDPOINT")
+		if endpoint == "" {
+			return nil, fmt.Errorf("%w: set OTEL_EXPORTER_ZIPKIN_ENDPOINT", ErrEndpointNotConfigured)
+		}
+		exp, err := zipkin.New(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Zipkin exporter: %w", err)
+		}
+		return exp, nil
+
+	case "datadog":
+		endpoint, err := DatadogEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+
 	case "none", "":
 		// Return a no-op exporter that discards everything
 		return stdouttrace.New(stdouttrace.WithWriter(io.Discard))
@@ -76,9 +104,11 @@ func NewTracingExporter(ctx context.Context, name string) (sdktrace.SpanExporter
 //   - "stdout": Writes metrics to stdout (for development)
 //   - "otlp": OTLP gRPC exporter (requires OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT)
 //   - "prometheus": Prometheus scrape endpoint
+//   - "datadog": OTLP gRPC to the Datadog Agent's OTLP intake with delta
+//     temporality (requires DD_AGENT_HOST; see DatadogEndpoint)
 //   - "none" or "": No-op reader (discards metrics)
 //
-// Returns ErrEndpointNotConfigured if OTLP endpoint is not set.
+// Returns ErrEndpointNotConfigured if the OTLP/Datadog endpoint is not set.
 // Returns ErrInvalidExporter for unknown exporter names.
 func NewMetricsReader(ctx context.Context, name string) (sdkmetric.Reader, error) {
 	switch name {
@@ -103,6 +133,21 @@ func NewMetricsReader(ctx context.Context, name string) (sdkmetric.Reader, error
 		}
 		return sdkmetric.NewPeriodicReader(exp), nil
 
+	case "datadog":
+		endpoint, err := DatadogEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		exp, err := otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpoint(endpoint),
+			otlpmetricgrpc.WithInsecure(),
+			otlpmetricgrpc.WithTemporalitySelector(datadogTemporality),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Datadog metrics exporter: %w", err)
+		}
+		return sdkmetric.NewPeriodicReader(exp), nil
+
 	case "prometheus":
 		exp, err := prometheus.New()
 		if err != nil {
@@ -122,3 +167,36 @@ func NewMetricsReader(ctx context.Context, name string) (sdkmetric.Reader, error
 		return nil, fmt.Errorf("%w: %q", ErrInvalidExporter, name)
 	}
 }
+
+// DefaultDatadogOTLPPort is the Datadog Agent's default OTLP gRPC receiver port.
+const DefaultDatadogOTLPPort = "4317"
+
+// DatadogEndpoint returns the host:port of the Datadog Agent's OTLP gRPC
+// intake, from DD_AGENT_HOST and DD_TRACE_AGENT_PORT (default
+// DefaultDatadogOTLPPort). The Agent must have its OTLP receiver enabled
+// (otlp_config.receiver.protocols.grpc); the port must be that receiver's,
+// not the Agent's native trace intake (8126).
+//
+// Returns ErrEndpointNotConfigured if DD_AGENT_HOST is not set.
+func DatadogEndpoint() (string, error) {
+	host := os.Getenv("DD_AGENT_HOST")
+	if host == "" {
+		return "", fmt.Errorf("%w: set DD_AGENT_HOST", ErrEndpointNotConfigured)
+	}
+	port := os.Getenv("DD_TRACE_AGENT_PORT")
+	if port == "" {
+		port = DefaultDatadogOTLPPort
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// datadogTemporality reports counters and histograms as deltas, which
+// Datadog expects for OTLP metrics; up-down counters stay cumulative.
+func datadogTemporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+		return metricdata.CumulativeTemporality
+	default:
+		return metricdata.DeltaTemporality
+	}
+}
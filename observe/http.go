@@ -0,0 +1,202 @@
+package observe
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// httpPropagator carries W3C trace context and baggage across HTTP calls.
+var httpPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// HTTPTransport is an http.RoundTripper that records a client span for each
+// outgoing request and injects W3C trace context and baggage headers, so the
+// call appears as a child of the span in the request's context.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use if the base transport is.
+//   - Context: The span is parented to the span in req.Context().
+//   - Ownership: The caller's request is not modified; headers are set on a clone.
+//   - Privacy: Only the method, host, path, and status code are recorded;
+//     query strings and headers are not.
+type HTTPTransport struct {
+	base       http.RoundTripper
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewHTTPTransport wraps base with client tracing.
+// If base is nil, http.DefaultTransport is used.
+func NewHTTPTransport(base http.RoundTripper, tracer trace.Tracer) *HTTPTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &HTTPTransport{
+		base:       base,
+		tracer:     tracer,
+		propagator: httpPropagator,
+	}
+}
+
+// RoundTrip executes the request inside a client span.
+func (t *HTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	outgoing := req.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(outgoing.Header))
+
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// NewHTTPClient returns a copy of base whose transport is traced with the
+// observer's tracer. Use it for outbound calls made while handling a traced
+// request, such as auth's OAuth2Config.HTTPClient and JWKSConfig.HTTPClient,
+// so token introspection and key fetches show up in the request's trace.
+//
+// If base is nil, a zero http.Client is used; set a Timeout on base, since
+// auth applies its default timeouts only when it builds the client itself.
+// A nil Observer is treated as NewNoopObserver().
+func NewHTTPClient(obs Observer, base *http.Client) *http.Client {
+	if obs == nil {
+		obs = NewNoopObserver()
+	}
+
+	var client http.Client
+	if base != nil {
+		client = *base
+	}
+	client.Transport = NewHTTPTransport(client.Transport, obs.Tracer())
+	return &client
+}
+
+// InjectContext writes the trace context and baggage of ctx into req's
+// headers (traceparent, tracestate, baggage), for outgoing requests that do
+// not go through HTTPTransport. Headers are set on req itself.
+func InjectContext(ctx context.Context, req *http.Request) {
+	httpPropagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// ObserverHTTPMiddleware wraps next so each incoming request runs inside a
+// server span. Trace context in the request's traceparent header is
+// extracted first, making the span a child of the caller's span; without
+// it, the span starts a new trace. The handler's request context carries
+// the span, so StartSpan, HTTPTransport, and log correlation continue the
+// same trace.
+//
+// Only the method, path, and status code are recorded, and only 5xx
+// responses mark the span as failed. A nil Observer is treated as
+// NewNoopObserver().
+func ObserverHTTPMiddleware(obs Observer, next http.Handler) http.Handler {
+	if obs == nil {
+		obs = NewNoopObserver()
+	}
+	tracer := obs.Tracer()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := httpPropagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, "HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(sw.status))
+		}
+	})
+}
+
+// statusWriter records the status code written by a handler.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends buffered data to the client, so streaming handlers that
+// type-assert http.Flusher keep working behind the middleware. It is a
+// no-op if the underlying writer cannot flush.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack hands the connection to the handler, e.g. for a WebSocket
+// upgrade. It returns http.ErrNotSupported if the underlying writer cannot
+// be hijacked.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	if !w.wroteHeader {
+		w.status = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return h.Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Ensure HTTPTransport implements http.RoundTripper
+var _ http.RoundTripper = (*HTTPTransport)(nil)
+
+// Ensure statusWriter implements http.Flusher and http.Hijacker
+var (
+	_ http.Flusher  = (*statusWriter)(nil)
+	_ http.Hijacker = (*statusWriter)(nil)
+)
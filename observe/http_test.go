@@ -0,0 +1,271 @@
+package observe
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestHTTPTransport_ChildSpanAndPropagation verifies a client span is created
+// under the caller's span and its context is sent in traceparent.
+func TestHTTPTransport_ChildSpanAndPropagation(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "authenticate")
+	client := &http.Client{Transport: NewHTTPTransport(nil, tracer)}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/keys?secret=x", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	parent.End()
+
+	if req.Header.Get("traceparent") != "" {
+		t.Error("caller's request should not be modified")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	child := spans[0]
+	if child.Name() != "HTTP GET" {
+		t.Errorf("span name = %q, want \"HTTP GET\"", child.Name())
+	}
+	if child.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %v, want client", child.SpanKind())
+	}
+	if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("HTTP span should be a child of the caller's span")
+	}
+
+	want := "00-" + child.SpanContext().TraceID().String() + "-" + child.SpanContext().SpanID().String() + "-01"
+	if traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+
+	for _, kv := range child.Attributes() {
+		if kv.Key == "url.path" && kv.Value.AsString() != "/keys" {
+			t.Errorf("url.path = %q, want \"/keys\"", kv.Value.AsString())
+		}
+	}
+}
+
+// TestHTTPTransport_ErrorStatus verifies error responses mark the span as failed.
+func TestHTTPTransport_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := &http.Client{Transport: NewHTTPTransport(nil, tp.Tracer("test"))}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", spans[0].Status().Code)
+	}
+}
+
+// TestNewHTTPClient_CopiesBase verifies the base client is not mutated.
+func TestNewHTTPClient_CopiesBase(t *testing.T) {
+	base := &http.Client{}
+	client := NewHTTPClient(nil, base)
+
+	if base.Transport != nil {
+		t.Error("base client transport should not be modified")
+	}
+	if _, ok := client.Transport.(*HTTPTransport); !ok {
+		t.Errorf("Transport = %T, want *HTTPTransport", client.Transport)
+	}
+}
+
+// TestObserverHTTPMiddleware_ContinuesIncomingTrace verifies a traceparent
+// header on an incoming request produces a child server span in the same
+// trace.
+func TestObserverHTTPMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	obs := &observer{tracer: tp.Tracer("test")}
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	var handlerSpan trace.SpanContext
+	handler := ObserverHTTPMiddleware(obs, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/invoke", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v, want server", span.SpanKind())
+	}
+	if got := span.SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("trace ID = %s, want %s", got, traceID)
+	}
+	if got := span.Parent().SpanID().String(); got != parentID {
+		t.Errorf("parent span ID = %s, want %s", got, parentID)
+	}
+	if !span.Parent().IsRemote() {
+		t.Error("parent should be marked remote")
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("handler context should carry the server span")
+	}
+
+	for _, kv := range span.Attributes() {
+		if kv.Key == "http.response.status_code" && kv.Value.AsInt64() != http.StatusAccepted {
+			t.Errorf("status code attribute = %d, want %d", kv.Value.AsInt64(), http.StatusAccepted)
+		}
+	}
+}
+
+// TestObserverHTTPMiddleware_NewTraceAndServerError verifies a request
+// without trace context starts a new trace and a 5xx marks the span failed.
+func TestObserverHTTPMiddleware_NewTraceAndServerError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	obs := &observer{tracer: tp.Tracer("test")}
+
+	handler := ObserverHTTPMiddleware(obs, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/invoke", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Parent().IsValid() {
+		t.Error("span without incoming context should be a root span")
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", spans[0].Status().Code)
+	}
+}
+
+// TestObserverHTTPMiddleware_Streaming verifies flushed chunks reach the
+// client while the handler is still running.
+func TestObserverHTTPMiddleware_Streaming(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	obs := &observer{tracer: tp.Tracer("test")}
+
+	release := make(chan struct{})
+	handler := ObserverHTTPMiddleware(obs, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("ResponseWriter does not implement http.Flusher")
+			return
+		}
+		_, _ = w.Write([]byte("first\n"))
+		flusher.Flush()
+		<-release
+		_, _ = w.Write([]byte("second\n"))
+	}))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	defer close(release)
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Blocks until the handler's flush, which happens before release
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || line != "first\n" {
+		t.Fatalf("first chunk = %q, %v; want \"first\\n\" before the handler returns", line, err)
+	}
+}
+
+// TestObserverHTTPMiddleware_Hijack verifies Hijack reaches the underlying
+// writer, or reports http.ErrNotSupported when it cannot.
+func TestObserverHTTPMiddleware_Hijack(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	obs := &observer{tracer: tp.Tracer("test")}
+
+	hijacked := make(chan error, 1)
+	handler := ObserverHTTPMiddleware(obs, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+		hijacked <- err
+	}))
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	if resp, err := http.Get(srv.URL); err == nil {
+		_ = resp.Body.Close()
+	}
+	if err := <-hijacked; err != nil {
+		t.Errorf("Hijack() error = %v, want nil", err)
+	}
+
+	// httptest.ResponseRecorder cannot be hijacked
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if err := <-hijacked; !errors.Is(err, http.ErrNotSupported) {
+		t.Errorf("Hijack() error = %v, want http.ErrNotSupported", err)
+	}
+}
+
+// TestInjectContext verifies the span in ctx is written as traceparent.
+func TestInjectContext(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "call")
+	defer span.End()
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	InjectContext(ctx, req)
+
+	sc := span.SpanContext()
+	want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+	if got := req.Header.Get("traceparent"); got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+}
@@ -3,10 +3,20 @@ package observe
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"math"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Logger is a minimal structured logging interface.
@@ -59,26 +69,196 @@ func (l LogLevel) String() string {
 	}
 }
 
+// Log output formats for LoggingConfig.Format and WithFormat.
+const (
+	// LogFormatJSON writes one JSON object per line (the default).
+	LogFormatJSON = "json"
+	// LogFormatText writes compact human-readable lines for local
+	// development: timestamp, level, message, then sorted key=value fields.
+	LogFormatText = "text"
+)
+
+// LevelSetter is implemented by loggers whose level can change at runtime.
+// Loggers from NewLogger, NewLoggerWithWriter, and Observer.Logger
+// implement it; a level set on one is seen by every logger derived from
+// the same root with WithTool.
+//
+// Contract:
+//   - Concurrency: SetLevel and Level are safe to call while logging.
+//   - Errors: SetLevel returns ErrInvalidLogLevel for anything other than
+//     "debug", "info", "warn", or "error", leaving the level unchanged.
+type LevelSetter interface {
+	// SetLevel changes the minimum level of entries written.
+	SetLevel(level string) error
+	// Level returns the current minimum level.
+	Level() string
+}
+
+// LevelEnabler is implemented by loggers that can report whether a level
+// is written, so hot paths can skip building fields that would be
+// discarded:
+//
+//	if observe.LogEnabled(logger, observe.LevelDebug) {
+//	    logger.Debug(ctx, "request", observe.Field{Key: "body", Value: dump(req)})
+//	}
+//
+// Loggers from NewLogger, NewLoggerWithWriter, and Observer.Logger
+// implement it.
+type LevelEnabler interface {
+	// Enabled reports whether entries at level pass the level threshold.
+	// Sampled entries (WithSampling) may still be dropped.
+	Enabled(level LogLevel) bool
+}
+
+// LogEnabled reports whether logger writes entries at level. Loggers that
+// do not implement LevelEnabler are assumed to write every level.
+func LogEnabled(logger Logger, level LogLevel) bool {
+	if le, ok := logger.(LevelEnabler); ok {
+		return le.Enabled(level)
+	}
+	return true
+}
+
+// levelVar holds a LogLevel shared by a root logger and its derivations.
+type levelVar struct {
+	v atomic.Int32
+}
+
+func (lv *levelVar) get() LogLevel {
+	return LogLevel(lv.v.Load())
+}
+
+func (lv *levelVar) set(level LogLevel) {
+	lv.v.Store(int32(level))
+}
+
 // structuredLogger is a JSON structured logger implementation.
 type structuredLogger struct {
-	level     LogLevel
+	level     *levelVar
 	writer    io.Writer
 	mu        sync.Mutex
 	toolMeta  *ToolMeta
 	baseAttrs map[string]any
+	options   loggerOptions
+}
+
+// loggerOptions holds optional logger behavior shared by derived loggers.
+type loggerOptions struct {
+	correlation        bool
+	redactFields       []string
+	noDefaultRedaction bool
+	redactPatterns     []*regexp.Regexp
+	sampler            *logSampler
+	text               bool
+
+	// redacted is the lowercased set of keys to redact, built once from
+	// RedactedFields and redactFields.
+	redacted map[string]struct{}
+}
+
+// LoggerOption configures optional Logger behavior.
+type LoggerOption func(*loggerOptions)
+
+// WithCorrelation enables or disables trace correlation.
+// When enabled (the default), each log entry written with a context carrying
+// a valid span includes trace_id and span_id fields.
+func WithCorrelation(enabled bool) LoggerOption {
+	return func(o *loggerOptions) {
+		o.correlation = enabled
+	}
+}
+
+// WithRedactedFields adds keys to redact in addition to RedactedFields,
+// such as "ssn", "authorization", or "cookie". Keys are matched
+// case-insensitively, both as field keys and as keys of nested map values.
+func WithRedactedFields(keys ...string) LoggerOption {
+	return func(o *loggerOptions) {
+		o.redactFields = append(o.redactFields, keys...)
+	}
+}
+
+// WithoutDefaultRedaction stops the logger from redacting RedactedFields,
+// so only keys added with WithRedactedFields are redacted. Use it to
+// replace the default set, e.g. to log "token" fields that hold no secret.
+func WithoutDefaultRedaction() LoggerOption {
+	return func(o *loggerOptions) {
+		o.noDefaultRedaction = true
+	}
+}
+
+// WithRedactPatterns masks every match of the patterns in the message and
+// in string field values, including strings inside map values, with
+// "[REDACTED]". It catches secrets under harmless keys, such as a message
+// quoting "Bearer eyJ...". Patterns run after key-based redaction; with
+// none configured, values are not scanned.
+func WithRedactPatterns(patterns ...*regexp.Regexp) LoggerOption {
+	return func(o *loggerOptions) {
+		o.redactPatterns = append(o.redactPatterns, patterns...)
+	}
+}
+
+// WithSampling writes only about rate (0.0-1.0) of debug and info entries,
+// so repetitive per-call logs cannot flood the pipeline under load. Warn
+// and error entries are always written. Sampling is counter-based rather
+// than random: with rate 0.1, the 1st, 11th, 21st, ... info entries are
+// written. Loggers derived with WithTool share the counters. A rate <= 0
+// or >= 1 disables sampling.
+func WithSampling(rate float64) LoggerOption {
+	return func(o *loggerOptions) {
+		if rate <= 0 || rate >= 1 {
+			o.sampler = nil
+			return
+		}
+		o.sampler = &logSampler{rate: rate}
+	}
+}
+
+// logSampler decides which debug and info entries to write.
+type logSampler struct {
+	rate   float64
+	counts [LevelWarn]atomic.Uint64 // per level, debug and info
+}
+
+// sample reports whether the next entry at level should be written.
+// Entry n is written when ceil(n*rate) increases, so the first entry is
+// always written and exactly ceil(n*rate) of n entries are.
+func (s *logSampler) sample(level LogLevel) bool {
+	if level >= LevelWarn {
+		return true
+	}
+	n := float64(s.counts[level].Add(1))
+	return math.Ceil(n*s.rate) > math.Ceil((n-1)*s.rate)
+}
+
+// WithFormat selects the output format: LogFormatJSON (the default) or
+// LogFormatText. Redaction applies to both. Unknown formats use JSON.
+func WithFormat(format string) LoggerOption {
+	return func(o *loggerOptions) {
+		o.text = format == LogFormatText
+	}
 }
 
 // NewLogger creates a new structured logger with the given level.
-func NewLogger(level string) Logger {
-	return NewLoggerWithWriter(level, os.Stderr)
+func NewLogger(level string, opts ...LoggerOption) Logger {
+	return NewLoggerWithWriter(level, os.Stderr, opts...)
 }
 
 // NewLoggerWithWriter creates a new structured logger with a custom writer.
-func NewLoggerWithWriter(level string, w io.Writer) Logger {
+func NewLoggerWithWriter(level string, w io.Writer, opts ...LoggerOption) Logger {
+	options := loggerOptions{correlation: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	options.redacted = redactionSet(options)
+
+	lv := &levelVar{}
+	lv.set(ParseLogLevel(level))
+
 	return &structuredLogger{
-		level:     ParseLogLevel(level),
+		level:     lv,
 		writer:    w,
 		baseAttrs: make(map[string]any),
+		options:   options,
 	}
 }
 
@@ -103,28 +283,64 @@ func (l *structuredLogger) WithTool(meta ToolMeta) Logger {
 		writer:    l.writer,
 		toolMeta:  &meta,
 		baseAttrs: attrs,
+		options:   l.options,
+	}
+}
+
+// SetLevel changes the level of this logger and every logger sharing its
+// root, so verbosity can be raised or lowered without a restart.
+func (l *structuredLogger) SetLevel(level string) error {
+	if level == "" || !validLogLevels[level] {
+		return fmt.Errorf("%w: %q", ErrInvalidLogLevel, level)
 	}
+	l.level.set(ParseLogLevel(level))
+	return nil
 }
 
+// Level returns the current level, e.g. "info".
+func (l *structuredLogger) Level() string {
+	return l.level.get().String()
+}
+
+// Enabled reports whether entries at level pass the current level.
+func (l *structuredLogger) Enabled(level LogLevel) bool {
+	return level >= l.level.get()
+}
+
+// Each logging method checks the level before anything else, so a filtered
+// call does no field processing and allocates nothing.
+
 func (l *structuredLogger) Info(ctx context.Context, msg string, fields ...Field) {
-	l.log(LevelInfo, msg, fields)
+	if !l.Enabled(LevelInfo) {
+		return
+	}
+	l.log(ctx, LevelInfo, msg, fields)
 }
 
 func (l *structuredLogger) Warn(ctx context.Context, msg string, fields ...Field) {
-	l.log(LevelWarn, msg, fields)
+	if !l.Enabled(LevelWarn) {
+		return
+	}
+	l.log(ctx, LevelWarn, msg, fields)
 }
 
 func (l *structuredLogger) Error(ctx context.Context, msg string, fields ...Field) {
-	l.log(LevelError, msg, fields)
+	if !l.Enabled(LevelError) {
+		return
+	}
+	l.log(ctx, LevelError, msg, fields)
 }
 
 func (l *structuredLogger) Debug(ctx context.Context, msg string, fields ...Field) {
-	l.log(LevelDebug, msg, fields)
+	if !l.Enabled(LevelDebug) {
+		return
+	}
+	l.log(ctx, LevelDebug, msg, fields)
 }
 
-func (l *structuredLogger) log(level LogLevel, msg string, fields []Field) {
-	// Filter by level
-	if level < l.level {
+// log writes an entry that has passed the level check.
+func (l *structuredLogger) log(ctx context.Context, level LogLevel, msg string, fields []Field) {
+	if l.options.sampler != nil && !l.options.sampler.sample(level) {
 		return
 	}
 
@@ -134,52 +350,189 @@ func (l *structuredLogger) log(level LogLevel, msg string, fields []Field) {
 	// Add timestamp and level
 	entry["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
 	entry["level"] = level.String()
-	entry["msg"] = msg
+	entry["msg"] = l.redactString(msg)
 
 	// Add base attributes (tool context)
 	for k, v := range l.baseAttrs {
 		entry[k] = v
 	}
 
+	// Add trace correlation from the active span
+	if l.options.correlation && ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			entry["trace_id"] = sc.TraceID().String()
+			entry["span_id"] = sc.SpanID().String()
+		}
+	}
+
 	// Add fields (with input redaction)
 	for _, f := range fields {
-		if isRedactedField(f.Key) {
-			entry[f.Key] = "[REDACTED]"
+		if l.isRedactedField(f.Key) {
+			entry[f.Key] = redactedValue
 		} else {
-			entry[f.Key] = f.Value
+			entry[f.Key] = l.redactNested(f.Value)
 		}
 	}
 
-	// Serialize and write
+	// Serialize outside the lock; only the write needs serializing
+	var data []byte
+	if l.options.text {
+		data = formatText(entry)
+	} else {
+		var err error
+		data, err = json.Marshal(entry)
+		if err != nil {
+			return // Silently drop malformed log entries
+		}
+	}
+
+	// Single write per entry so rotating writers never split a line
+	data = append(data, '\n')
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if _, err := l.writer.Write(data); err != nil {
+		return
+	}
+}
+
+// formatText renders entry as "<timestamp> <LEVEL> <msg> key=value ...",
+// with fields sorted by key. Strings containing spaces, quotes, or "=" are
+// quoted; other values are written as JSON.
+func formatText(entry map[string]any) []byte {
+	var b strings.Builder
+	b.WriteString(entry["timestamp"].(string))
+	b.WriteByte(' ')
+	fmt.Fprintf(&b, "%-5s", strings.ToUpper(entry["level"].(string)))
+	b.WriteByte(' ')
+	b.WriteString(entry["msg"].(string))
+
+	keys := make([]string, 0, len(entry))
+	for k := range entry {
+		switch k {
+		case "timestamp", "level", "msg":
+		default:
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
 
-	data, err := json.Marshal(entry)
+	for _, k := range keys {
+		b.WriteByte(' ')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(textValue(entry[k]))
+	}
+	return []byte(b.String())
+}
+
+// textValue formats one field value for formatText.
+func textValue(v any) string {
+	if s, ok := v.(string); ok {
+		if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+	data, err := json.Marshal(v)
 	if err != nil {
-		return // Silently drop malformed log entries
+		return strconv.Quote(fmt.Sprint(v))
 	}
+	return string(data)
+}
 
-	if _, err := l.writer.Write(data); err != nil {
-		return
+// redactedValue replaces the value of a redacted field.
+const redactedValue = "[REDACTED]"
+
+// redactionSet builds the lowercased set of keys to redact.
+func redactionSet(o loggerOptions) map[string]struct{} {
+	set := make(map[string]struct{}, len(RedactedFields)+len(o.redactFields))
+	if !o.noDefaultRedaction {
+		for _, key := range RedactedFields {
+			set[strings.ToLower(key)] = struct{}{}
+		}
 	}
-	if _, err := l.writer.Write([]byte("\n")); err != nil {
-		return
+	for _, key := range o.redactFields {
+		set[strings.ToLower(key)] = struct{}{}
 	}
+	return set
 }
 
 // isRedactedField returns true if the field should be redacted.
-func isRedactedField(key string) bool {
-	redactedKeys := map[string]bool{
-		"input":      true,
-		"inputs":     true,
-		"password":   true,
-		"secret":     true,
-		"token":      true,
-		"api_key":    true,
-		"apiKey":     true,
-		"credential": true,
+func (l *structuredLogger) isRedactedField(key string) bool {
+	_, ok := l.options.redacted[strings.ToLower(key)]
+	return ok
+}
+
+// redactNested returns v with redacted keys masked inside map values, at
+// any depth, and redact pattern matches masked in strings. Maps are copied,
+// never modified; other values are returned unchanged.
+func (l *structuredLogger) redactNested(v any) any {
+	switch v := v.(type) {
+	case string:
+		return l.redactString(v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if l.isRedactedField(k) {
+				out[k] = redactedValue
+			} else {
+				out[k] = l.redactNested(val)
+			}
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for k, val := range v {
+			if l.isRedactedField(k) {
+				out[k] = redactedValue
+			} else {
+				out[k] = l.redactString(val)
+			}
+		}
+		return out
+	case http.Header:
+		return http.Header(l.redactValues(v))
+	case map[string][]string:
+		return l.redactValues(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = l.redactNested(val)
+		}
+		return out
+	default:
+		return v
 	}
-	return redactedKeys[key]
+}
+
+// redactValues copies a multi-valued map such as http.Header, masking
+// every value of a redacted key.
+func (l *structuredLogger) redactValues(v map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(v))
+	for k, vals := range v {
+		if l.isRedactedField(k) {
+			out[k] = []string{redactedValue}
+			continue
+		}
+		if len(l.options.redactPatterns) == 0 {
+			out[k] = vals
+			continue
+		}
+		redacted := make([]string, len(vals))
+		for i, val := range vals {
+			redacted[i] = l.redactString(val)
+		}
+		out[k] = redacted
+	}
+	return out
+}
+
+// redactString masks matches of the redact patterns in s.
+func (l *structuredLogger) redactString(s string) string {
+	for _, re := range l.options.redactPatterns {
+		s = re.ReplaceAllLiteralString(s, redactedValue)
+	}
+	return s
 }
 
 // ExtendedLogger extends Logger with WithTool for creating tool-scoped loggers.
@@ -193,5 +546,9 @@ type ExtendedLogger interface {
 	WithTool(meta ToolMeta) Logger
 }
 
-// Ensure structuredLogger implements ExtendedLogger
-var _ ExtendedLogger = (*structuredLogger)(nil)
+// Ensure structuredLogger implements ExtendedLogger, LevelSetter, and LevelEnabler
+var (
+	_ ExtendedLogger = (*structuredLogger)(nil)
+	_ LevelSetter    = (*structuredLogger)(nil)
+	_ LevelEnabler   = (*structuredLogger)(nil)
+)
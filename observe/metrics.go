@@ -2,12 +2,99 @@ package observe
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
+// Namespace rollup modes for MetricsConfig.Rollup.
+const (
+	// RollupNone records only per-tool series.
+	RollupNone = ""
+	// RollupAdditive records per-tool series plus "<instrument>.by_namespace"
+	// series labeled only by tool.namespace.
+	RollupAdditive = "additive"
+	// RollupReplace records tool.exec.* labeled only by tool.namespace,
+	// dropping per-tool series entirely.
+	RollupReplace = "replace"
+)
+
+// Extra metric labels for MetricsConfig.ExtraLabels, selected from ToolMeta.
+const (
+	// MetricLabelVersion adds tool.version from ToolMeta.Version.
+	MetricLabelVersion = "version"
+	// MetricLabelCategory adds tool.category from ToolMeta.Category.
+	MetricLabelCategory = "category"
+)
+
+// rollupSuffix is appended to instrument names for additive rollup series.
+const rollupSuffix = ".by_namespace"
+
+// durationInstrument is the name of the execution duration histogram.
+const durationInstrument = "tool.exec.duration_ms"
+
+// toolExecInstruments lists the instruments eligible for namespace rollup.
+var toolExecInstruments = []string{"tool.exec.total", "tool.exec.errors", durationInstrument}
+
+// metricViews returns the metric views for cfg's rollup mode and duration
+// buckets. Rollup series keep only the tool.namespace attribute, so their
+// cardinality is the number of namespaces instead of the number of tools.
+// Every tool.exec.duration_ms stream, rollup included, uses
+// cfg.DurationBuckets when set.
+func metricViews(cfg MetricsConfig) []sdkmetric.View {
+	var durationAgg sdkmetric.Aggregation
+	if len(cfg.DurationBuckets) > 0 {
+		durationAgg = sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: slices.Clone(cfg.DurationBuckets),
+		}
+	}
+	// A view's stream replaces the default, so every view matching the
+	// histogram carries the bucket aggregation.
+	stream := func(name string, s sdkmetric.Stream) sdkmetric.Stream {
+		if name == durationInstrument {
+			s.Aggregation = durationAgg
+		}
+		return s
+	}
+
+	if cfg.Rollup == RollupNone {
+		if durationAgg == nil {
+			return nil
+		}
+		return []sdkmetric.View{
+			sdkmetric.NewView(sdkmetric.Instrument{Name: durationInstrument},
+				stream(durationInstrument, sdkmetric.Stream{})),
+		}
+	}
+
+	namespaceOnly := attribute.NewAllowKeysFilter("tool.namespace")
+	views := make([]sdkmetric.View, 0, 2*len(toolExecInstruments))
+	for _, name := range toolExecInstruments {
+		switch cfg.Rollup {
+		case RollupAdditive:
+			views = append(views,
+				// Keep the detailed stream; a matching view replaces the default.
+				sdkmetric.NewView(sdkmetric.Instrument{Name: name}, stream(name, sdkmetric.Stream{})),
+				sdkmetric.NewView(sdkmetric.Instrument{Name: name}, stream(name, sdkmetric.Stream{
+					Name:            name + rollupSuffix,
+					AttributeFilter: namespaceOnly,
+				})),
+			)
+		case RollupReplace:
+			views = append(views,
+				sdkmetric.NewView(sdkmetric.Instrument{Name: name}, stream(name, sdkmetric.Stream{
+					AttributeFilter: namespaceOnly,
+				})),
+			)
+		}
+	}
+	return views
+}
+
 // Metrics records execution metrics for tools.
 //
 // Contract:
@@ -26,16 +113,30 @@ type Metrics interface {
 	RecordExecution(ctx context.Context, meta ToolMeta, duration time.Duration, err error)
 }
 
+// DefaultOverflowToolID is the tool.id recorded for tools beyond
+// MetricsConfig.MaxToolIDs when OverflowToolID is empty.
+const DefaultOverflowToolID = "other"
+
 // metricsImpl is the concrete implementation of Metrics.
 type metricsImpl struct {
 	meter        metric.Meter
+	extraLabels  []string
+	toolIDs      *toolIDLimiter // nil when tool IDs are unlimited
 	totalCount   metric.Int64Counter
 	errorCount   metric.Int64Counter
 	durationHist metric.Float64Histogram
 }
 
 // newMetrics creates a new Metrics instance with the given meter.
-func newMetrics(meter metric.Meter) (*metricsImpl, error) {
+// extraLabels selects additional ToolMeta fields (MetricLabelVersion,
+// MetricLabelCategory) to record as attributes on every instrument.
+func newMetrics(meter metric.Meter, extraLabels ...string) (*metricsImpl, error) {
+	return newMetricsWithConfig(meter, MetricsConfig{ExtraLabels: extraLabels})
+}
+
+// newMetricsWithConfig creates a new Metrics instance using the label
+// settings of cfg: ExtraLabels, MaxToolIDs, and OverflowToolID.
+func newMetricsWithConfig(meter metric.Meter, cfg MetricsConfig) (*metricsImpl, error) {
 	totalCount, err := meter.Int64Counter(
 		"tool.exec.total",
 		metric.WithDescription("Total number of tool executions"),
@@ -55,7 +156,7 @@ func newMetrics(meter metric.Meter) (*metricsImpl, error) {
 	}
 
 	durationHist, err := meter.Float64Histogram(
-		"tool.exec.duration_ms",
+		durationInstrument,
 		metric.WithDescription("Tool execution duration in milliseconds"),
 		metric.WithUnit("ms"),
 	)
@@ -63,8 +164,23 @@ func newMetrics(meter metric.Meter) (*metricsImpl, error) {
 		return nil, err
 	}
 
+	var toolIDs *toolIDLimiter
+	if cfg.MaxToolIDs > 0 {
+		overflow := cfg.OverflowToolID
+		if overflow == "" {
+			overflow = DefaultOverflowToolID
+		}
+		toolIDs = &toolIDLimiter{
+			max:      cfg.MaxToolIDs,
+			overflow: overflow,
+			seen:     make(map[string]struct{}, cfg.MaxToolIDs),
+		}
+	}
+
 	return &metricsImpl{
 		meter:        meter,
+		extraLabels:  cfg.ExtraLabels,
+		toolIDs:      toolIDs,
 		totalCount:   totalCount,
 		errorCount:   errorCount,
 		durationHist: durationHist,
@@ -73,10 +189,14 @@ func newMetrics(meter metric.Meter) (*metricsImpl, error) {
 
 // RecordExecution records metrics for a tool execution.
 func (m *metricsImpl) RecordExecution(ctx context.Context, meta ToolMeta, duration time.Duration, err error) {
-	// Build common attributes
+	// Build common attributes, collapsing tools past the cardinality limit
+	toolID, toolName := meta.ToolID(), meta.Name
+	if m.toolIDs != nil && !m.toolIDs.allow(toolID) {
+		toolID, toolName = m.toolIDs.overflow, m.toolIDs.overflow
+	}
 	attrs := []attribute.KeyValue{
-		attribute.String("tool.id", meta.ToolID()),
-		attribute.String("tool.name", meta.Name),
+		attribute.String("tool.id", toolID),
+		attribute.String("tool.name", toolName),
 	}
 
 	// Add namespace if present
@@ -84,6 +204,20 @@ func (m *metricsImpl) RecordExecution(ctx context.Context, meta ToolMeta, durati
 		attrs = append(attrs, attribute.String("tool.namespace", meta.Namespace))
 	}
 
+	// Add configured extra labels if present
+	for _, label := range m.extraLabels {
+		switch label {
+		case MetricLabelVersion:
+			if meta.Version != "" {
+				attrs = append(attrs, attribute.String("tool.version", meta.Version))
+			}
+		case MetricLabelCategory:
+			if meta.Category != "" {
+				attrs = append(attrs, attribute.String("tool.category", meta.Category))
+			}
+		}
+	}
+
 	opt := metric.WithAttributes(attrs...)
 
 	// Always increment total counter
@@ -94,11 +228,49 @@ func (m *metricsImpl) RecordExecution(ctx context.Context, meta ToolMeta, durati
 		m.errorCount.Add(ctx, 1, opt)
 	}
 
-	// Record duration in milliseconds
-	durationMs := float64(duration.Milliseconds())
+	// Record duration in fractional milliseconds, so sub-millisecond
+	// buckets are meaningful
+	durationMs := float64(duration) / float64(time.Millisecond)
 	m.durationHist.Record(ctx, durationMs, opt)
 }
 
+// toolIDLimiter admits the first max distinct tool IDs as metric labels.
+// Later IDs are recorded under the overflow label, bounding series count
+// when tool names are dynamic or user-supplied.
+type toolIDLimiter struct {
+	max      int
+	overflow string
+
+	mu   sync.RWMutex
+	seen map[string]struct{}
+}
+
+// allow reports whether id may be used as a label, admitting it if the
+// limit has not been reached.
+func (l *toolIDLimiter) allow(id string) bool {
+	l.mu.RLock()
+	_, ok := l.seen[id]
+	full := len(l.seen) >= l.max
+	l.mu.RUnlock()
+	if ok {
+		return true
+	}
+	if full {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[id]; ok {
+		return true
+	}
+	if len(l.seen) >= l.max {
+		return false
+	}
+	l.seen[id] = struct{}{}
+	return true
+}
+
 // noopMetrics is a metrics implementation that does nothing.
 type noopMetrics struct{}
 
@@ -3,6 +3,9 @@ package observe
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -10,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
 // TestMetrics_TotalCounterIncrements verifies tool.exec.total is incremented.
@@ -234,6 +238,65 @@ func TestMetrics_LabelsApplied(t *testing.T) {
 	}
 }
 
+// TestMetrics_ExtraLabels verifies tool.version is recorded on every
+// instrument only when configured.
+func TestMetrics_ExtraLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraLabels []string
+		wantVersion bool
+	}{
+		{"default", nil, false},
+		{"version", []string{MetricLabelVersion}, true},
+		{"category only", []string{MetricLabelCategory}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			m, err := newMetrics(mp.Meter("test"), tt.extraLabels...)
+			if err != nil {
+				t.Fatalf("failed to create metrics: %v", err)
+			}
+
+			meta := ToolMeta{Name: "deploy", Version: "1.4.2", Category: "ops"}
+			m.RecordExecution(context.Background(), meta, time.Millisecond, errors.New("boom"))
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+
+			for _, name := range []string{"tool.exec.total", "tool.exec.errors", "tool.exec.duration_ms"} {
+				found := findMetric(rm, name)
+				if found == nil {
+					t.Fatalf("%s metric not found", name)
+				}
+
+				var attrs attribute.Set
+				switch data := found.Data.(type) {
+				case metricdata.Sum[int64]:
+					attrs = data.DataPoints[0].Attributes
+				case metricdata.Histogram[float64]:
+					attrs = data.DataPoints[0].Attributes
+				default:
+					t.Fatalf("unexpected data type %T for %s", found.Data, name)
+				}
+
+				v, ok := attrs.Value("tool.version")
+				if ok != tt.wantVersion {
+					t.Errorf("%s: tool.version present = %v, want %v", name, ok, tt.wantVersion)
+				}
+				if ok && v.AsString() != "1.4.2" {
+					t.Errorf("%s: tool.version = %q, want \"1.4.2\"", name, v.AsString())
+				}
+			}
+		})
+	}
+}
+
 // TestMetrics_ConcurrentRecording verifies thread safety.
 func TestMetrics_ConcurrentRecording(t *testing.T) {
 	reader := sdkmetric.NewManualReader()
@@ -283,6 +346,95 @@ func TestMetrics_ConcurrentRecording(t *testing.T) {
 }
 
 // findMetric searches for a metric by name in ResourceMetrics.
+// recordRollupFixture records executions for three tools across two namespaces.
+func recordRollupFixture(t *testing.T, mode string) metricdata.ResourceMetrics {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithView(metricViews(MetricsConfig{Rollup: mode})...),
+	)
+	m, err := newMetrics(mp.Meter("test"))
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+
+	ctx := context.Background()
+	m.RecordExecution(ctx, ToolMeta{Namespace: "github", Name: "create_issue"}, time.Millisecond, nil)
+	m.RecordExecution(ctx, ToolMeta{Namespace: "github", Name: "list_repos"}, time.Millisecond, nil)
+	m.RecordExecution(ctx, ToolMeta{Namespace: "slack", Name: "post"}, time.Millisecond, nil)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	return rm
+}
+
+// namespaceCounts returns tool.namespace -> value for a Sum[int64] metric,
+// failing if any data point carries attributes other than tool.namespace.
+func namespaceCounts(t *testing.T, found *metricdata.Metrics) map[string]int64 {
+	t.Helper()
+
+	sum, ok := found.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected Sum[int64], got %T", found.Data)
+	}
+	counts := make(map[string]int64)
+	for _, dp := range sum.DataPoints {
+		if dp.Attributes.Len() != 1 {
+			t.Errorf("expected only tool.namespace, got %v", dp.Attributes.ToSlice())
+		}
+		ns, _ := dp.Attributes.Value("tool.namespace")
+		counts[ns.AsString()] = dp.Value
+	}
+	return counts
+}
+
+// TestMetrics_RollupAdditive verifies namespace rollup series sit alongside per-tool series.
+func TestMetrics_RollupAdditive(t *testing.T) {
+	rm := recordRollupFixture(t, RollupAdditive)
+
+	rollup := findMetric(rm, "tool.exec.total.by_namespace")
+	if rollup == nil {
+		t.Fatal("tool.exec.total.by_namespace metric not found")
+	}
+	counts := namespaceCounts(t, rollup)
+	if counts["github"] != 2 || counts["slack"] != 1 || len(counts) != 2 {
+		t.Errorf("unexpected rollup counts: %v", counts)
+	}
+
+	detailed := findMetric(rm, "tool.exec.total")
+	if detailed == nil {
+		t.Fatal("tool.exec.total metric not found")
+	}
+	if n := len(detailed.Data.(metricdata.Sum[int64]).DataPoints); n != 3 {
+		t.Errorf("expected 3 per-tool series, got %d", n)
+	}
+
+	if findMetric(rm, "tool.exec.duration_ms.by_namespace") == nil {
+		t.Error("tool.exec.duration_ms.by_namespace metric not found")
+	}
+}
+
+// TestMetrics_RollupReplace verifies only namespace-labeled series are recorded.
+func TestMetrics_RollupReplace(t *testing.T) {
+	rm := recordRollupFixture(t, RollupReplace)
+
+	if findMetric(rm, "tool.exec.total.by_namespace") != nil {
+		t.Error("replace mode should not add by_namespace series")
+	}
+	total := findMetric(rm, "tool.exec.total")
+	if total == nil {
+		t.Fatal("tool.exec.total metric not found")
+	}
+	counts := namespaceCounts(t, total)
+	if counts["github"] != 2 || counts["slack"] != 1 || len(counts) != 2 {
+		t.Errorf("unexpected rollup counts: %v", counts)
+	}
+}
+
 func findMetric(rm metricdata.ResourceMetrics, name string) *metricdata.Metrics {
 	for _, sm := range rm.ScopeMetrics {
 		for i := range sm.Metrics {
@@ -296,3 +448,148 @@ func findMetric(rm metricdata.ResourceMetrics, name string) *metricdata.Metrics
 
 // Silence unused import warning
 var _ = attribute.String
+
+// TestMetrics_DurationBuckets verifies configured bucket boundaries are
+// used for the duration histogram, including its rollup series.
+func TestMetrics_DurationBuckets(t *testing.T) {
+	buckets := []float64{0.1, 0.5, 1, 5, 60000}
+
+	for _, mode := range []string{RollupNone, RollupAdditive, RollupReplace} {
+		t.Run("rollup="+mode, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithView(metricViews(MetricsConfig{Rollup: mode, DurationBuckets: buckets})...),
+			)
+			m, err := newMetrics(mp.Meter("test"))
+			if err != nil {
+				t.Fatalf("failed to create metrics: %v", err)
+			}
+			m.RecordExecution(context.Background(), ToolMeta{Namespace: "fs", Name: "stat"}, 300*time.Microsecond, nil)
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+
+			names := []string{"tool.exec.duration_ms"}
+			if mode == RollupAdditive {
+				names = append(names, "tool.exec.duration_ms.by_namespace")
+			}
+			for _, name := range names {
+				found := findMetric(rm, name)
+				if found == nil {
+					t.Fatalf("%s metric not found", name)
+				}
+				hist, ok := found.Data.(metricdata.Histogram[float64])
+				if !ok {
+					t.Fatalf("expected Histogram[float64], got %T", found.Data)
+				}
+				dp := hist.DataPoints[0]
+				if !slices.Equal(dp.Bounds, buckets) {
+					t.Errorf("%s bounds = %v, want %v", name, dp.Bounds, buckets)
+				}
+				// 0.3ms falls in the (0.1, 0.5] bucket.
+				if dp.BucketCounts[1] != 1 {
+					t.Errorf("%s bucket counts = %v, want the 0.3ms sample in bucket 1", name, dp.BucketCounts)
+				}
+			}
+		})
+	}
+}
+
+// TestMetrics_MaxToolIDs verifies tool IDs beyond the limit are recorded
+// under the overflow label.
+func TestMetrics_MaxToolIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow string
+		wantID   string
+	}{
+		{name: "default overflow", wantID: DefaultOverflowToolID},
+		{name: "custom overflow", overflow: "_overflow", wantID: "_overflow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			m, err := newMetricsWithConfig(mp.Meter("test"), MetricsConfig{
+				MaxToolIDs:     3,
+				OverflowToolID: tt.overflow,
+			})
+			if err != nil {
+				t.Fatalf("failed to create metrics: %v", err)
+			}
+
+			ctx := context.Background()
+			for i := 0; i < 10; i++ {
+				m.RecordExecution(ctx, ToolMeta{Name: fmt.Sprintf("tool_%d", i)}, time.Millisecond, nil)
+			}
+			// Admitted tools keep their own series after the limit is hit
+			m.RecordExecution(ctx, ToolMeta{Name: "tool_0"}, time.Millisecond, nil)
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(ctx, &rm); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+			found := findMetric(rm, "tool.exec.total")
+			if found == nil {
+				t.Fatal("tool.exec.total metric not found")
+			}
+
+			counts := make(map[string]int64)
+			for _, dp := range found.Data.(metricdata.Sum[int64]).DataPoints {
+				id, _ := dp.Attributes.Value("tool.id")
+				name, _ := dp.Attributes.Value("tool.name")
+				if id.AsString() == tt.wantID && name.AsString() != tt.wantID {
+					t.Errorf("overflow series tool.name = %q, want %q", name.AsString(), tt.wantID)
+				}
+				counts[id.AsString()] = dp.Value
+			}
+
+			want := map[string]int64{"tool_0": 2, "tool_1": 1, "tool_2": 1, tt.wantID: 7}
+			if len(counts) != len(want) {
+				t.Errorf("series = %v, want %v", counts, want)
+			}
+			for id, n := range want {
+				if counts[id] != n {
+					t.Errorf("tool.id=%s count = %d, want %d", id, counts[id], n)
+				}
+			}
+		})
+	}
+}
+
+// TestMiddlewareFromObserver_MaxToolIDs verifies the limit configured on
+// the Observer reaches the middleware's metrics.
+func TestMiddlewareFromObserver_MaxToolIDs(t *testing.T) {
+	obs := &observer{
+		tracer:     tracenoop.NewTracerProvider().Tracer("test"),
+		logger:     NewLoggerWithWriter("error", io.Discard),
+		metricsCfg: MetricsConfig{MaxToolIDs: 1},
+	}
+	reader := sdkmetric.NewManualReader()
+	obs.meter = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	mw, err := MiddlewareFromObserver(obs)
+	if err != nil {
+		t.Fatalf("MiddlewareFromObserver() error = %v", err)
+	}
+	exec := func(ctx context.Context, _ ToolMeta, _ any) (any, error) { return nil, nil }
+	for _, name := range []string{"a", "b", "c"} {
+		_, _ = mw.Wrap(exec)(context.Background(), ToolMeta{Name: name}, nil)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	found := findMetric(rm, "tool.exec.total")
+	if found == nil {
+		t.Fatal("tool.exec.total metric not found")
+	}
+	if n := len(found.Data.(metricdata.Sum[int64]).DataPoints); n != 2 {
+		t.Errorf("expected 2 series (a and other), got %d", n)
+	}
+}
@@ -2,13 +2,72 @@ package observe
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ExecuteFunc is the signature for tool execution functions.
 // This is the standard function signature that Middleware wraps.
 type ExecuteFunc func(ctx context.Context, tool ToolMeta, input any) (any, error)
 
+// TracingMode controls which telemetry a Middleware records.
+type TracingMode int
+
+const (
+	// TracingFull records spans, metrics, and logs.
+	TracingFull TracingMode = iota
+	// TracingMetricsOnly skips span creation entirely while still recording
+	// metrics and logs. Use it on high-throughput paths where per-call span
+	// allocation is too costly.
+	TracingMetricsOnly
+	// TracingOff records no spans and no metrics; only logs are written.
+	TracingOff
+)
+
+// String returns the mode name.
+func (m TracingMode) String() string {
+	switch m {
+	case TracingFull:
+		return "full"
+	case TracingMetricsOnly:
+		return "metrics_only"
+	case TracingOff:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
+
+// MiddlewareConfig configures optional Middleware behavior.
+// The zero value preserves the default behavior of NewMiddleware.
+type MiddlewareConfig struct {
+	// RecoverPanics recovers panics raised by the wrapped ExecuteFunc.
+	// The panic is recorded on the span (with a tool.panic.stack attribute),
+	// counted as an error, logged at error level, and returned as an error
+	// wrapping ErrToolPanic.
+	// Default: false (panics propagate to the caller)
+	RecoverPanics bool
+
+	// TracingMode selects which telemetry is recorded per call. It applies
+	// on top of the Observer's global Tracing.Enabled switch.
+	// Default: TracingFull
+	TracingMode TracingMode
+
+	// RecordPayloadSizes sets tool.input_bytes and tool.output_bytes span
+	// attributes without recording content. The input is measured as its
+	// JSON encoding unless it is a string or byte slice; the output is
+	// measured only when that is cheap (string, []byte, json.RawMessage, or
+	// a type with a Len() int method) and the call succeeded. Encoding the
+	// input costs an extra marshal per call, so enable it for debugging.
+	// Default: false
+	RecordPayloadSizes bool
+}
+
 // Middleware wraps tool execution with observability (tracing, metrics, logging).
 //
 // Contract:
@@ -20,39 +79,82 @@ type Middleware struct {
 	tracer  Tracer
 	metrics Metrics
 	logger  Logger
+	config  MiddlewareConfig
 }
 
 // NewMiddleware creates a new Middleware with the given observability components.
 func NewMiddleware(tracer Tracer, metrics Metrics, logger Logger) *Middleware {
+	return NewMiddlewareWithConfig(tracer, metrics, logger, MiddlewareConfig{})
+}
+
+// NewMiddlewareWithConfig creates a new Middleware with custom configuration.
+// Nil components are replaced with no-op implementations.
+func NewMiddlewareWithConfig(tracer Tracer, metrics Metrics, logger Logger, config MiddlewareConfig) *Middleware {
+	if tracer == nil {
+		tracer = newNoopTracer()
+	}
+	if metrics == nil {
+		metrics = &noopMetrics{}
+	}
+	if logger == nil {
+		logger = &noopLogger{}
+	}
+
 	return &Middleware{
 		tracer:  tracer,
 		metrics: metrics,
 		logger:  logger,
+		config:  config,
 	}
 }
 
 // Wrap wraps an ExecuteFunc with tracing, metrics, and logging.
 func (m *Middleware) Wrap(fn ExecuteFunc) ExecuteFunc {
 	return func(ctx context.Context, tool ToolMeta, input any) (any, error) {
-		// Start span
-		ctx, span := m.tracer.StartSpan(ctx, tool)
+		// Start span (skipped entirely unless tracing is on)
+		var span trace.Span
+		if m.config.TracingMode == TracingFull {
+			ctx, span = m.tracer.StartSpan(ctx, tool)
+		}
 
 		// Record start time
 		start := time.Now()
 
 		// Execute the function
-		result, err := fn(ctx, tool, input)
+		var result any
+		var err error
+		if m.config.RecoverPanics {
+			result, err = m.execRecover(ctx, span, fn, tool, input)
+		} else {
+			result, err = fn(ctx, tool, input)
+		}
 
 		// Calculate duration
 		duration := time.Since(start)
 
 		// End span (records error status if err != nil)
-		m.tracer.EndSpan(span, err)
+		if span != nil {
+			if m.config.RecordPayloadSizes {
+				setPayloadSizes(span, input, result, err)
+			}
+			m.tracer.EndSpan(span, err)
+		}
 
 		// Record metrics
-		m.metrics.RecordExecution(ctx, tool, duration, err)
+		if m.config.TracingMode != TracingOff {
+			m.metrics.RecordExecution(ctx, tool, duration, err)
+		}
+
+		// Log the execution, skipping the tool logger and fields when the
+		// entry would be filtered out anyway
+		level := LevelInfo
+		if err != nil {
+			level = LevelError
+		}
+		if !LogEnabled(m.logger, level) {
+			return result, err
+		}
 
-		// Log the execution
 		toolLogger := m.logger.WithTool(tool)
 		fields := []Field{
 			{Key: "duration_ms", Value: float64(duration.Milliseconds())},
@@ -69,15 +171,80 @@ func (m *Middleware) Wrap(fn ExecuteFunc) ExecuteFunc {
 	}
 }
 
+// execRecover runs fn and converts a panic into an error wrapping ErrToolPanic.
+// The panic stack is attached to the span, if any, before it is ended.
+func (m *Middleware) execRecover(ctx context.Context, span trace.Span, fn ExecuteFunc, tool ToolMeta, input any) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if span != nil {
+				span.SetAttributes(attribute.String("tool.panic.stack", string(debug.Stack())))
+			}
+			result = nil
+			err = fmt.Errorf("%w: %v", ErrToolPanic, r)
+		}
+	}()
+	return fn(ctx, tool, input)
+}
+
+// setPayloadSizes records the byte sizes of input and, on success, result
+// as span attributes. Values that cannot be sized are left out.
+func setPayloadSizes(span trace.Span, input, result any, err error) {
+	if n, ok := cheapSize(input); ok {
+		span.SetAttributes(attribute.Int("tool.input_bytes", n))
+	} else if data, mErr := json.Marshal(input); mErr == nil {
+		span.SetAttributes(attribute.Int("tool.input_bytes", len(data)))
+	}
+	if err != nil {
+		return
+	}
+	if n, ok := cheapSize(result); ok {
+		span.SetAttributes(attribute.Int("tool.output_bytes", n))
+	}
+}
+
+// cheapSize returns the byte size of v when it is known without encoding.
+func cheapSize(v any) (int, bool) {
+	switch v := v.(type) {
+	case nil:
+		return 0, true
+	case []byte:
+		return len(v), true
+	case json.RawMessage:
+		return len(v), true
+	case string:
+		return len(v), true
+	case interface{ Len() int }:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
 // MiddlewareFromObserver creates a Middleware from an Observer.
 // This is a convenience function for common use cases.
+// A nil Observer is treated as NewNoopObserver().
 func MiddlewareFromObserver(obs Observer) (*Middleware, error) {
+	return MiddlewareFromObserverWithConfig(obs, MiddlewareConfig{})
+}
+
+// MiddlewareFromObserverWithConfig creates a Middleware from an Observer with custom configuration.
+func MiddlewareFromObserverWithConfig(obs Observer, config MiddlewareConfig) (*Middleware, error) {
+	if obs == nil {
+		obs = NewNoopObserver()
+	}
+
 	tracer := newTracer(obs.Tracer())
 
-	metrics, err := newMetrics(obs.Meter())
+	// Observers built by NewObserver carry MetricsConfig label settings
+	var metricsCfg MetricsConfig
+	if c, ok := obs.(interface{ metricsConfig() MetricsConfig }); ok {
+		metricsCfg = c.metricsConfig()
+	}
+
+	metrics, err := newMetricsWithConfig(obs.Meter(), metricsCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewMiddleware(tracer, metrics, obs.Logger()), nil
+	return NewMiddlewareWithConfig(tracer, metrics, obs.Logger(), config), nil
 }
@@ -1,12 +1,16 @@
 package observe
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/codes"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -309,3 +313,248 @@ func TestMiddleware_DisabledNoop(t *testing.T) {
 		t.Errorf("expected result %q, got %q", expectedResult, result)
 	}
 }
+
+// TestMiddleware_RecoverPanics verifies a panicking tool is recorded and returned as an error.
+func TestMiddleware_RecoverPanics(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	tracer := &tracerImpl{tracer: tp.Tracer("test")}
+
+	metricReader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	metrics, _ := newMetrics(mp.Meter("test"))
+
+	var buf bytes.Buffer
+	logger := NewLoggerWithWriter("info", &buf)
+
+	mw := NewMiddlewareWithConfig(tracer, metrics, logger, MiddlewareConfig{RecoverPanics: true})
+
+	innerFunc := func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+		panic("boom")
+	}
+
+	wrapped := mw.Wrap(innerFunc)
+	result, err := wrapped(context.Background(), ToolMeta{Name: "panic_tool"}, nil)
+
+	if !errors.Is(err, ErrToolPanic) {
+		t.Fatalf("expected ErrToolPanic, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected panic value in error, got %q", err.Error())
+	}
+	if result != nil {
+		t.Errorf("expected nil result, got %v", result)
+	}
+
+	// Verify span is errored and carries the stack
+	spans := spanRecorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected span status Error, got %v", spans[0].Status().Code)
+	}
+	var stack string
+	for _, attr := range spans[0].Attributes() {
+		if string(attr.Key) == "tool.panic.stack" {
+			stack = attr.Value.AsString()
+		}
+	}
+	if stack == "" {
+		t.Error("expected tool.panic.stack attribute on span")
+	}
+
+	// Verify error metric incremented
+	var rm metricdata.ResourceMetrics
+	if err := metricReader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	errMetric := findMetric(rm, "tool.exec.errors")
+	if errMetric == nil {
+		t.Fatal("tool.exec.errors metric not found")
+	}
+	sum, ok := errMetric.Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) == 0 || sum.DataPoints[0].Value != 1 {
+		t.Errorf("expected errors count 1, got %+v", errMetric.Data)
+	}
+
+	// Verify error-level log
+	var logEntry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if logEntry["level"] != "error" {
+		t.Errorf("expected level=error, got %v", logEntry["level"])
+	}
+}
+
+// TestMiddleware_PanicPropagatesByDefault verifies panics are not recovered unless enabled.
+func TestMiddleware_PanicPropagatesByDefault(t *testing.T) {
+	mw := NewMiddleware(newNoopTracer(), &noopMetrics{}, &noopLogger{})
+
+	wrapped := mw.Wrap(func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+		panic("boom")
+	})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic to propagate")
+		}
+	}()
+	_, _ = wrapped(context.Background(), ToolMeta{Name: "panic_tool"}, nil)
+}
+
+// TestMiddlewareFromObserver_Nil verifies a nil observer falls back to noop.
+func TestMiddlewareFromObserver_Nil(t *testing.T) {
+	mw, err := MiddlewareFromObserver(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	wrapped := mw.Wrap(func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+		return "ok", nil
+	})
+	result, err := wrapped(context.Background(), ToolMeta{Name: "nil_obs"}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if result != "ok" {
+		t.Errorf("expected result %q, got %v", "ok", result)
+	}
+}
+
+// TestNewMiddleware_NilComponents verifies nil components are replaced with noops.
+func TestNewMiddleware_NilComponents(t *testing.T) {
+	mw := NewMiddleware(nil, nil, nil)
+
+	wrapped := mw.Wrap(func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := wrapped(context.Background(), ToolMeta{Name: "nil_parts"}, nil); err == nil {
+		t.Error("expected error to propagate")
+	}
+}
+
+// TestMiddleware_TracingModes verifies which telemetry each TracingMode records.
+func TestMiddleware_TracingModes(t *testing.T) {
+	tests := []struct {
+		mode        TracingMode
+		wantSpans   int
+		wantMetrics bool
+	}{
+		{TracingFull, 1, true},
+		{TracingMetricsOnly, 0, true},
+		{TracingOff, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			tracer := &tracerImpl{tracer: tp.Tracer("test")}
+
+			metricReader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+			metrics, _ := newMetrics(mp.Meter("test"))
+
+			var buf bytes.Buffer
+			logger := NewLoggerWithWriter("info", &buf)
+
+			mw := NewMiddlewareWithConfig(tracer, metrics, logger, MiddlewareConfig{TracingMode: tt.mode})
+			wrapped := mw.Wrap(func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+				return "ok", nil
+			})
+			if _, err := wrapped(context.Background(), ToolMeta{Name: "mode_tool"}, nil); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if got := len(spanRecorder.Ended()); got != tt.wantSpans {
+				t.Errorf("expected %d spans, got %d", tt.wantSpans, got)
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := metricReader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+			if got := findMetric(rm, "tool.exec.total") != nil; got != tt.wantMetrics {
+				t.Errorf("expected metrics recorded = %v, got %v", tt.wantMetrics, got)
+			}
+
+			if !strings.Contains(buf.String(), "tool execution completed") {
+				t.Errorf("expected completion log in every mode, got: %s", buf.String())
+			}
+		})
+	}
+}
+
+// TestMiddleware_MetricsOnlyRecoverPanics verifies panic recovery works without a span.
+func TestMiddleware_MetricsOnlyRecoverPanics(t *testing.T) {
+	mw := NewMiddlewareWithConfig(newNoopTracer(), &noopMetrics{}, &noopLogger{}, MiddlewareConfig{
+		RecoverPanics: true,
+		TracingMode:   TracingMetricsOnly,
+	})
+
+	wrapped := mw.Wrap(func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+		panic("boom")
+	})
+	_, err := wrapped(context.Background(), ToolMeta{Name: "panicky"}, nil)
+	if !errors.Is(err, ErrToolPanic) {
+		t.Errorf("expected ErrToolPanic, got: %v", err)
+	}
+}
+
+// TestMiddleware_RecordPayloadSizes verifies input and output sizes are set
+// as span attributes only when enabled.
+func TestMiddleware_RecordPayloadSizes(t *testing.T) {
+	input := map[string]any{"path": "/tmp/a"} // {"path":"/tmp/a"} is 17 bytes
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		result     any
+		err        error
+		wantInput  int64
+		wantOutput int64 // -1 when absent
+	}{
+		{name: "bytes output", enabled: true, result: []byte("hello world"), wantInput: 17, wantOutput: 11},
+		{name: "string output", enabled: true, result: "héllo", wantInput: 17, wantOutput: 6},
+		{name: "buffer output", enabled: true, result: bytes.NewBufferString("abc"), wantInput: 17, wantOutput: 3},
+		{name: "struct output not sized", enabled: true, result: struct{ N int }{1}, wantInput: 17, wantOutput: -1},
+		{name: "error omits output", enabled: true, result: "partial", err: errors.New("boom"), wantInput: 17, wantOutput: -1},
+		{name: "disabled", enabled: false, result: []byte("hello"), wantInput: -1, wantOutput: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			mw := NewMiddlewareWithConfig(&tracerImpl{tracer: tp.Tracer("test")}, nil, nil,
+				MiddlewareConfig{RecordPayloadSizes: tt.enabled})
+
+			fn := func(ctx context.Context, tool ToolMeta, in any) (any, error) {
+				return tt.result, tt.err
+			}
+			_, _ = mw.Wrap(fn)(context.Background(), ToolMeta{Name: "read_file"}, input)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			gotInput, gotOutput := int64(-1), int64(-1)
+			for _, kv := range spans[0].Attributes() {
+				switch kv.Key {
+				case "tool.input_bytes":
+					gotInput = kv.Value.AsInt64()
+				case "tool.output_bytes":
+					gotOutput = kv.Value.AsInt64()
+				}
+			}
+			if gotInput != tt.wantInput {
+				t.Errorf("tool.input_bytes = %d, want %d", gotInput, tt.wantInput)
+			}
+			if gotOutput != tt.wantOutput {
+				t.Errorf("tool.output_bytes = %d, want %d", gotOutput, tt.wantOutput)
+			}
+		})
+	}
+}
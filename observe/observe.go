@@ -4,8 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -22,43 +30,148 @@ import (
 type Config struct {
 	ServiceName string
 	Version     string
-	Tracing     TracingConfig
-	Metrics     MetricsConfig
-	Logging     LoggingConfig
+
+	// ResourceAttributes are added to the OpenTelemetry resource, tagging
+	// every span and metric, e.g. {"deployment.environment": "prod",
+	// "cloud.region": "eu-west-1"}. ServiceName and Version take
+	// precedence over "service.name" and "service.version" entries.
+	// Default: nil
+	ResourceAttributes map[string]string
+
+	Tracing TracingConfig
+	Metrics MetricsConfig
+	Logging LoggingConfig
 }
 
 // TracingConfig configures the tracing subsystem.
 type TracingConfig struct {
 	Enabled   bool
-	Exporter  string  // otlp|jaeger|stdout|none
+	Exporter  string  // otlp|jaeger|zipkin|datadog|stdout|none
 	SamplePct float64 // 0.0-1.0
 }
 
 // MetricsConfig configures the metrics subsystem.
 type MetricsConfig struct {
 	Enabled  bool
-	Exporter string // otlp|prometheus|stdout|none
+	Exporter string // otlp|prometheus|datadog|stdout|none
+
+	// Events lists event names that, when recorded via Observer.Event, also
+	// increment a counter named "event.<name>". Event fields are never used
+	// as metric attributes, so cardinality is bounded by this list.
+	// Default: nil (events are logged only)
+	Events []string
+
+	// Rollup aggregates tool.exec.* by tool.namespace, dropping the
+	// per-tool dimensions: RollupAdditive adds "<name>.by_namespace" series
+	// alongside per-tool series; RollupReplace records only the rollup.
+	// Default: RollupNone
+	Rollup string
+
+	// ExtraLabels adds ToolMeta fields as labels on tool.exec.* metrics:
+	// MetricLabelVersion ("tool.version") and MetricLabelCategory
+	// ("tool.category"). Each label multiplies series count by its number
+	// of distinct values; version grows with every deploy, so prefer it
+	// for short-retention canary analysis.
+	// Default: nil (tool.id, tool.name, tool.namespace only)
+	ExtraLabels []string
+
+	// DurationBuckets sets the bucket boundaries, in milliseconds, of the
+	// tool.exec.duration_ms histogram, e.g. {0.1, 0.5, 1, 5} for
+	// sub-millisecond tools. Must be positive and strictly increasing.
+	// Default: nil (OpenTelemetry default boundaries)
+	DurationBuckets []float64
+
+	// MaxToolIDs caps the distinct tool.id values recorded on tool.exec.*
+	// metrics, protecting the backend when tool names are dynamic or
+	// user-supplied. The first MaxToolIDs IDs seen keep their own series;
+	// later ones are recorded with tool.id and tool.name set to
+	// OverflowToolID. Zero or negative means no limit.
+	// Default: 0 (no limit)
+	MaxToolIDs int
+
+	// OverflowToolID is the tool.id and tool.name recorded for tools beyond
+	// MaxToolIDs.
+	// Default: DefaultOverflowToolID ("other")
+	OverflowToolID string
 }
 
 // LoggingConfig configures the logging subsystem.
 type LoggingConfig struct {
 	Enabled bool
 	Level   string // debug|info|warn|error
+
+	// File directs logs to a size-rotated JSON-lines file instead of stderr.
+	// Ignored when File.Path is empty.
+	File LogFileConfig
+
+	// Async queues entries for a background writer instead of writing
+	// them on the calling goroutine. See AsyncWriter.
+	Async LogAsyncConfig
+
+	// Correlation adds trace_id and span_id fields from the active span in
+	// the log call's context. Nil means enabled.
+	// Default: nil (enabled)
+	Correlation *bool
+
+	// RedactFields lists extra field keys to redact, such as "ssn" or
+	// "cookie", merged with RedactedFields. Matched case-insensitively,
+	// including keys inside map values.
+	// Default: nil (RedactedFields only)
+	RedactFields []string
+
+	// DisableDefaultRedaction stops RedactedFields from being redacted, so
+	// RedactFields replaces the default set instead of extending it.
+	// Default: false
+	DisableDefaultRedaction bool
+
+	// RedactPatterns are regular expressions (RE2 syntax) whose matches
+	// are masked in log messages and string field values; see
+	// WithRedactPatterns.
+	// Default: nil (no value scanning)
+	RedactPatterns []string
+
+	// SampleRate is the fraction (0.0-1.0) of debug and info entries to
+	// write; warn and error entries are always written. See WithSampling.
+	// Default: 0 (no sampling, every entry is written)
+	SampleRate float64
+
+	// Format selects the output format: LogFormatJSON for log pipelines or
+	// LogFormatText for reading logs locally.
+	// Default: LogFormatJSON ("" is treated as "json")
+	Format string
+}
+
+// LogFileConfig configures file-based log output with rotation.
+type LogFileConfig struct {
+	Path       string // Log file path; empty disables file output
+	MaxSizeMB  int    // Rotate after this many megabytes (default: 100)
+	MaxBackups int    // Rotated files to keep (default: 0, keep all)
+	MaxAgeDays int    // Remove rotated files older than this (default: 0, keep all)
+}
+
+// LogAsyncConfig configures asynchronous log writing.
+type LogAsyncConfig struct {
+	Enabled    bool
+	BufferSize int            // Queued entries (default: 1024)
+	Policy     OverflowPolicy // When the buffer is full (default: OverflowBlock)
 }
 
 // Valid tracing exporters.
 var validTracingExporters = map[string]bool{
-	"otlp":   true,
-	"jaeger": true,
-	"stdout": true,
-	"none":   true,
-	"":       true, // Empty is valid (disabled)
+	"otlp":    true,
+	"jaeger":  true,
+	"zipkin":  true,
+	"datadog": true,
+	"stdout":  true,
+	"none":    true,
+	"":        true, // Empty is valid (disabled)
 }
 
 // Valid metrics exporters.
 var validMetricsExporters = map[string]bool{
 	"otlp":       true,
 	"prometheus": true,
+	"datadog":    true,
 	"stdout":     true,
 	"none":       true,
 	"":           true, // Empty is valid (disabled)
@@ -76,14 +189,27 @@ var validLogLevels = map[string]bool{
 // Validate validates the configuration.
 // Returns sentinel errors that can be checked with errors.Is:
 //   - ErrMissingServiceName: ServiceName is empty
+//   - ErrInvalidResourceAttribute: A ResourceAttributes key is empty
 //   - ErrInvalidTracingExporter: Unknown tracing exporter
 //   - ErrInvalidSamplePct: SamplePct not in [0.0, 1.0]
 //   - ErrInvalidMetricsExporter: Unknown metrics exporter
+//   - ErrInvalidMetricsRollup: Unknown metrics rollup mode
+//   - ErrInvalidMetricsLabel: Unknown metrics extra label
+//   - ErrInvalidDurationBuckets: Duration buckets not positive and increasing
 //   - ErrInvalidLogLevel: Unknown log level
+//   - ErrInvalidLogFile: Negative log file rotation limits
+//   - ErrInvalidLogFormat: Unknown log format
+//   - ErrInvalidLogSampleRate: Logging.SampleRate not in [0.0, 1.0]
+//   - ErrInvalidRedactPattern: A Logging.RedactPatterns entry does not compile
 func (c *Config) Validate() error {
 	if c.ServiceName == "" {
 		return ErrMissingServiceName
 	}
+	for key := range c.ResourceAttributes {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: empty key", ErrInvalidResourceAttribute)
+		}
+	}
 
 	if c.Tracing.Enabled {
 		if !validTracingExporters[c.Tracing.Exporter] {
@@ -98,12 +224,49 @@ func (c *Config) Validate() error {
 		if !validMetricsExporters[c.Metrics.Exporter] {
 			return fmt.Errorf("%w: %q", ErrInvalidMetricsExporter, c.Metrics.Exporter)
 		}
+		switch c.Metrics.Rollup {
+		case RollupNone, RollupAdditive, RollupReplace:
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidMetricsRollup, c.Metrics.Rollup)
+		}
+		for _, label := range c.Metrics.ExtraLabels {
+			switch label {
+			case MetricLabelVersion, MetricLabelCategory:
+			default:
+				return fmt.Errorf("%w: %q", ErrInvalidMetricsLabel, label)
+			}
+		}
+		for i, bound := range c.Metrics.DurationBuckets {
+			if bound <= 0 {
+				return fmt.Errorf("%w: boundary %v is not positive", ErrInvalidDurationBuckets, bound)
+			}
+			if i > 0 && bound <= c.Metrics.DurationBuckets[i-1] {
+				return fmt.Errorf("%w: boundaries must be strictly increasing", ErrInvalidDurationBuckets)
+			}
+		}
 	}
 
 	if c.Logging.Enabled {
 		if !validLogLevels[c.Logging.Level] {
 			return fmt.Errorf("%w: %q", ErrInvalidLogLevel, c.Logging.Level)
 		}
+		f := c.Logging.File
+		if f.MaxSizeMB < 0 || f.MaxBackups < 0 || f.MaxAgeDays < 0 {
+			return fmt.Errorf("%w: rotation limits must not be negative", ErrInvalidLogFile)
+		}
+		switch c.Logging.Format {
+		case "", LogFormatJSON, LogFormatText:
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidLogFormat, c.Logging.Format)
+		}
+		if c.Logging.SampleRate < MinSamplePct || c.Logging.SampleRate > MaxSamplePct {
+			return fmt.Errorf("%w: got %f", ErrInvalidLogSampleRate, c.Logging.SampleRate)
+		}
+		for _, pattern := range c.Logging.RedactPatterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("%w: %q: %w", ErrInvalidRedactPattern, pattern, err)
+			}
+		}
 	}
 
 	return nil
@@ -114,7 +277,7 @@ func (c *Config) Validate() error {
 // Contract:
 //   - Concurrency: All methods are safe for concurrent use after construction.
 //   - Context: Shutdown honors context cancellation/deadlines.
-//   - Errors: Shutdown aggregates errors from subsystem shutdowns using errors.Join.
+//   - Errors: ForceFlush and Shutdown aggregate subsystem errors using errors.Join.
 //   - Ownership: Returned Tracer, Meter, Logger are shared; do not close individually.
 //   - Lifecycle: Shutdown is idempotent; subsequent calls are safe.
 type Observer interface {
@@ -127,6 +290,17 @@ type Observer interface {
 	// Logger returns the configured structured logger.
 	Logger() Logger
 
+	// Event records a structured business event (e.g., "order_placed").
+	// It logs at info level with an "event" field, so fields are redacted
+	// and trace-correlated like any log entry, and increments the event's
+	// counter when the name is listed in MetricsConfig.Events.
+	Event(ctx context.Context, name string, fields ...Field)
+
+	// ForceFlush exports all buffered spans, metrics, and log entries
+	// without shutting down. The observer remains usable afterward.
+	// Returns aggregated errors from all subsystems.
+	ForceFlush(ctx context.Context) error
+
 	// Shutdown gracefully shuts down all telemetry providers.
 	// Returns aggregated errors from all subsystems.
 	Shutdown(ctx context.Context) error
@@ -136,7 +310,7 @@ type Observer interface {
 //
 // Contract:
 //   - Concurrency: All methods are safe for concurrent use.
-//   - Context: Methods accept context for future extensibility (tracing correlation).
+//   - Context: trace_id/span_id are taken from the active span in ctx, when present.
 //   - Errors: Logging is best-effort and must not panic on failures.
 //   - Ownership: WithTool returns a new Logger; original remains unchanged.
 //   - Redaction: Sensitive fields (see RedactedFields) are automatically redacted.
@@ -166,6 +340,10 @@ type observer struct {
 	logger         Logger
 	tracerProvider *sdktrace.TracerProvider
 	meterProvider  *sdkmetric.MeterProvider
+	logFile        *RotatingFile
+	logAsync       *AsyncWriter
+	eventCounters  map[string]metric.Int64Counter
+	metricsCfg     MetricsConfig
 }
 
 // NewObserver creates a new Observer with the given configuration.
@@ -177,12 +355,7 @@ func NewObserver(ctx context.Context, cfg Config) (Observer, error) {
 	obs := &observer{}
 
 	// Set up resource for all providers
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.ServiceName),
-			semconv.ServiceVersion(cfg.Version),
-		),
-	)
+	res, err := newResource(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
@@ -207,13 +380,58 @@ func NewObserver(ctx context.Context, cfg Config) (Observer, error) {
 		}
 		obs.meterProvider = mp
 		obs.meter = meter
+
+		counters, err := newEventCounters(meter, cfg.Metrics.Events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup metrics: %w", err)
+		}
+		obs.eventCounters = counters
+		obs.metricsCfg = cfg.Metrics
 	} else {
 		obs.meter = noop.NewMeterProvider().Meter("noop")
 	}
 
 	// Set up logging
 	if cfg.Logging.Enabled {
-		obs.logger = NewLogger(cfg.Logging.Level)
+		logOpts := []LoggerOption{
+			WithCorrelation(cfg.Logging.Correlation == nil || *cfg.Logging.Correlation),
+			WithRedactedFields(cfg.Logging.RedactFields...),
+		}
+		if cfg.Logging.DisableDefaultRedaction {
+			logOpts = append(logOpts, WithoutDefaultRedaction())
+		}
+		for _, pattern := range cfg.Logging.RedactPatterns {
+			logOpts = append(logOpts, WithRedactPatterns(regexp.MustCompile(pattern)))
+		}
+		if cfg.Logging.Format != "" {
+			logOpts = append(logOpts, WithFormat(cfg.Logging.Format))
+		}
+		if cfg.Logging.SampleRate > 0 {
+			logOpts = append(logOpts, WithSampling(cfg.Logging.SampleRate))
+		}
+		var out io.Writer = os.Stderr
+		if cfg.Logging.File.Path != "" {
+			f := cfg.Logging.File
+			rf, err := NewRotatingFile(RotatingFileConfig{
+				Path:       f.Path,
+				MaxSize:    int64(f.MaxSizeMB) * 1024 * 1024,
+				MaxBackups: f.MaxBackups,
+				MaxAge:     time.Duration(f.MaxAgeDays) * 24 * time.Hour,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to setup logging: %w", err)
+			}
+			obs.logFile = rf
+			out = rf
+		}
+		if cfg.Logging.Async.Enabled {
+			obs.logAsync = NewAsyncWriter(out, AsyncWriterConfig{
+				BufferSize: cfg.Logging.Async.BufferSize,
+				Policy:     cfg.Logging.Async.Policy,
+			})
+			out = obs.logAsync
+		}
+		obs.logger = NewLoggerWithWriter(cfg.Logging.Level, out, logOpts...)
 	} else {
 		obs.logger = &noopLogger{}
 	}
@@ -221,6 +439,74 @@ func NewObserver(ctx context.Context, cfg Config) (Observer, error) {
 	return obs, nil
 }
 
+// NewNoopObserver returns an Observer whose tracer, meter, and logger are all
+// no-ops and whose Shutdown always succeeds.
+//
+// Libraries that accept an Observer can use it as a safe default instead of
+// handling nil:
+//
+//	if obs == nil {
+//	    obs = observe.NewNoopObserver()
+//	}
+func NewNoopObserver() Observer {
+	return &observer{
+		tracer: tracenoop.NewTracerProvider().Tracer("noop"),
+		meter:  noop.NewMeterProvider().Meter("noop"),
+		logger: &noopLogger{},
+	}
+}
+
+// newResource builds the resource shared by all providers from the service
+// identity and cfg.ResourceAttributes.
+func newResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	extra := cfg.ResourceAttributes
+	version := cfg.Version
+	if usesDatadog(cfg) {
+		extra, version = datadogResource(extra, version)
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys)+2)
+	for _, key := range keys {
+		attrs = append(attrs, attribute.String(key, extra[key]))
+	}
+	// Later duplicates win, so the service identity overrides the map
+	attrs = append(attrs,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(version),
+	)
+	return resource.New(ctx, resource.WithAttributes(attrs...))
+}
+
+// usesDatadog reports whether tracing or metrics export to Datadog.
+func usesDatadog(cfg Config) bool {
+	return (cfg.Tracing.Enabled && cfg.Tracing.Exporter == "datadog") ||
+		(cfg.Metrics.Enabled && cfg.Metrics.Exporter == "datadog")
+}
+
+// datadogResource fills the attributes Datadog maps to its env and version
+// tags from the Datadog Agent conventions DD_ENV and DD_VERSION, unless the
+// configuration already sets them.
+func datadogResource(attrs map[string]string, version string) (map[string]string, string) {
+	if env := os.Getenv("DD_ENV"); env != "" {
+		if _, ok := attrs["deployment.environment"]; !ok {
+			merged := make(map[string]string, len(attrs)+1)
+			maps.Copy(merged, attrs)
+			merged["deployment.environment"] = env
+			attrs = merged
+		}
+	}
+	if version == "" {
+		version = os.Getenv("DD_VERSION")
+	}
+	return attrs, version
+}
+
 func setupTracing(ctx context.Context, cfg Config, res *resource.Resource) (*sdktrace.TracerProvider, trace.Tracer, error) {
 	exporter, err := exporters.NewTracingExporter(ctx, cfg.Tracing.Exporter)
 	if err != nil {
@@ -264,6 +550,9 @@ func setupMetrics(ctx context.Context, cfg Config, res *resource.Resource) (*sdk
 	if reader != nil {
 		opts = append(opts, sdkmetric.WithReader(reader))
 	}
+	if views := metricViews(cfg.Metrics); len(views) > 0 {
+		opts = append(opts, sdkmetric.WithView(views...))
+	}
 
 	mp := sdkmetric.NewMeterProvider(opts...)
 	otel.SetMeterProvider(mp)
@@ -284,6 +573,77 @@ func (o *observer) Logger() Logger {
 	return o.logger
 }
 
+func (o *observer) Event(ctx context.Context, name string, fields ...Field) {
+	all := make([]Field, 0, len(fields)+1)
+	all = append(all, Field{Key: "event", Value: name})
+	all = append(all, fields...)
+	o.logger.Info(ctx, name, all...)
+
+	if counter, ok := o.eventCounters[name]; ok {
+		counter.Add(ctx, 1)
+	}
+}
+
+// metricsConfig returns the MetricsConfig the observer was built with.
+func (o *observer) metricsConfig() MetricsConfig {
+	return o.metricsCfg
+}
+
+// newEventCounters creates one counter per registered event name.
+func newEventCounters(meter metric.Meter, names []string) (map[string]metric.Int64Counter, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	counters := make(map[string]metric.Int64Counter, len(names))
+	for _, name := range names {
+		counter, err := meter.Int64Counter(
+			"event."+name,
+			metric.WithDescription("Total number of "+name+" events"),
+			metric.WithUnit("{event}"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		counters[name] = counter
+	}
+	return counters, nil
+}
+
+func (o *observer) ForceFlush(ctx context.Context) error {
+	var errs []error
+
+	if o.tracerProvider != nil {
+		if err := o.tracerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("tracer flush: %w", err))
+		}
+	}
+
+	if o.meterProvider != nil {
+		if err := o.meterProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("meter flush: %w", err))
+		}
+	}
+
+	// Drain queued entries before flushing the file they are written to
+	if o.logAsync != nil {
+		if err := o.logAsync.Flush(); err != nil {
+			errs = append(errs, fmt.Errorf("async log flush: %w", err))
+		}
+	}
+
+	if o.logFile != nil {
+		if err := o.logFile.Flush(); err != nil {
+			errs = append(errs, fmt.Errorf("log file flush: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
 func (o *observer) Shutdown(ctx context.Context) error {
 	var errs []error
 
@@ -299,6 +659,18 @@ func (o *observer) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if o.logAsync != nil {
+		if err := o.logAsync.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("async log shutdown: %w", err))
+		}
+	}
+
+	if o.logFile != nil {
+		if err := o.logFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("log file shutdown: %w", err))
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
@@ -313,3 +685,4 @@ func (l *noopLogger) Warn(ctx context.Context, msg string, fields ...Field)  {}
 func (l *noopLogger) Error(ctx context.Context, msg string, fields ...Field) {}
 func (l *noopLogger) Debug(ctx context.Context, msg string, fields ...Field) {}
 func (l *noopLogger) WithTool(meta ToolMeta) Logger                          { return l }
+func (l *noopLogger) Enabled(level LogLevel) bool                            { return false }
@@ -1,9 +1,21 @@
 package observe
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
 // TestConfigValidate_Valid verifies that a fully valid config passes validation.
@@ -48,6 +60,65 @@ func TestConfigValidate_MissingServiceName(t *testing.T) {
 	}
 }
 
+// TestConfigValidate_EmptyResourceAttributeKey verifies that an empty
+// resource attribute key fails validation.
+func TestConfigValidate_EmptyResourceAttributeKey(t *testing.T) {
+	cfg := Config{
+		ServiceName:        "test-service",
+		ResourceAttributes: map[string]string{" ": "prod"},
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidResourceAttribute) {
+		t.Errorf("expected ErrInvalidResourceAttribute, got: %v", err)
+	}
+}
+
+// TestNewObserver_ResourceAttributes verifies recorded spans carry the
+// configured resource attributes alongside the service identity.
+func TestNewObserver_ResourceAttributes(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Version:     "1.2.3",
+		ResourceAttributes: map[string]string{
+			"deployment.environment": "prod",
+			"cloud.region":           "eu-west-1",
+			"service.name":           "ignored",
+		},
+		Tracing: TracingConfig{Enabled: true, Exporter: "none", SamplePct: 1.0},
+	}
+
+	obs, err := NewObserver(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+	defer func() { _ = obs.Shutdown(context.Background()) }()
+
+	recorder := tracetest.NewSpanRecorder()
+	obs.(*observer).tracerProvider.RegisterSpanProcessor(recorder)
+
+	_, span := obs.Tracer().Start(context.Background(), "op")
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	res := spans[0].Resource()
+	want := map[string]string{
+		"deployment.environment": "prod",
+		"cloud.region":           "eu-west-1",
+		"service.name":           "test-service",
+		"service.version":        "1.2.3",
+	}
+	for key, value := range want {
+		got, ok := res.Set().Value(attribute.Key(key))
+		if !ok || got.AsString() != value {
+			t.Errorf("resource %s = %q, want %q", key, got.AsString(), value)
+		}
+	}
+}
+
 // TestConfigValidate_UnknownTracingExporter verifies that unknown tracing exporter fails validation.
 func TestConfigValidate_UnknownTracingExporter(t *testing.T) {
 	cfg := Config{
@@ -67,6 +138,104 @@ func TestConfigValidate_UnknownTracingExporter(t *testing.T) {
 	}
 }
 
+// TestConfigValidate_ZipkinExporter verifies zipkin is an accepted tracing
+// exporter and NewObserver reports a missing endpoint.
+func TestConfigValidate_ZipkinExporter(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", "")
+
+	cfg := Config{
+		ServiceName: "test-service",
+		Tracing: TracingConfig{
+			Enabled:   true,
+			Exporter:  "zipkin",
+			SamplePct: 1.0,
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected zipkin to be valid, got: %v", err)
+	}
+
+	_, err := NewObserver(context.Background(), cfg)
+	if !errors.Is(err, ErrEndpointNotConfigured) {
+		t.Errorf("expected ErrEndpointNotConfigured, got: %v", err)
+	}
+}
+
+// TestConfigValidate_DatadogExporter verifies datadog is accepted for both
+// tracing and metrics, and NewObserver reports a missing agent host.
+func TestConfigValidate_DatadogExporter(t *testing.T) {
+	t.Setenv("DD_AGENT_HOST", "")
+
+	cfg := Config{
+		ServiceName: "test-service",
+		Tracing:     TracingConfig{Enabled: true, Exporter: "datadog", SamplePct: 1.0},
+		Metrics:     MetricsConfig{Enabled: true, Exporter: "datadog"},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected datadog to be valid, got: %v", err)
+	}
+
+	_, err := NewObserver(context.Background(), cfg)
+	if !errors.Is(err, ErrEndpointNotConfigured) {
+		t.Errorf("expected ErrEndpointNotConfigured, got: %v", err)
+	}
+}
+
+// TestNewResource_DatadogTags verifies DD_ENV and DD_VERSION fill the
+// resource when exporting to Datadog, without overriding configuration.
+func TestNewResource_DatadogTags(t *testing.T) {
+	t.Setenv("DD_ENV", "staging")
+	t.Setenv("DD_VERSION", "9.9.9")
+
+	tests := []struct {
+		name        string
+		cfg         Config
+		wantEnv     string
+		wantVersion string
+	}{
+		{
+			name:        "from env",
+			cfg:         Config{ServiceName: "svc", Tracing: TracingConfig{Enabled: true, Exporter: "datadog"}},
+			wantEnv:     "staging",
+			wantVersion: "9.9.9",
+		},
+		{
+			name: "config wins",
+			cfg: Config{
+				ServiceName:        "svc",
+				Version:            "1.0.0",
+				ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+				Metrics:            MetricsConfig{Enabled: true, Exporter: "datadog"},
+			},
+			wantEnv:     "prod",
+			wantVersion: "1.0.0",
+		},
+		{
+			name: "other exporters ignore DD_ENV",
+			cfg:  Config{ServiceName: "svc", Tracing: TracingConfig{Enabled: true, Exporter: "otlp"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := newResource(context.Background(), tt.cfg)
+			if err != nil {
+				t.Fatalf("newResource() error = %v", err)
+			}
+			env, _ := res.Set().Value("deployment.environment")
+			if env.AsString() != tt.wantEnv {
+				t.Errorf("deployment.environment = %q, want %q", env.AsString(), tt.wantEnv)
+			}
+			version, _ := res.Set().Value("service.version")
+			if version.AsString() != tt.wantVersion {
+				t.Errorf("service.version = %q, want %q", version.AsString(), tt.wantVersion)
+			}
+		})
+	}
+}
+
 // TestConfigValidate_UnknownMetricsExporter verifies that unknown metrics exporter fails validation.
 func TestConfigValidate_UnknownMetricsExporter(t *testing.T) {
 	cfg := Config{
@@ -126,6 +295,65 @@ func TestConfigValidate_SamplePctNegative(t *testing.T) {
 	}
 }
 
+// TestConfigValidate_UnknownMetricsRollup verifies unknown rollup modes are rejected.
+func TestConfigValidate_UnknownMetricsRollup(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Metrics:     MetricsConfig{Enabled: true, Exporter: "none", Rollup: "by_tool"},
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidMetricsRollup) {
+		t.Errorf("expected ErrInvalidMetricsRollup, got: %v", err)
+	}
+}
+
+// TestConfigValidate_UnknownMetricsLabel verifies unknown extra labels are rejected.
+func TestConfigValidate_UnknownMetricsLabel(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Metrics:     MetricsConfig{Enabled: true, Exporter: "none", ExtraLabels: []string{"tags"}},
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidMetricsLabel) {
+		t.Errorf("expected ErrInvalidMetricsLabel, got: %v", err)
+	}
+}
+
+// TestConfigValidate_InvalidDurationBuckets verifies that duration buckets
+// must be positive and strictly increasing.
+func TestConfigValidate_InvalidDurationBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []float64
+		wantErr bool
+	}{
+		{name: "valid", buckets: []float64{0.5, 1, 10}},
+		{name: "zero", buckets: []float64{0, 1}, wantErr: true},
+		{name: "negative", buckets: []float64{-1, 1}, wantErr: true},
+		{name: "unsorted", buckets: []float64{5, 1}, wantErr: true},
+		{name: "duplicate", buckets: []float64{1, 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				ServiceName: "test-service",
+				Metrics:     MetricsConfig{Enabled: true, Exporter: "none", DurationBuckets: tt.buckets},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidDurationBuckets) {
+				t.Errorf("expected ErrInvalidDurationBuckets, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // TestConfigValidate_UnknownLogLevel verifies that unknown log level fails validation.
 func TestConfigValidate_UnknownLogLevel(t *testing.T) {
 	cfg := Config{
@@ -145,6 +373,54 @@ func TestConfigValidate_UnknownLogLevel(t *testing.T) {
 	}
 }
 
+// TestConfigValidate_UnknownLogFormat verifies that an unknown log format
+// fails validation.
+func TestConfigValidate_UnknownLogFormat(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Logging:     LoggingConfig{Enabled: true, Level: "info", Format: "xml"},
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidLogFormat) {
+		t.Errorf("expected ErrInvalidLogFormat, got: %v", err)
+	}
+}
+
+// TestConfigValidate_LogSampleRateOutOfRange verifies that a log sample
+// rate outside [0.0, 1.0] fails validation.
+func TestConfigValidate_LogSampleRateOutOfRange(t *testing.T) {
+	for _, rate := range []float64{-0.1, 1.5} {
+		cfg := Config{
+			ServiceName: "test-service",
+			Logging:     LoggingConfig{Enabled: true, Level: "info", SampleRate: rate},
+		}
+
+		err := cfg.Validate()
+		if !errors.Is(err, ErrInvalidLogSampleRate) {
+			t.Errorf("SampleRate %v: expected ErrInvalidLogSampleRate, got: %v", rate, err)
+		}
+	}
+}
+
+// TestConfigValidate_InvalidRedactPattern verifies that a redact pattern
+// that does not compile fails validation.
+func TestConfigValidate_InvalidRedactPattern(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Logging: LoggingConfig{
+			Enabled:        true,
+			Level:          "info",
+			RedactPatterns: []string{`eyJ[\w-]+`, `(unclosed`},
+		},
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidRedactPattern) {
+		t.Errorf("expected ErrInvalidRedactPattern, got: %v", err)
+	}
+}
+
 // TestNewObserver_DisabledNoop verifies that all-disabled config returns no-op observer.
 func TestNewObserver_DisabledNoop(t *testing.T) {
 	cfg := Config{
@@ -255,3 +531,163 @@ func TestObserver_ShutdownGracefully(t *testing.T) {
 		t.Errorf("expected no shutdown error, got: %v", err)
 	}
 }
+
+// TestNewNoopObserver verifies the noop observer is fully usable.
+func TestNewNoopObserver(t *testing.T) {
+	obs := NewNoopObserver()
+
+	if obs.Tracer() == nil {
+		t.Error("expected non-nil tracer (noop)")
+	}
+	if obs.Meter() == nil {
+		t.Error("expected non-nil meter (noop)")
+	}
+	if obs.Logger() == nil {
+		t.Fatal("expected non-nil logger (noop)")
+	}
+
+	ctx, span := obs.Tracer().Start(context.Background(), "op")
+	span.End()
+	obs.Logger().WithTool(ToolMeta{Name: "t"}).Info(ctx, "ignored")
+
+	if err := obs.ForceFlush(context.Background()); err != nil {
+		t.Errorf("expected no flush error, got: %v", err)
+	}
+	if err := obs.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected no shutdown error, got: %v", err)
+	}
+	if err := obs.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected idempotent shutdown, got: %v", err)
+	}
+}
+
+// TestObserver_ForceFlush verifies buffered spans and metrics are exported
+// on demand and the observer stays usable afterward.
+func TestObserver_ForceFlush(t *testing.T) {
+	ctx := context.Background()
+
+	// Batching and periodic export with long intervals: nothing is exported
+	// during the test unless flushed.
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(spans, sdktrace.WithBatchTimeout(time.Hour)))
+
+	var metricsOut bytes.Buffer
+	metricExporter, err := stdoutmetric.New(stdoutmetric.WithWriter(&metricsOut))
+	if err != nil {
+		t.Fatalf("failed to create metric exporter: %v", err)
+	}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(
+		sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(time.Hour)),
+	))
+
+	obs := &observer{
+		tracer:         tp.Tracer("test"),
+		meter:          mp.Meter("test"),
+		logger:         &noopLogger{},
+		tracerProvider: tp,
+		meterProvider:  mp,
+	}
+	defer func() { _ = obs.Shutdown(ctx) }()
+
+	counter, err := obs.Meter().Int64Counter("jobs.processed")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	_, span := obs.Tracer().Start(ctx, "job")
+	counter.Add(ctx, 1)
+	span.End()
+
+	if len(spans.GetSpans()) != 0 || metricsOut.Len() != 0 {
+		t.Fatal("expected nothing exported before ForceFlush")
+	}
+
+	if err := obs.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported spans = %d, want 1", got)
+	}
+	if !strings.Contains(metricsOut.String(), "jobs.processed") {
+		t.Errorf("expected jobs.processed in exported metrics, got: %s", metricsOut.String())
+	}
+
+	// Still usable after flushing
+	_, span = obs.Tracer().Start(ctx, "job")
+	span.End()
+	if err := obs.ForceFlush(ctx); err != nil {
+		t.Fatalf("second ForceFlush() error = %v", err)
+	}
+	if got := len(spans.GetSpans()); got != 2 {
+		t.Errorf("exported spans = %d, want 2", got)
+	}
+}
+
+// TestObserver_Event verifies events are logged with redaction and counted when registered.
+func TestObserver_Event(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meter := mp.Meter("test")
+	counters, err := newEventCounters(meter, []string{"order_placed"})
+	if err != nil {
+		t.Fatalf("failed to create event counters: %v", err)
+	}
+
+	var buf bytes.Buffer
+	obs := &observer{
+		tracer:        tracenoop.NewTracerProvider().Tracer("noop"),
+		meter:         meter,
+		logger:        NewLoggerWithWriter("info", &buf),
+		eventCounters: counters,
+	}
+
+	ctx := context.Background()
+	obs.Event(ctx, "order_placed", Field{Key: "order_id", Value: "o-1"}, Field{Key: "token", Value: "s3cret"})
+	obs.Event(ctx, "order_placed")
+	obs.Event(ctx, "unregistered_event")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 log lines, got %d", len(lines))
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatalf("failed to parse log output as JSON: %v", err)
+	}
+	if entry["event"] != "order_placed" {
+		t.Errorf("expected event='order_placed', got %v", entry["event"])
+	}
+	if entry["level"] != "info" {
+		t.Errorf("expected level='info', got %v", entry["level"])
+	}
+	if entry["order_id"] != "o-1" {
+		t.Errorf("expected order_id='o-1', got %v", entry["order_id"])
+	}
+	if entry["token"] == "s3cret" {
+		t.Error("expected token to be redacted")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	found := findMetric(rm, "event.order_placed")
+	if found == nil {
+		t.Fatal("event.order_placed metric not found")
+	}
+	sum, ok := found.Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 {
+		t.Fatalf("expected one Sum[int64] data point, got %T", found.Data)
+	}
+	if sum.DataPoints[0].Value != 2 {
+		t.Errorf("expected count 2, got %d", sum.DataPoints[0].Value)
+	}
+	if findMetric(rm, "event.unregistered_event") != nil {
+		t.Error("unregistered event should not create a metric")
+	}
+}
+
+// TestNewNoopObserver_Event verifies Event is safe on the noop observer.
+func TestNewNoopObserver_Event(t *testing.T) {
+	NewNoopObserver().Event(context.Background(), "ignored", Field{Key: "k", Value: "v"})
+}
@@ -0,0 +1,248 @@
+package observe
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp layout used in rotated file names.
+// It sorts lexicographically in chronological order.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// RotatingFileConfig configures a RotatingFile.
+type RotatingFileConfig struct {
+	// Path is the file to write to. Rotated backups are written alongside it
+	// as <name>-<timestamp><ext>.
+	Path string
+
+	// MaxSize is the size in bytes at which the file is rotated.
+	// Default: 100 MB
+	MaxSize int64
+
+	// MaxBackups is the maximum number of rotated files to retain.
+	// Default: 0 (retain all)
+	MaxBackups int
+
+	// MaxAge is the maximum age of rotated files before they are removed.
+	// Default: 0 (no age-based removal)
+	MaxAge time.Duration
+
+	// BufferSize is the size of the write buffer in bytes.
+	// Default: 64 KB
+	BufferSize int
+}
+
+// RotatingFile is a buffered io.WriteCloser that rotates the underlying file
+// once it reaches a size threshold, pruning old backups by count and age.
+//
+// Contract:
+//   - Concurrency: All methods are safe for concurrent use.
+//   - Buffering: Writes are buffered; call Flush or Close to persist them.
+//   - Rotation: If the file cannot be rotated, writing continues to the
+//     current file and rotation is retried on the next write over MaxSize.
+//   - Lifecycle: Close flushes and closes the file; it is idempotent.
+type RotatingFile struct {
+	config RotatingFileConfig
+
+	mu     sync.Mutex
+	file   *os.File
+	buf    *bufio.Writer
+	size   int64
+	closed bool
+	now    func() time.Time
+}
+
+// NewRotatingFile opens (or creates) the file at config.Path for appending.
+func NewRotatingFile(config RotatingFileConfig) (*RotatingFile, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("%w: path is required", ErrInvalidLogFile)
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = 100 * 1024 * 1024
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 64 * 1024
+	}
+
+	rf := &RotatingFile{config: config, now: time.Now}
+	if err := rf.openLocked(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Write appends p to the file, rotating first if p would exceed MaxSize.
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return 0, os.ErrClosed
+	}
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.config.MaxSize {
+		if err := rf.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.buf.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Flush writes any buffered data to the underlying file.
+func (rf *RotatingFile) Flush() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return nil
+	}
+	return rf.buf.Flush()
+}
+
+// Close flushes buffered data and closes the file.
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return nil
+	}
+	rf.closed = true
+
+	flushErr := rf.buf.Flush()
+	closeErr := rf.file.Close()
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
+
+func (rf *RotatingFile) openLocked() error {
+	if err := os.MkdirAll(filepath.Dir(rf.config.Path), 0o750); err != nil {
+		return fmt.Errorf("observe: failed to create log directory: %w", err)
+	}
+
+	// #nosec G304 -- path is operator-supplied configuration.
+	f, err := os.OpenFile(rf.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("observe: failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("observe: failed to stat log file: %w", err)
+	}
+
+	rf.file = f
+	rf.size = info.Size()
+	if rf.buf == nil {
+		rf.buf = bufio.NewWriterSize(f, rf.config.BufferSize)
+	} else {
+		rf.buf.Reset(f)
+	}
+	return nil
+}
+
+// rotateLocked moves the current file to a backup and opens a new one.
+// It returns an error only when no file is left open for writing; if the
+// backup cannot be created, the unrotated file is reopened instead.
+func (rf *RotatingFile) rotateLocked() error {
+	if err := rf.buf.Flush(); err != nil {
+		return err
+	}
+
+	rotated := rf.file.Close() == nil &&
+		os.Rename(rf.config.Path, rf.backupName(rf.now())) == nil
+
+	// Reopen Path: a fresh file after a rename, or the unrotated file
+	// otherwise, so that rf.buf never wraps a closed handle
+	if err := rf.openLocked(); err != nil {
+		return err
+	}
+
+	if rotated {
+		rf.pruneLocked()
+	}
+	return nil
+}
+
+func (rf *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := rf.nameParts()
+	return filepath.Join(dir, prefix+t.UTC().Format(backupTimeFormat)+ext)
+}
+
+func (rf *RotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(rf.config.Path)
+	base := filepath.Base(rf.config.Path)
+	ext = filepath.Ext(base)
+	prefix = strings.TrimSuffix(base, ext) + "-"
+	return dir, prefix, ext
+}
+
+// Backups returns the paths of rotated files, oldest first.
+func (rf *RotatingFile) Backups() ([]string, error) {
+	dir, prefix, ext := rf.nameParts()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// pruneLocked removes backups beyond MaxBackups or older than MaxAge.
+// Pruning is best-effort; failures do not interrupt logging.
+func (rf *RotatingFile) pruneLocked() {
+	if rf.config.MaxBackups <= 0 && rf.config.MaxAge <= 0 {
+		return
+	}
+
+	backups, err := rf.Backups()
+	if err != nil {
+		return
+	}
+
+	_, prefix, ext := rf.nameParts()
+	cutoff := time.Now().Add(-rf.config.MaxAge)
+	keepFrom := 0
+	if rf.config.MaxBackups > 0 && len(backups) > rf.config.MaxBackups {
+		keepFrom = len(backups) - rf.config.MaxBackups
+	}
+
+	for i, path := range backups {
+		remove := i < keepFrom
+		if !remove && rf.config.MaxAge > 0 {
+			stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), prefix), ext)
+			if t, err := time.Parse(backupTimeFormat, stamp); err == nil && t.Before(cutoff) {
+				remove = true
+			}
+		}
+		if remove {
+			_ = os.Remove(path)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package observe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRotatingFile_RotatesOnSize verifies the file rotates at the size threshold.
+func TestRotatingFile_RotatesOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rf, err := NewRotatingFile(RotatingFileConfig{Path: path, MaxSize: 100})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+
+	line := []byte(strings.Repeat("x", 39) + "\n") // 40 bytes
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write(line); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	backups, err := rf.Backups()
+	if err != nil {
+		t.Fatalf("Backups() error = %v", err)
+	}
+	// 5 lines of 40 bytes with a 100 byte limit: [2][2][1]
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d: %v", len(backups), backups)
+	}
+	for _, b := range backups {
+		data, err := os.ReadFile(b)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", b, err)
+		}
+		if len(data) > 100 {
+			t.Errorf("backup %s has %d bytes, want <= 100", b, len(data))
+		}
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(current, line) {
+		t.Errorf("current file = %q, want single line", current)
+	}
+}
+
+// TestRotatingFile_RenameFailureKeepsWriting verifies a failed rotation
+// leaves the file writable instead of wrapping a closed handle.
+func TestRotatingFile_RenameFailureKeepsWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rf, err := NewRotatingFile(RotatingFileConfig{Path: path, MaxSize: 100})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+
+	// A non-empty directory at the backup name makes the rename fail
+	stamp := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	rf.now = func() time.Time { return stamp }
+	blocker := rf.backupName(stamp)
+	if err := os.MkdirAll(filepath.Join(blocker, "occupied"), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	line := []byte(strings.Repeat("x", 39) + "\n") // 40 bytes
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write(line); err != nil {
+			t.Fatalf("Write() %d error = %v", i, err)
+		}
+	}
+	if err := rf.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(current, bytes.Repeat(line, 5)) {
+		t.Errorf("current file has %d bytes, want all 5 lines (%d bytes)", len(current), 5*len(line))
+	}
+
+	// Once the backup name is free, rotation succeeds again
+	if err := os.RemoveAll(blocker); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+	if _, err := rf.Write(line); err != nil {
+		t.Fatalf("Write() after unblocking error = %v", err)
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	backups, err := rf.Backups()
+	if err != nil {
+		t.Fatalf("Backups() error = %v", err)
+	}
+	if len(backups) != 1 {
+		t.Errorf("expected 1 backup after rotation resumes, got %d: %v", len(backups), backups)
+	}
+}
+
+// TestRotatingFile_MaxBackups verifies old backups are pruned by count.
+func TestRotatingFile_MaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rf, err := NewRotatingFile(RotatingFileConfig{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	defer func() { _ = rf.Close() }()
+
+	for i := 0; i < 6; i++ {
+		if _, err := rf.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	backups, err := rf.Backups()
+	if err != nil {
+		t.Fatalf("Backups() error = %v", err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("expected 2 backups retained, got %d", len(backups))
+	}
+}
+
+// TestRotatingFile_MaxAge verifies expired backups are pruned on rotation.
+func TestRotatingFile_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	stale := filepath.Join(dir, "app-"+time.Now().Add(-48*time.Hour).UTC().Format(backupTimeFormat)+".log")
+	if err := os.WriteFile(stale, []byte("old"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	rf, err := NewRotatingFile(RotatingFileConfig{Path: path, MaxSize: 10, MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+	defer func() { _ = rf.Close() }()
+
+	for i := 0; i < 2; i++ {
+		if _, err := rf.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expected stale backup to be removed")
+	}
+}
+
+// TestRotatingFile_BufferedUntilFlush verifies writes are buffered.
+func TestRotatingFile_BufferedUntilFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rf, err := NewRotatingFile(RotatingFileConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error = %v", err)
+	}
+
+	if _, err := rf.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Errorf("expected no data before flush, got %q", data)
+	}
+
+	if err := rf.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "hello\n" {
+		t.Errorf("expected data after flush, got %q", data)
+	}
+
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := rf.Close(); err != nil {
+		t.Errorf("second Close() error = %v, want nil", err)
+	}
+	if _, err := rf.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write after Close error = %v, want os.ErrClosed", err)
+	}
+}
+
+// TestObserver_LogFile verifies file logging is flushed on Shutdown.
+func TestObserver_LogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "observe.log")
+	ctx := context.Background()
+
+	obs, err := NewObserver(ctx, Config{
+		ServiceName: "test-service",
+		Logging: LoggingConfig{
+			Enabled: true,
+			Level:   "info",
+			File:    LogFileConfig{Path: path, MaxSizeMB: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+
+	obs.Logger().Info(ctx, "written to file")
+
+	if err := obs.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), "written to file") {
+		t.Errorf("log file missing entry: %q", data)
+	}
+}
+
+// TestObserver_LogRedactFields verifies LoggingConfig redaction settings
+// reach the logger.
+func TestObserver_LogRedactFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observe.log")
+	ctx := context.Background()
+
+	obs, err := NewObserver(ctx, Config{
+		ServiceName: "test-service",
+		Logging: LoggingConfig{
+			Enabled:                 true,
+			Level:                   "info",
+			File:                    LogFileConfig{Path: path},
+			RedactFields:            []string{"ssn"},
+			DisableDefaultRedaction: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+
+	obs.Logger().Info(ctx, "user", Field{Key: "SSN", Value: "123-45-6789"}, Field{Key: "token", Value: "public"})
+
+	if err := obs.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), "123-45-6789") {
+		t.Errorf("log file contains redacted value: %q", data)
+	}
+	if !strings.Contains(string(data), `"token":"public"`) {
+		t.Errorf("token should be logged with default redaction disabled: %q", data)
+	}
+}
+
+// TestConfig_Validate_InvalidLogFile verifies negative rotation limits are rejected.
+func TestConfig_Validate_InvalidLogFile(t *testing.T) {
+	cfg := Config{
+		ServiceName: "test-service",
+		Logging: LoggingConfig{
+			Enabled: true,
+			Level:   "info",
+			File:    LogFileConfig{Path: "x.log", MaxBackups: -1},
+		},
+	}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidLogFile) {
+		t.Errorf("Validate() error = %v, want ErrInvalidLogFile", err)
+	}
+}
@@ -2,6 +2,7 @@ package observe
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -123,6 +124,60 @@ func (t *tracerImpl) EndSpan(span trace.Span, err error) {
 	span.End()
 }
 
+// Span event names recorded by RecordRetryAttempt, RecordCacheHit, and
+// RecordCacheMiss.
+const (
+	SpanEventRetryAttempt = "retry.attempt"
+	SpanEventCacheHit     = "cache.hit"
+	SpanEventCacheMiss    = "cache.miss"
+)
+
+// RecordRetryAttempt adds a "retry.attempt" event to the span in ctx with
+// the attempt number, the delay before it, and the error that caused it,
+// so retries explain latency in the trace. It fits RetryConfig.OnRetry in
+// the resilience package when the closure has the call's context:
+//
+//	OnRetry: func(attempt int, err error, delay time.Duration) {
+//	    observe.RecordRetryAttempt(ctx, attempt, delay, err)
+//	}
+//
+// It does nothing if ctx carries no recording span.
+func RecordRetryAttempt(ctx context.Context, attempt int, delay time.Duration, err error) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Int("retry.attempt", attempt),
+		attribute.Float64("retry.delay_ms", float64(delay)/float64(time.Millisecond)),
+	}
+	if err != nil {
+		attrs = append(attrs, attribute.String("retry.error", err.Error()))
+	}
+	span.AddEvent(SpanEventRetryAttempt, trace.WithAttributes(attrs...))
+}
+
+// RecordCacheHit adds a "cache.hit" event with the cache key to the span in
+// ctx. It does nothing if ctx carries no recording span.
+func RecordCacheHit(ctx context.Context, key string) {
+	addCacheEvent(ctx, SpanEventCacheHit, key)
+}
+
+// RecordCacheMiss adds a "cache.miss" event with the cache key to the span
+// in ctx. It does nothing if ctx carries no recording span.
+func RecordCacheMiss(ctx context.Context, key string) {
+	addCacheEvent(ctx, SpanEventCacheMiss, key)
+}
+
+// addCacheEvent records a cache lookup event on the span in ctx.
+func addCacheEvent(ctx context.Context, name, key string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent(name, trace.WithAttributes(attribute.String("cache.key", key)))
+}
+
 // noopTracer is a tracer that does nothing.
 type noopTracer struct {
 	noop trace.Tracer
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -258,3 +259,71 @@ func TestTracer_ErrorRecording(t *testing.T) {
 		t.Error("expected tool.error=true")
 	}
 }
+
+// TestRecordSpanEvents verifies retry and cache events appear on the span
+// in the context with their attributes.
+func TestRecordSpanEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	tr := &tracerImpl{tracer: tp.Tracer("test")}
+	ctx, span := tr.StartSpan(context.Background(), ToolMeta{Name: "search"})
+
+	RecordCacheMiss(ctx, "search:abc123")
+	RecordRetryAttempt(ctx, 1, 250*time.Millisecond, errors.New("connection reset"))
+	RecordRetryAttempt(ctx, 2, 500*time.Millisecond, nil)
+	RecordCacheHit(ctx, "search:abc123")
+	tr.EndSpan(span, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	events := spans[0].Events()
+
+	want := []struct {
+		name  string
+		attrs map[attribute.Key]attribute.Value
+	}{
+		{SpanEventCacheMiss, map[attribute.Key]attribute.Value{
+			"cache.key": attribute.StringValue("search:abc123"),
+		}},
+		{SpanEventRetryAttempt, map[attribute.Key]attribute.Value{
+			"retry.attempt":  attribute.IntValue(1),
+			"retry.delay_ms": attribute.Float64Value(250),
+			"retry.error":    attribute.StringValue("connection reset"),
+		}},
+		{SpanEventRetryAttempt, map[attribute.Key]attribute.Value{
+			"retry.attempt":  attribute.IntValue(2),
+			"retry.delay_ms": attribute.Float64Value(500),
+		}},
+		{SpanEventCacheHit, map[attribute.Key]attribute.Value{
+			"cache.key": attribute.StringValue("search:abc123"),
+		}},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.Name != w.name {
+			t.Errorf("event %d name = %q, want %q", i, got.Name, w.name)
+		}
+		if len(got.Attributes) != len(w.attrs) {
+			t.Errorf("event %d attributes = %v, want %v", i, got.Attributes, w.attrs)
+		}
+		for _, kv := range got.Attributes {
+			if wantVal, ok := w.attrs[kv.Key]; !ok || wantVal != kv.Value {
+				t.Errorf("event %d attribute %s = %v, want %v", i, kv.Key, kv.Value.Emit(), wantVal.Emit())
+			}
+		}
+	}
+}
+
+// TestRecordSpanEvents_NoSpan verifies the helpers are safe without a span.
+func TestRecordSpanEvents_NoSpan(t *testing.T) {
+	ctx := context.Background()
+	RecordRetryAttempt(ctx, 1, time.Second, errors.New("x"))
+	RecordCacheHit(ctx, "k")
+	RecordCacheMiss(ctx, "k")
+}
@@ -0,0 +1,164 @@
+package resilience
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// latencySmoothing is the weight given to each new sample in the latency
+// moving average reported by AdaptiveBulkhead.Metrics.
+const latencySmoothing = 0.2
+
+// AdaptiveBulkheadConfig configures an AdaptiveBulkhead.
+type AdaptiveBulkheadConfig struct {
+	// MinLimit is the lowest the concurrency limit may shrink to.
+	// Default: 1
+	MinLimit int
+
+	// MaxLimit is the highest the concurrency limit may grow to.
+	// Default: 100
+	MaxLimit int
+
+	// InitialLimit is the concurrency limit before any latency is observed,
+	// clamped to [MinLimit, MaxLimit].
+	// Default: MaxLimit
+	InitialLimit int
+
+	// TargetLatency is the latency considered healthy. Operations slower
+	// than this shrink the limit; faster ones grow it.
+	// Default: 100ms
+	TargetLatency time.Duration
+
+	// Backoff is the factor the limit is multiplied by after a slow
+	// operation. Must be in (0, 1).
+	// Default: 0.9
+	Backoff float64
+}
+
+// AdaptiveBulkhead limits concurrent operations like Bulkhead, but tunes its
+// limit from observed latency using additive-increase/multiplicative-decrease
+// (AIMD): each operation slower than TargetLatency multiplies the limit by
+// Backoff, and each faster one adds 1/limit, growing the limit by about one
+// slot per limit's worth of healthy operations.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Admission: Operations beyond the current limit are rejected with
+//     ErrBulkheadFull; there is no waiting.
+//   - Shrinking: Lowering the limit never cancels running operations; new
+//     ones are rejected until active work drains below the limit.
+type AdaptiveBulkhead struct {
+	config AdaptiveBulkheadConfig
+
+	mu       sync.Mutex
+	limit    float64
+	active   int
+	latency  time.Duration // Exponential moving average
+	rejected int64
+
+	now func() time.Time // Injectable for tests
+}
+
+// NewAdaptiveBulkhead creates an adaptive bulkhead.
+func NewAdaptiveBulkhead(config AdaptiveBulkheadConfig) *AdaptiveBulkhead {
+	// Apply defaults
+	if config.MinLimit <= 0 {
+		config.MinLimit = 1
+	}
+	if config.MaxLimit <= 0 {
+		config.MaxLimit = 100
+	}
+	if config.MaxLimit < config.MinLimit {
+		config.MaxLimit = config.MinLimit
+	}
+	if config.InitialLimit <= 0 {
+		config.InitialLimit = config.MaxLimit
+	}
+	config.InitialLimit = min(max(config.InitialLimit, config.MinLimit), config.MaxLimit)
+	if config.TargetLatency <= 0 {
+		config.TargetLatency = 100 * time.Millisecond
+	}
+	if config.Backoff <= 0 || config.Backoff >= 1 {
+		config.Backoff = 0.9
+	}
+
+	return &AdaptiveBulkhead{
+		config: config,
+		limit:  float64(config.InitialLimit),
+		now:    time.Now,
+	}
+}
+
+// Execute runs the operation if the current limit allows, recording its
+// latency to adjust the limit. Returns ErrBulkheadFull without calling op
+// if the bulkhead is at its limit.
+func (b *AdaptiveBulkhead) Execute(ctx context.Context, op func(context.Context) error) error {
+	b.mu.Lock()
+	if b.active >= b.currentLimitLocked() {
+		b.rejected++
+		b.mu.Unlock()
+		return ErrBulkheadFull
+	}
+	b.active++
+	b.mu.Unlock()
+
+	start := b.now()
+	defer func() {
+		b.record(b.now().Sub(start))
+	}()
+
+	return op(ctx)
+}
+
+// record releases a slot and adjusts the limit for one latency sample.
+func (b *AdaptiveBulkhead) record(latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.active--
+
+	if b.latency == 0 {
+		b.latency = latency
+	} else {
+		b.latency += time.Duration(latencySmoothing * float64(latency-b.latency))
+	}
+
+	if latency > b.config.TargetLatency {
+		b.limit *= b.config.Backoff
+	} else {
+		b.limit += 1 / b.limit
+	}
+	b.limit = min(max(b.limit, float64(b.config.MinLimit)), float64(b.config.MaxLimit))
+}
+
+// currentLimitLocked returns the whole-slot limit. Caller must hold b.mu.
+func (b *AdaptiveBulkhead) currentLimitLocked() int {
+	return int(math.Floor(b.limit))
+}
+
+// Metrics returns current adaptive bulkhead metrics.
+func (b *AdaptiveBulkhead) Metrics() AdaptiveBulkheadMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return AdaptiveBulkheadMetrics{
+		Active:   b.active,
+		Limit:    b.currentLimitLocked(),
+		Latency:  b.latency,
+		Rejected: b.rejected,
+	}
+}
+
+// AdaptiveBulkheadMetrics contains adaptive bulkhead statistics.
+type AdaptiveBulkheadMetrics struct {
+	// Active is the number of operations running.
+	Active int
+	// Limit is the current concurrency limit.
+	Limit int
+	// Latency is a moving average of recent operation latency.
+	Latency time.Duration
+	// Rejected counts operations refused at the limit.
+	Rejected int64
+}
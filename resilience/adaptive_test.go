@@ -0,0 +1,173 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for latency-driven tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func newTestAdaptiveBulkhead(config AdaptiveBulkheadConfig) (*AdaptiveBulkhead, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := NewAdaptiveBulkhead(config)
+	b.now = clock.Now
+	return b, clock
+}
+
+// runWithLatency executes one operation that takes latency on clock.
+func runWithLatency(t *testing.T, b *AdaptiveBulkhead, clock *fakeClock, latency time.Duration) {
+	t.Helper()
+	err := b.Execute(context.Background(), func(ctx context.Context) error {
+		clock.Advance(latency)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+}
+
+func TestNewAdaptiveBulkhead_Defaults(t *testing.T) {
+	b := NewAdaptiveBulkhead(AdaptiveBulkheadConfig{})
+
+	if b.config.MinLimit != 1 {
+		t.Errorf("MinLimit = %d, want 1", b.config.MinLimit)
+	}
+	if b.config.MaxLimit != 100 {
+		t.Errorf("MaxLimit = %d, want 100", b.config.MaxLimit)
+	}
+	if b.config.TargetLatency != 100*time.Millisecond {
+		t.Errorf("TargetLatency = %v, want 100ms", b.config.TargetLatency)
+	}
+	if b.config.Backoff != 0.9 {
+		t.Errorf("Backoff = %v, want 0.9", b.config.Backoff)
+	}
+	if got := b.Metrics().Limit; got != 100 {
+		t.Errorf("Limit = %d, want InitialLimit default of MaxLimit (100)", got)
+	}
+}
+
+func TestAdaptiveBulkhead_RisingLatencyShrinksLimit(t *testing.T) {
+	b, clock := newTestAdaptiveBulkhead(AdaptiveBulkheadConfig{
+		MinLimit:      2,
+		MaxLimit:      20,
+		TargetLatency: 50 * time.Millisecond,
+		Backoff:       0.5,
+	})
+
+	prev := b.Metrics().Limit
+	for i := 0; i < 3; i++ {
+		runWithLatency(t, b, clock, 200*time.Millisecond)
+		got := b.Metrics().Limit
+		if got >= prev {
+			t.Fatalf("slow op %d: Limit = %d, want below %d", i, got, prev)
+		}
+		prev = got
+	}
+
+	// Never shrinks below MinLimit
+	for i := 0; i < 10; i++ {
+		runWithLatency(t, b, clock, 200*time.Millisecond)
+	}
+	m := b.Metrics()
+	if m.Limit != 2 {
+		t.Errorf("Limit = %d, want MinLimit (2)", m.Limit)
+	}
+	if m.Latency < 150*time.Millisecond {
+		t.Errorf("Latency = %v, want close to 200ms", m.Latency)
+	}
+}
+
+func TestAdaptiveBulkhead_RecoveryGrowsLimit(t *testing.T) {
+	b, clock := newTestAdaptiveBulkhead(AdaptiveBulkheadConfig{
+		MinLimit:      1,
+		MaxLimit:      10,
+		InitialLimit:  1,
+		TargetLatency: 50 * time.Millisecond,
+	})
+
+	for i := 0; i < 100; i++ {
+		runWithLatency(t, b, clock, 10*time.Millisecond)
+	}
+
+	m := b.Metrics()
+	if m.Limit < 5 {
+		t.Errorf("Limit = %d after healthy latency, want grown toward MaxLimit", m.Limit)
+	}
+	if m.Latency != 10*time.Millisecond {
+		t.Errorf("Latency = %v, want 10ms", m.Latency)
+	}
+
+	// Never grows past MaxLimit
+	for i := 0; i < 1000; i++ {
+		runWithLatency(t, b, clock, time.Millisecond)
+	}
+	if got := b.Metrics().Limit; got != 10 {
+		t.Errorf("Limit = %d, want MaxLimit (10)", got)
+	}
+}
+
+func TestAdaptiveBulkhead_RejectsAtLimit(t *testing.T) {
+	b := NewAdaptiveBulkhead(AdaptiveBulkheadConfig{MaxLimit: 2})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = b.Execute(context.Background(), func(ctx context.Context) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	err := b.Execute(context.Background(), func(ctx context.Context) error { return nil })
+	if !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("Execute() error = %v, want ErrBulkheadFull", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	m := b.Metrics()
+	if m.Active != 0 {
+		t.Errorf("Active = %d, want 0", m.Active)
+	}
+	if m.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", m.Rejected)
+	}
+}
+
+func TestAdaptiveBulkhead_PropagatesError(t *testing.T) {
+	b := NewAdaptiveBulkhead(AdaptiveBulkheadConfig{})
+	errOp := errors.New("op failed")
+
+	err := b.Execute(context.Background(), func(ctx context.Context) error { return errOp })
+	if !errors.Is(err, errOp) {
+		t.Errorf("Execute() error = %v, want %v", err, errOp)
+	}
+}
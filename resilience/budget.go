@@ -0,0 +1,95 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryBudgetConfig configures a RetryBudget.
+type RetryBudgetConfig struct {
+	// Ratio is the maximum number of retries allowed per request within
+	// Window (e.g., 0.1 allows one retry for every ten requests).
+	// Default: 0.1
+	Ratio float64
+
+	// Window is the rolling window over which requests and retries are
+	// counted, tracked in 10 buckets.
+	// Default: 10 seconds
+	Window time.Duration
+
+	// MinRetries is the number of retries always allowed within Window,
+	// regardless of Ratio, so low-traffic callers can still retry.
+	// Default: 10
+	MinRetries int
+}
+
+// RetryBudget caps retries as a fraction of requests across every Retry
+// that shares it. During a broad outage, when most requests fail, the
+// budget runs out and further retries fail fast instead of multiplying
+// load on the struggling backend.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use by many Retry.Execute calls.
+type RetryBudget struct {
+	config RetryBudgetConfig
+
+	mu     sync.Mutex
+	window *rollingWindow // "failures" count retries; "requests" count both
+}
+
+// NewRetryBudget creates a new retry budget.
+func NewRetryBudget(config RetryBudgetConfig) *RetryBudget {
+	if config.Ratio <= 0 {
+		config.Ratio = 0.1
+	}
+	if config.Window <= 0 {
+		config.Window = 10 * time.Second
+	}
+	if config.MinRetries <= 0 {
+		config.MinRetries = 10
+	}
+	return &RetryBudget{
+		config: config,
+		window: newRollingWindow(config.Window, rollingBuckets),
+	}
+}
+
+// recordRequest counts an initial attempt toward the budget.
+func (b *RetryBudget) recordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.window.record(time.Now(), false)
+}
+
+// tryRetry reports whether a retry fits in the budget, counting it if so.
+func (b *RetryBudget) tryRetry() bool {
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	total, retries := b.window.counts(now)
+	requests := total - retries
+	if retries >= b.config.MinRetries && float64(retries+1) > b.config.Ratio*float64(requests) {
+		return false
+	}
+	b.window.record(now, true)
+	return true
+}
+
+// Metrics returns the request and retry counts in the current window.
+func (b *RetryBudget) Metrics() RetryBudgetMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	total, retries := b.window.counts(time.Now())
+	return RetryBudgetMetrics{Requests: total - retries, Retries: retries}
+}
+
+// RetryBudgetMetrics contains retry budget statistics.
+type RetryBudgetMetrics struct {
+	// Requests counts initial attempts in the current window.
+	Requests int
+	// Retries counts retries granted in the current window.
+	Retries int
+}
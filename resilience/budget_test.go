@@ -0,0 +1,100 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget_AllowsRetriesUnderNormalLoad(t *testing.T) {
+	budget := NewRetryBudget(RetryBudgetConfig{Ratio: 0.2, Window: time.Minute, MinRetries: 1})
+	r := NewRetry(RetryConfig{MaxAttempts: 2, InitialDelay: time.Microsecond, Budget: budget})
+	ctx := context.Background()
+	errTransient := errors.New("transient")
+
+	// 100 requests, 1 in 10 failing once: 10% retries under a 20% budget
+	for i := 0; i < 100; i++ {
+		failed := false
+		err := r.Execute(ctx, func(ctx context.Context) error {
+			if i%10 == 0 && !failed {
+				failed = true
+				return errTransient
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("request %d: Execute() error = %v", i, err)
+		}
+	}
+
+	if m := budget.Metrics(); m.Requests != 100 || m.Retries != 10 {
+		t.Errorf("Metrics() = %+v, want 100 requests, 10 retries", m)
+	}
+}
+
+func TestRetryBudget_SuppressesRetriesWhenExhausted(t *testing.T) {
+	budget := NewRetryBudget(RetryBudgetConfig{Ratio: 0.1, Window: time.Minute, MinRetries: 2})
+	r := NewRetry(RetryConfig{MaxAttempts: 3, InitialDelay: time.Microsecond, Budget: budget})
+	ctx := context.Background()
+	errOutage := errors.New("backend down")
+
+	calls := 0
+	exhausted := 0
+	for i := 0; i < 20; i++ {
+		err := r.Execute(ctx, func(ctx context.Context) error {
+			calls++
+			return errOutage
+		})
+		if errors.Is(err, ErrRetryBudgetExhausted) {
+			exhausted++
+			if !errors.Is(err, errOutage) {
+				t.Errorf("Execute() error = %v, want it to wrap the last error", err)
+			}
+		}
+	}
+
+	// 20 requests at 10% allows 2 retries (also the MinRetries floor)
+	if m := budget.Metrics(); m.Retries != 2 {
+		t.Errorf("Retries = %d, want 2", m.Retries)
+	}
+	if calls != 22 {
+		t.Errorf("operation calls = %d, want 22 (20 requests + 2 retries)", calls)
+	}
+	if exhausted < 18 {
+		t.Errorf("exhausted results = %d, want at least 18", exhausted)
+	}
+}
+
+func TestRetryBudget_Concurrent(t *testing.T) {
+	budget := NewRetryBudget(RetryBudgetConfig{Ratio: 0.5, Window: time.Minute, MinRetries: 1})
+	r := NewRetry(RetryConfig{MaxAttempts: 2, InitialDelay: time.Microsecond, Budget: budget})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = r.Execute(context.Background(), func(ctx context.Context) error {
+				return errors.New("fail")
+			})
+		}()
+	}
+	wg.Wait()
+
+	m := budget.Metrics()
+	if m.Requests != 100 {
+		t.Errorf("Requests = %d, want 100", m.Requests)
+	}
+	if m.Retries > 50 {
+		t.Errorf("Retries = %d, want at most 50 (ratio 0.5)", m.Retries)
+	}
+}
+
+func TestNewRetryBudget_Defaults(t *testing.T) {
+	budget := NewRetryBudget(RetryBudgetConfig{})
+	if budget.config.Ratio != 0.1 || budget.config.Window != 10*time.Second || budget.config.MinRetries != 10 {
+		t.Errorf("config = %+v, want defaults", budget.config)
+	}
+}
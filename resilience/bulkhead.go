@@ -26,6 +26,10 @@ type Bulkhead struct {
 	active    int
 	maxActive int
 	rejected  int64
+	queued    int
+	waits     int64
+	lastWait  time.Duration
+	totalWait time.Duration
 }
 
 // NewBulkhead creates a new bulkhead.
@@ -70,9 +74,15 @@ func (b *Bulkhead) Acquire(ctx context.Context) error {
 	timer := time.NewTimer(b.config.MaxWait)
 	defer timer.Stop()
 
+	b.mu.Lock()
+	b.queued++
+	b.mu.Unlock()
+	start := time.Now()
+
 	select {
 	case b.sem <- struct{}{}:
 		b.mu.Lock()
+		b.dequeueLocked(start)
 		b.active++
 		if b.active > b.maxActive {
 			b.maxActive = b.active
@@ -81,14 +91,28 @@ func (b *Bulkhead) Acquire(ctx context.Context) error {
 		return nil
 	case <-timer.C:
 		b.mu.Lock()
+		b.dequeueLocked(start)
 		b.rejected++
 		b.mu.Unlock()
 		return ErrBulkheadFull
 	case <-ctx.Done():
+		b.mu.Lock()
+		b.dequeueLocked(start)
+		b.mu.Unlock()
 		return ctx.Err()
 	}
 }
 
+// dequeueLocked records the end of a wait that began at start, whatever
+// its outcome. Caller must hold b.mu.
+func (b *Bulkhead) dequeueLocked(start time.Time) {
+	wait := time.Since(start)
+	b.queued--
+	b.waits++
+	b.lastWait = wait
+	b.totalWait += wait
+}
+
 // Release releases a slot in the bulkhead.
 func (b *Bulkhead) Release() {
 	select {
@@ -122,14 +146,32 @@ func (b *Bulkhead) Metrics() BulkheadMetrics {
 		Available:     b.config.MaxConcurrent - b.active,
 		MaxConcurrent: b.config.MaxConcurrent,
 		Rejected:      b.rejected,
+		QueuedWaiters: b.queued,
+		Waits:         b.waits,
+		LastWait:      b.lastWait,
+		TotalWait:     b.totalWait,
 	}
 }
 
 // BulkheadMetrics contains bulkhead statistics.
+//
+// A persistent QueuedWaiters backlog or a rising average wait
+// (TotalWait / Waits) means the bulkhead is saturated.
 type BulkheadMetrics struct {
 	Active        int
 	MaxActive     int
 	Available     int
 	MaxConcurrent int
 	Rejected      int64
+
+	// QueuedWaiters is the number of callers waiting for a slot under
+	// MaxWait.
+	QueuedWaiters int
+	// Waits counts callers that queued for a slot, whether they got one,
+	// timed out, or gave up.
+	Waits int64
+	// LastWait is how long the most recent queued caller waited.
+	LastWait time.Duration
+	// TotalWait is the cumulative time queued callers have waited.
+	TotalWait time.Duration
 }
@@ -224,3 +224,95 @@ func TestBulkhead_Metrics(t *testing.T) {
 		t.Errorf("Metrics.Rejected = %d, want 1", b2Metrics.Rejected)
 	}
 }
+
+func TestBulkhead_QueueMetrics(t *testing.T) {
+	b := NewBulkhead(BulkheadConfig{
+		MaxConcurrent: 2,
+		MaxWait:       time.Second,
+	})
+	ctx := context.Background()
+
+	// Fill the bulkhead
+	for i := 0; i < 2; i++ {
+		if err := b.Acquire(ctx); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+
+	// Queue three more acquirers
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.Acquire(ctx); err != nil {
+				t.Errorf("queued Acquire() error = %v", err)
+				return
+			}
+			b.Release()
+		}()
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for b.Metrics().QueuedWaiters != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("QueuedWaiters = %d, want 3", b.Metrics().QueuedWaiters)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	b.Release()
+	b.Release()
+	wg.Wait()
+
+	m := b.Metrics()
+	if m.QueuedWaiters != 0 {
+		t.Errorf("QueuedWaiters = %d after drain, want 0", m.QueuedWaiters)
+	}
+	if m.Waits != 3 {
+		t.Errorf("Waits = %d, want 3", m.Waits)
+	}
+	if m.LastWait <= 0 {
+		t.Errorf("LastWait = %v, want > 0", m.LastWait)
+	}
+	if m.TotalWait < 3*20*time.Millisecond {
+		t.Errorf("TotalWait = %v, want at least 60ms across three waiters", m.TotalWait)
+	}
+}
+
+func TestBulkhead_QueueMetricsTimeout(t *testing.T) {
+	b := NewBulkhead(BulkheadConfig{
+		MaxConcurrent: 1,
+		MaxWait:       10 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	_ = b.Acquire(ctx)
+	if err := b.Acquire(ctx); err != ErrBulkheadFull {
+		t.Fatalf("Acquire() error = %v, want ErrBulkheadFull", err)
+	}
+
+	m := b.Metrics()
+	if m.QueuedWaiters != 0 {
+		t.Errorf("QueuedWaiters = %d after timeout, want 0", m.QueuedWaiters)
+	}
+	if m.Waits != 1 {
+		t.Errorf("Waits = %d, want 1", m.Waits)
+	}
+	if m.LastWait < 10*time.Millisecond {
+		t.Errorf("LastWait = %v, want at least MaxWait", m.LastWait)
+	}
+}
+
+func TestBulkhead_NoQueueWithoutMaxWait(t *testing.T) {
+	b := NewBulkhead(BulkheadConfig{MaxConcurrent: 1})
+	ctx := context.Background()
+
+	_ = b.Acquire(ctx)
+	_ = b.Acquire(ctx)
+
+	if m := b.Metrics(); m.Waits != 0 || m.QueuedWaiters != 0 {
+		t.Errorf("Metrics() = %+v, want no queueing without MaxWait", m)
+	}
+}
@@ -49,14 +49,44 @@ type CircuitBreakerConfig struct {
 	// OnStateChange is called when the circuit state changes.
 	OnStateChange func(from, to State)
 
-	// IsFailure determines if an error should count as a failure.
+	// IsFailure determines if an error should count as a failure. When
+	// set, it takes precedence over an Executor's WithFailureClassifier.
 	// Default: all non-nil errors are failures.
 	IsFailure func(err error) bool
+
+	// FailureWindow bounds how long a failure counts toward MaxFailures.
+	// Failures older than the window are forgotten when evaluating the
+	// threshold, so sporadic failures spread over a long-running process
+	// never accumulate into a trip.
+	// Default: 0 (failures never decay)
+	FailureWindow time.Duration
+
+	// FailureRateThreshold opens the circuit when the fraction of failed
+	// requests within RollingWindow reaches it (0.0–1.0), instead of after
+	// MaxFailures consecutive failures. Suits services that fail
+	// intermittently, where consecutive counts are noisy. Requires
+	// RollingWindow; MaxFailures and FailureWindow are then ignored.
+	// Default: 0 (consecutive-failure mode)
+	FailureRateThreshold float64
+
+	// RollingWindow is the sliding window over which FailureRateThreshold
+	// is evaluated, tracked in 10 buckets.
+	// Default: 0 (consecutive-failure mode)
+	RollingWindow time.Duration
+
+	// MinRequests is the number of requests the window must hold before
+	// the failure rate is evaluated, so one early failure cannot open it.
+	// Default: 10 (rate mode only)
+	MinRequests int
 }
 
+// rollingBuckets is the number of buckets a rolling window is split into.
+const rollingBuckets = 10
+
 // CircuitBreaker implements the circuit breaker pattern.
 type CircuitBreaker struct {
-	config CircuitBreakerConfig
+	config          CircuitBreakerConfig
+	customIsFailure bool // IsFailure was set by the caller
 
 	mu            sync.Mutex
 	state         State
@@ -64,6 +94,9 @@ type CircuitBreaker struct {
 	successes     int
 	lastFailure   time.Time
 	halfOpenCount int
+	forced        bool           // state pinned by ForceOpen or ForceClose
+	failureTimes  []time.Time    // only tracked when FailureWindow > 0
+	window        *rollingWindow // nil unless rate mode is configured
 }
 
 // NewCircuitBreaker creates a new circuit breaker.
@@ -78,27 +111,51 @@ func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
 	if config.HalfOpenMaxRequests <= 0 {
 		config.HalfOpenMaxRequests = 1
 	}
+	customIsFailure := config.IsFailure != nil
 	if config.IsFailure == nil {
 		config.IsFailure = func(err error) bool { return err != nil }
 	}
 
-	return &CircuitBreaker{
-		config: config,
-		state:  StateClosed,
+	cb := &CircuitBreaker{
+		config:          config,
+		customIsFailure: customIsFailure,
+		state:           StateClosed,
 	}
+	if config.FailureRateThreshold > 0 && config.RollingWindow > 0 {
+		if cb.config.MinRequests <= 0 {
+			cb.config.MinRequests = 10
+		}
+		cb.window = newRollingWindow(config.RollingWindow, rollingBuckets)
+	}
+	return cb
 }
 
 // Execute runs the operation through the circuit breaker.
 func (cb *CircuitBreaker) Execute(ctx context.Context, op func(context.Context) error) error {
+	return cb.execute(ctx, op, cb.config.IsFailure)
+}
+
+// execute runs op, classifying its error with isFailure.
+func (cb *CircuitBreaker) execute(ctx context.Context, op func(context.Context) error, isFailure func(error) bool) error {
 	if err := cb.beforeRequest(); err != nil {
 		return err
 	}
 
 	err := op(ctx)
-	cb.afterRequest(err)
+	cb.afterRequest(isFailure(err))
 	return err
 }
 
+// isFailure returns the classifier for an Executor with the given
+// executor-level classifier: IsFailure if set explicitly, else classifier,
+// else the default.
+func (cb *CircuitBreaker) isFailure(classifier func(error) bool) func(error) bool {
+	if classifier != nil && !cb.customIsFailure {
+		return classifier
+	}
+	return cb.config.IsFailure
+}
+
 // State returns the current circuit state.
 func (cb *CircuitBreaker) State() State {
 	cb.mu.Lock()
@@ -106,22 +163,78 @@ func (cb *CircuitBreaker) State() State {
 	return cb.currentStateLocked()
 }
 
-// Reset resets the circuit breaker to closed state.
+// IsOpen reports whether the circuit is open and rejecting requests.
+func (cb *CircuitBreaker) IsOpen() bool {
+	return cb.State() == StateOpen
+}
+
+// Reset resets the circuit breaker to closed state, clearing any forced
+// state.
 func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
 	oldState := cb.state
 	cb.state = StateClosed
+	cb.forced = false
 	cb.failures = 0
 	cb.successes = 0
 	cb.halfOpenCount = 0
+	cb.failureTimes = nil
+	cb.resetWindowLocked()
 
 	if oldState != StateClosed && cb.config.OnStateChange != nil {
 		cb.config.OnStateChange(oldState, StateClosed)
 	}
 }
 
+// ForceOpen pins the circuit open, rejecting every request with
+// ErrCircuitOpen, e.g. during planned downstream maintenance. The circuit
+// stays open, with no half-open probes, until Reset or AllowTransitions.
+func (cb *CircuitBreaker) ForceOpen() {
+	cb.force(StateOpen)
+}
+
+// ForceClose pins the circuit closed, allowing every request and ignoring
+// failures, until Reset or AllowTransitions.
+func (cb *CircuitBreaker) ForceClose() {
+	cb.force(StateClosed)
+}
+
+// AllowTransitions clears a forced state, returning the circuit to normal
+// operation from its current state. A circuit released while open moves to
+// half-open once ResetTimeout has passed since its last failure, which may
+// be immediately.
+func (cb *CircuitBreaker) AllowTransitions() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.forced = false
+}
+
+// Forced reports whether the circuit is pinned by ForceOpen or ForceClose.
+func (cb *CircuitBreaker) Forced() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.forced
+}
+
+func (cb *CircuitBreaker) force(state State) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	oldState := cb.state
+	cb.forced = true
+	cb.setState(state)
+	cb.failures = 0
+	cb.successes = 0
+	cb.failureTimes = nil
+	cb.resetWindowLocked()
+
+	if oldState != state && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(oldState, state)
+	}
+}
+
 func (cb *CircuitBreaker) beforeRequest() error {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
@@ -141,24 +254,34 @@ func (cb *CircuitBreaker) beforeRequest() error {
 	return nil
 }
 
-func (cb *CircuitBreaker) afterRequest(err error) {
+func (cb *CircuitBreaker) afterRequest(isFailure bool) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	isFailure := cb.config.IsFailure(err)
+	// A forced state ignores outcomes
+	if cb.forced {
+		return
+	}
+
 	oldState := cb.state
 
 	switch cb.state {
 	case StateClosed:
+		if cb.window != nil {
+			cb.afterRequestRateLocked(isFailure)
+			break
+		}
 		if isFailure {
-			cb.failures++
 			cb.lastFailure = time.Now()
+			cb.recordFailureLocked(cb.lastFailure)
 			if cb.failures >= cb.config.MaxFailures {
 				cb.setState(StateOpen)
+				cb.failureTimes = nil
 			}
 		} else {
 			// Reset failure count on success
 			cb.failures = 0
+			cb.failureTimes = nil
 		}
 
 	case StateHalfOpen:
@@ -172,6 +295,8 @@ func (cb *CircuitBreaker) afterRequest(err error) {
 			cb.setState(StateClosed)
 			cb.failures = 0
 			cb.successes = 0
+			cb.failureTimes = nil
+			cb.resetWindowLocked()
 		}
 	}
 
@@ -180,8 +305,53 @@ func (cb *CircuitBreaker) afterRequest(err error) {
 	}
 }
 
+// afterRequestRateLocked records a closed-state outcome in the rolling
+// window and opens the circuit once the failure rate reaches the threshold.
+func (cb *CircuitBreaker) afterRequestRateLocked(isFailure bool) {
+	now := time.Now()
+	cb.window.record(now, isFailure)
+	if isFailure {
+		cb.lastFailure = now
+	}
+
+	requests, failures := cb.window.counts(now)
+	cb.failures = failures
+	if requests < cb.config.MinRequests {
+		return
+	}
+	if float64(failures)/float64(requests) >= cb.config.FailureRateThreshold {
+		cb.setState(StateOpen)
+		cb.resetWindowLocked()
+	}
+}
+
+// resetWindowLocked clears the rolling window, if any.
+func (cb *CircuitBreaker) resetWindowLocked() {
+	if cb.window != nil {
+		cb.window.reset()
+	}
+}
+
+// recordFailureLocked counts a failure, forgetting failures that have aged
+// out of the configured FailureWindow.
+func (cb *CircuitBreaker) recordFailureLocked(now time.Time) {
+	if cb.config.FailureWindow <= 0 {
+		cb.failures++
+		return
+	}
+
+	cb.failureTimes = append(cb.failureTimes, now)
+	cutoff := now.Add(-cb.config.FailureWindow)
+	i := 0
+	for i < len(cb.failureTimes) && !cb.failureTimes[i].After(cutoff) {
+		i++
+	}
+	cb.failureTimes = cb.failureTimes[i:]
+	cb.failures = len(cb.failureTimes)
+}
+
 func (cb *CircuitBreaker) currentStateLocked() State {
-	if cb.state == StateOpen && time.Since(cb.lastFailure) >= cb.config.ResetTimeout {
+	if cb.state == StateOpen && !cb.forced && time.Since(cb.lastFailure) >= cb.config.ResetTimeout {
 		cb.state = StateHalfOpen
 		cb.halfOpenCount = 0
 		if cb.config.OnStateChange != nil {
@@ -203,12 +373,17 @@ func (cb *CircuitBreaker) Metrics() CircuitBreakerMetrics {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	return CircuitBreakerMetrics{
+	m := CircuitBreakerMetrics{
 		State:       cb.currentStateLocked(),
+		Forced:      cb.forced,
 		Failures:    cb.failures,
 		Successes:   cb.successes,
 		LastFailure: cb.lastFailure,
 	}
+	if cb.window != nil {
+		m.WindowRequests, m.WindowFailures = cb.window.counts(time.Now())
+	}
+	return m
 }
 
 // CircuitBreakerMetrics contains circuit breaker statistics.
@@ -217,4 +392,66 @@ type CircuitBreakerMetrics struct {
 	Failures    int
 	Successes   int
 	LastFailure time.Time
+
+	// Forced is true while State is pinned by ForceOpen or ForceClose.
+	Forced bool
+
+	// WindowRequests and WindowFailures count outcomes in the current
+	// rolling window. Zero unless FailureRateThreshold is configured.
+	WindowRequests int
+	WindowFailures int
+}
+
+// rollingWindow counts requests and failures over a sliding window split
+// into fixed-width buckets, so memory stays constant regardless of load.
+type rollingWindow struct {
+	width   time.Duration
+	buckets []windowBucket
+}
+
+type windowBucket struct {
+	start    int64 // Bucket start, in units of width since the epoch
+	requests int
+	failures int
+}
+
+func newRollingWindow(window time.Duration, n int) *rollingWindow {
+	width := window / time.Duration(n)
+	if width <= 0 {
+		width = 1
+	}
+	return &rollingWindow{
+		width:   width,
+		buckets: make([]windowBucket, n),
+	}
+}
+
+// record adds an outcome to the bucket for now, recycling it if stale.
+func (w *rollingWindow) record(now time.Time, failed bool) {
+	slot := now.UnixNano() / int64(w.width)
+	b := &w.buckets[slot%int64(len(w.buckets))]
+	if b.start != slot {
+		*b = windowBucket{start: slot}
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+}
+
+// counts sums the buckets that fall within the window ending at now.
+func (w *rollingWindow) counts(now time.Time) (requests, failures int) {
+	slot := now.UnixNano() / int64(w.width)
+	oldest := slot - int64(len(w.buckets)) + 1
+	for _, b := range w.buckets {
+		if b.start >= oldest && b.start <= slot {
+			requests += b.requests
+			failures += b.failures
+		}
+	}
+	return requests, failures
+}
+
+func (w *rollingWindow) reset() {
+	clear(w.buckets)
 }
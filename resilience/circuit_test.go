@@ -3,6 +3,7 @@ package resilience
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -301,3 +302,305 @@ func TestState_String(t *testing.T) {
 		})
 	}
 }
+
+func TestCircuitBreaker_FailureWindow_SpacedFailuresNeverTrip(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MaxFailures:   3,
+		ResetTimeout:  time.Second,
+		FailureWindow: 30 * time.Millisecond,
+	})
+
+	testErr := errors.New("test error")
+
+	for i := 0; i < 5; i++ {
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			return testErr
+		})
+		if cb.State() != StateClosed {
+			t.Fatalf("After spaced failure %d, state = %v, want closed", i+1, cb.State())
+		}
+		time.Sleep(40 * time.Millisecond)
+	}
+
+	if m := cb.Metrics(); m.Failures > 1 {
+		t.Errorf("Failures = %d, want at most 1 within window", m.Failures)
+	}
+}
+
+func TestCircuitBreaker_FailureWindow_BurstTrips(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MaxFailures:   3,
+		ResetTimeout:  time.Second,
+		FailureWindow: time.Second,
+	})
+
+	testErr := errors.New("test error")
+
+	for i := 0; i < 3; i++ {
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			return testErr
+		})
+	}
+
+	if cb.State() != StateOpen {
+		t.Errorf("State = %v, want open after burst within window", cb.State())
+	}
+}
+
+func TestCircuitBreaker_NoFailureWindow_SpacedFailuresTrip(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MaxFailures:  3,
+		ResetTimeout: time.Second,
+	})
+
+	testErr := errors.New("test error")
+
+	for i := 0; i < 3; i++ {
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			return testErr
+		})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if cb.State() != StateOpen {
+		t.Errorf("State = %v, want open without decay", cb.State())
+	}
+}
+
+// runWithFailureRate executes n requests through cb, failing failPerTen of
+// every ten.
+func runWithFailureRate(cb *CircuitBreaker, n, failPerTen int) {
+	testErr := errors.New("test error")
+	for i := 0; i < n; i++ {
+		fail := i%10 < failPerTen
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			if fail {
+				return testErr
+			}
+			return nil
+		})
+	}
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		failPerTen int
+		want       State
+	}{
+		{"60% failures open", 6, StateOpen},
+		{"10% failures stay closed", 1, StateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := NewCircuitBreaker(CircuitBreakerConfig{
+				FailureRateThreshold: 0.5,
+				RollingWindow:        time.Minute,
+				MinRequests:          10,
+				ResetTimeout:         time.Minute,
+			})
+
+			runWithFailureRate(cb, 100, tt.failPerTen)
+
+			if got := cb.State(); got != tt.want {
+				t.Errorf("State = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_FailureRateIgnoresConsecutiveFailures(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MaxFailures:          2,
+		FailureRateThreshold: 0.5,
+		RollingWindow:        time.Minute,
+		MinRequests:          10,
+	})
+
+	// Three consecutive failures exceed MaxFailures but not MinRequests
+	runWithFailureRate(cb, 3, 10)
+
+	if cb.State() != StateClosed {
+		t.Errorf("State = %v, want closed below MinRequests", cb.State())
+	}
+}
+
+func TestCircuitBreaker_FailureRateMetrics(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRateThreshold: 0.9,
+		RollingWindow:        time.Minute,
+	})
+
+	runWithFailureRate(cb, 10, 3)
+
+	m := cb.Metrics()
+	if m.WindowRequests != 10 || m.WindowFailures != 3 {
+		t.Errorf("window = %d requests/%d failures, want 10/3", m.WindowRequests, m.WindowFailures)
+	}
+
+	cb.Reset()
+	if m := cb.Metrics(); m.WindowRequests != 0 || m.WindowFailures != 0 {
+		t.Errorf("window after Reset = %d/%d, want 0/0", m.WindowRequests, m.WindowFailures)
+	}
+}
+
+func TestCircuitBreaker_FailureRateWindowSlides(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRateThreshold: 0.5,
+		RollingWindow:        50 * time.Millisecond,
+		MinRequests:          5,
+	})
+
+	runWithFailureRate(cb, 4, 10) // below MinRequests
+	time.Sleep(80 * time.Millisecond)
+	runWithFailureRate(cb, 4, 0)
+
+	m := cb.Metrics()
+	if m.WindowFailures != 0 {
+		t.Errorf("WindowFailures = %d, want 0 after old failures aged out", m.WindowFailures)
+	}
+	if cb.State() != StateClosed {
+		t.Errorf("State = %v, want closed", cb.State())
+	}
+}
+
+func TestCircuitBreaker_ForceOpen(t *testing.T) {
+	var transitions []string
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		ResetTimeout: time.Millisecond,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	cb.ForceOpen()
+
+	if cb.State() != StateOpen {
+		t.Errorf("State() = %v, want open", cb.State())
+	}
+	if !cb.Forced() {
+		t.Error("Forced() = false after ForceOpen, want true")
+	}
+
+	// Stays open past ResetTimeout: no half-open probes
+	time.Sleep(5 * time.Millisecond)
+	called := false
+	for i := 0; i < 3; i++ {
+		err := cb.Execute(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Execute() error = %v, want ErrCircuitOpen", err)
+		}
+	}
+	if called {
+		t.Error("operation ran while forced open")
+	}
+	if cb.State() != StateOpen {
+		t.Errorf("State() = %v after ResetTimeout, want still open", cb.State())
+	}
+
+	if want := []string{"closed->open"}; !slices.Equal(transitions, want) {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
+
+func TestCircuitBreaker_ForceClose(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1})
+	errFail := errors.New("fail")
+
+	cb.ForceClose()
+	for i := 0; i < 5; i++ {
+		err := cb.Execute(context.Background(), func(ctx context.Context) error { return errFail })
+		if !errors.Is(err, errFail) {
+			t.Errorf("Execute() error = %v, want %v", err, errFail)
+		}
+	}
+
+	if cb.State() != StateClosed {
+		t.Errorf("State() = %v, want closed despite failures", cb.State())
+	}
+	if m := cb.Metrics(); !m.Forced {
+		t.Error("Metrics().Forced = false, want true")
+	}
+}
+
+func TestCircuitBreaker_ForceOpenFromOpenKeepsCallbackQuiet(t *testing.T) {
+	calls := 0
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MaxFailures:   1,
+		ResetTimeout:  time.Minute,
+		OnStateChange: func(from, to State) { calls++ },
+	})
+	_ = cb.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	calls = 0
+
+	cb.ForceOpen()
+	if calls != 0 {
+		t.Errorf("OnStateChange calls = %d forcing an open circuit open, want 0", calls)
+	}
+}
+
+func TestCircuitBreaker_ClearForcedState(t *testing.T) {
+	tests := []struct {
+		name  string
+		clear func(cb *CircuitBreaker)
+	}{
+		{"Reset", (*CircuitBreaker).Reset},
+		{"AllowTransitions", (*CircuitBreaker).AllowTransitions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transitions []string
+			cb := NewCircuitBreaker(CircuitBreakerConfig{
+				MaxFailures:  1,
+				ResetTimeout: time.Millisecond,
+				OnStateChange: func(from, to State) {
+					transitions = append(transitions, from.String()+"->"+to.String())
+				},
+			})
+
+			cb.ForceOpen()
+			tt.clear(cb)
+			time.Sleep(5 * time.Millisecond)
+
+			if cb.Forced() {
+				t.Error("Forced() = true after clearing, want false")
+			}
+
+			// Normal operation resumes: a success closes the circuit (via a
+			// half-open probe after AllowTransitions)
+			if err := cb.Execute(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+				t.Fatalf("Execute() error = %v, want nil", err)
+			}
+			if cb.State() != StateClosed {
+				t.Errorf("State() = %v, want closed", cb.State())
+			}
+
+			// And failures trip it again
+			_ = cb.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+			if cb.State() != StateOpen {
+				t.Errorf("State() = %v after failure, want open", cb.State())
+			}
+
+			if transitions[0] != "closed->open" {
+				t.Errorf("first transition = %q, want closed->open", transitions[0])
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_IsOpen(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Hour})
+	if cb.IsOpen() {
+		t.Error("IsOpen() = true for new breaker, want false")
+	}
+
+	cb.ForceOpen()
+	if !cb.IsOpen() {
+		t.Error("IsOpen() = false after ForceOpen, want true")
+	}
+}
@@ -33,23 +33,48 @@
 //
 // # Resilience Patterns
 //
-// The package provides five core patterns:
+// The package provides seven core patterns:
 //
 //   - [CircuitBreaker]: Prevents cascading failures by stopping requests to
 //     failing services after a threshold is reached. Transitions through
-//     Closed → Open → HalfOpen states.
+//     Closed → Open → HalfOpen states. Trips on consecutive failures by
+//     default, or on a failure rate over a rolling window when
+//     CircuitBreakerConfig.FailureRateThreshold is set.
+//     [KeyedCircuitBreaker] keeps one breaker per key, such as a downstream
+//     host, so one bad backend does not block healthy ones.
+//     ForceOpen and ForceClose pin the state for planned maintenance until
+//     Reset or AllowTransitions.
 //
 //   - [Retry]: Automatically retries failed operations with configurable
-//     backoff strategies (exponential, linear, constant) and jitter.
+//     backoff strategies (exponential, linear, constant, full jitter,
+//     decorrelated jitter) and jitter.
+//     Context cancellation and deadline errors are never retried unless
+//     RetryConfig.RetryContextErrors is set.
 //
 //   - [RateLimiter]: Token bucket rate limiting to prevent overwhelming
 //     downstream services. Supports burst allowance and wait-on-limit.
+//     [RateLimiter.Reserve] reports how long until a token is available
+//     without blocking, e.g. to answer with Retry-After.
+//     [PerToolRateLimiter] gives each tool ID its own bucket and rate;
+//     [PerToolRateLimiter.LimiterWithConfig] keys buckets by other IDs, such
+//     as API key IDs, with limits supplied at request time.
+//     [RedisRateLimiter] enforces one rate across every replica.
 //
 //   - [Bulkhead]: Semaphore-based concurrency limiting to prevent resource
 //     exhaustion and isolate failures.
+//     [AdaptiveBulkhead] tunes its limit between a minimum and maximum from
+//     observed latency instead of using a fixed MaxConcurrent.
 //
 //   - [Timeout]: Context-based timeout to ensure operations complete within
-//     a time limit.
+//     a time limit. A tighter deadline already on the caller's context wins.
+//
+//   - [Fallback]: Runs a substitute operation, such as serving a cached
+//     response, when the primary fails with an open circuit or a timeout.
+//     Business errors pass through unchanged.
+//
+//   - [Hedge]: Launches a parallel attempt when the first is slow and takes
+//     whichever succeeds first. Only for operations declared idempotent via
+//     HedgeConfig.Idempotent, since every attempt may reach the server.
 //
 // # Quick Start
 //
@@ -81,25 +106,126 @@
 //	    return callExternalService(ctx)
 //	})
 //
+// # Idempotency
+//
+// Retrying an operation that already reached the server can duplicate side
+// effects (a second charge, a second insert). For non-idempotent operations,
+// set RetryConfig.SafeToRetry so only failures that guarantee the request was
+// never sent are retried:
+//
+//	// POST /payments: retry refused connections, never a read timeout
+//	retry := resilience.NewRetry(resilience.RetryConfig{
+//	    MaxAttempts: 3,
+//	    SafeToRetry: resilience.IsDialError,
+//	})
+//
+//	// GET /payments/123: idempotent, retry any transient error
+//	retry := resilience.NewRetry(resilience.RetryConfig{
+//	    MaxAttempts: 3,
+//	    Idempotent:  true,
+//	})
+//
+// RetryConfig.RetryableErrors additionally restricts retries to a fixed set
+// of sentinel errors, checked with errors.Is.
+//
+// # Retry-After
+//
+// Rate-limited or overloaded servers often say when to come back (a 429 or
+// 503 with Retry-After). Retrying sooner is rejected again. Wrap such errors
+// in [RetryAfterError] and set RetryConfig.RetryAfter to
+// [RetryAfterFromError]; the requested delay then replaces the computed
+// backoff for that retry. RetryConfig.MaxDelay still caps it, so a server
+// cannot stall the caller indefinitely.
+//
+// # Retry Budgets
+//
+// Retries help with isolated failures but multiply load during a broad
+// outage. Share one [RetryBudget] across the retries for a backend to cap
+// retries at a fraction of requests over a rolling window; once it is
+// spent, Execute returns [ErrRetryBudgetExhausted] instead of retrying:
+//
+//	budget := resilience.NewRetryBudget(resilience.RetryBudgetConfig{Ratio: 0.1})
+//	retry := resilience.NewRetry(resilience.RetryConfig{MaxAttempts: 3, Budget: budget})
+//
+// # Distributed Rate Limiting
+//
+// A [RateLimiter] is per process, so ten replicas with Rate 10 allow 100
+// requests per second in total. A [RedisRateLimiter] keeps the bucket in
+// Redis, updated atomically by a script, so the configured rate is global.
+// Limiters with the same Namespace share a bucket:
+//
+//	rl, err := resilience.NewRedisRateLimiter(resilience.RedisRateLimiterConfig{
+//	    Client:    redisClient,
+//	    Namespace: "github.search",
+//	    Rate:      10,
+//	})
+//
+// Each call is a Redis round trip. If Redis is unavailable, [FailOpen] (the
+// default) allows requests and [FailClosed] rejects them.
+//
 // # Execution Order
 //
 // When using the Executor, patterns are applied in this order (outermost first):
 //
-//  1. Rate Limiter - limits request rate
-//  2. Bulkhead - limits concurrency
-//  3. Circuit Breaker - prevents cascading failures
-//  4. Retry - retries on failure
-//  5. Timeout - limits execution time (innermost)
+//  1. Fallback - substitutes for unavailability errors
+//  2. Health Gate - refuses work while a dependency reports unhealthy
+//  3. Rate Limiter - limits request rate
+//  4. Bulkhead - limits concurrency
+//  5. Circuit Breaker - prevents cascading failures
+//  6. Retry - retries on failure
+//  7. Per-Attempt Timeout - limits each retry attempt as a whole
+//  8. Hedge - races parallel attempts of slow operations
+//  9. Timeout - limits each call to the operation (innermost)
+//
+// Both timeouts sit inside Retry, so every attempt starts with a fresh
+// timeout; neither bounds the retry loop as a whole. [WithPerAttemptTimeout]
+// also covers the hedged calls of an attempt. To cap the total time across
+// all attempts, give the context passed to Execute a deadline; Timeout
+// honors it.
+//
+// # Failure Classification
+//
+// [WithFailureClassifier] gives the Executor one definition of failure,
+// used by both the circuit breaker and retry layers:
+//
+//	executor := resilience.NewExecutor(
+//	    resilience.WithCircuitBreaker(cb),
+//	    resilience.WithRetry(retry),
+//	    resilience.WithFailureClassifier(func(err error) bool {
+//	        return err != nil && !errors.Is(err, context.Canceled)
+//	    }),
+//	)
+//
+// A pattern's own CircuitBreakerConfig.IsFailure or RetryConfig.RetryIf,
+// when set, takes precedence over the executor-level classifier.
+//
+// # Health Gating
+//
+// [WithHealthGate] connects the Executor to an external health signal. A
+// circuit breaker reacts to failures it has observed; a health gate acts on
+// what a checker already knows, refusing work before any call is attempted.
+// Adapt a checker with [HealthFunc]:
+//
+//	gate := resilience.HealthFunc(func(ctx context.Context) bool {
+//	    return dbChecker.Check(ctx).Status != health.StatusUnhealthy
+//	})
+//	executor := resilience.NewExecutor(resilience.WithHealthGate(gate))
 //
 // # Thread Safety
 //
 // All exported types are safe for concurrent use after construction:
 //
-//   - [CircuitBreaker]: Execute() and State() are mutex-protected; Reset() is safe
+//   - [CircuitBreaker]: Execute() and State() are mutex-protected; Reset() and ForceOpen() are safe
+//   - [KeyedCircuitBreaker]: Per-key breakers are created and swept under a mutex
 //   - [Retry]: Execute() is stateless and safe for concurrent use
-//   - [RateLimiter]: Allow(), AllowN(), Wait(), Execute() are mutex-protected
-//   - [Bulkhead]: Acquire(), Release(), Execute() use channel-based semaphore
-//   - [Timeout]: Execute() is stateless and safe for concurrent use
+//   - [RateLimiter]: Allow(), AllowN(), Wait(), Reserve(), Execute() are mutex-protected; Metrics() counters are atomic
+//   - [PerToolRateLimiter]: Per-tool limiters are created under a mutex
+//   - [RedisRateLimiter]: Each call is one atomic Redis script; safe across processes
+//   - [Bulkhead]: Acquire(), Release(), Execute() use channel-based semaphore; metrics use a separate mutex
+//   - [AdaptiveBulkhead]: Execute() and limit adjustments are mutex-protected
+//   - [Timeout]: Execute() is safe for concurrent use; Metrics() counters are atomic
+//   - [Fallback]: Execute() is stateless and safe for concurrent use
+//   - [Hedge]: Execute() is stateless and safe for concurrent use
 //   - [Executor]: Execute() is safe; all wrapped patterns maintain their guarantees
 //
 // # Error Handling
@@ -110,7 +236,10 @@
 //   - [ErrMaxRetriesExceeded]: All retry attempts exhausted
 //   - [ErrRateLimitExceeded]: Rate limit exceeded and no wait configured
 //   - [ErrBulkheadFull]: Bulkhead at maximum concurrency
-//   - [ErrTimeout]: Operation exceeded configured timeout
+//   - [ErrTimeout]: Operation exceeded its effective deadline; also matches context.DeadlineExceeded
+//   - [ErrDependencyUnhealthy]: Health gate reported the dependency unhealthy
+//   - [ErrRetryBudgetExhausted]: Shared retry budget is spent; retry suppressed
+//   - [ErrNilClient]: Redis-backed pattern constructed without a client
 //
 // Example error handling:
 //
@@ -133,6 +262,7 @@
 //   - RetryConfig.OnRetry: Called before each retry attempt
 //   - CircuitBreakerConfig.IsFailure: Custom failure classification
 //   - RetryConfig.RetryIf: Custom retry decision logic
+//   - RetryConfig.SafeToRetry: Pre-send failure classification for non-idempotent operations
 //
 // # Integration with ApertureStack
 //
@@ -140,5 +270,5 @@
 //
 //   - toolexec: Wrap tool execution with resilience patterns
 //   - observe: Connect callbacks to observability middleware
-//   - health: Use CircuitBreaker.State() for health checks
+//   - health: Register a breaker with health.CircuitBreakerChecker
 package resilience
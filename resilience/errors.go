@@ -18,4 +18,16 @@ var (
 
 	// ErrTimeout is returned when an operation times out.
 	ErrTimeout = errors.New("resilience: operation timed out")
+
+	// ErrDependencyUnhealthy is returned when a health gate reports the
+	// dependency as unhealthy.
+	ErrDependencyUnhealthy = errors.New("resilience: dependency unhealthy")
+
+	// ErrRetryBudgetExhausted is returned when a retry is suppressed
+	// because the shared RetryBudget is spent.
+	ErrRetryBudgetExhausted = errors.New("resilience: retry budget exhausted")
+
+	// ErrNilClient is returned when a Redis-backed pattern is constructed
+	// without a client.
+	ErrNilClient = errors.New("resilience: redis client is nil")
 )
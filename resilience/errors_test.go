@@ -14,6 +14,9 @@ func TestSentinelErrors(t *testing.T) {
 		{"ErrRateLimitExceeded", ErrRateLimitExceeded},
 		{"ErrBulkheadFull", ErrBulkheadFull},
 		{"ErrTimeout", ErrTimeout},
+		{"ErrDependencyUnhealthy", ErrDependencyUnhealthy},
+		{"ErrRetryBudgetExhausted", ErrRetryBudgetExhausted},
+		{"ErrNilClient", ErrNilClient},
 	}
 
 	for _, tt := range tests {
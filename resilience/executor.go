@@ -5,13 +5,36 @@ import (
 	"time"
 )
 
+// StateReporter reports whether a dependency is currently healthy.
+// It is intentionally minimal so health signals can be adapted without
+// this package importing a health library.
+type StateReporter interface {
+	// Healthy returns false when calls to the dependency should be refused.
+	Healthy(ctx context.Context) bool
+}
+
+// HealthFunc adapts a function to the StateReporter interface.
+type HealthFunc func(ctx context.Context) bool
+
+// Healthy calls f(ctx).
+func (f HealthFunc) Healthy(ctx context.Context) bool {
+	return f(ctx)
+}
+
 // Executor composes multiple resilience patterns.
 type Executor struct {
+	fallback       *Fallback
+	fallbackFunc   FallbackFunc
+	healthGate     StateReporter
 	circuitBreaker *CircuitBreaker
 	retry          *Retry
+	attemptTimeout *Timeout
+	hedge          *Hedge
 	rateLimiter    *RateLimiter
 	bulkhead       *Bulkhead
 	timeout        *Timeout
+
+	failureClassifier func(error) bool
 }
 
 // ExecutorOption configures an Executor.
@@ -26,6 +49,33 @@ func NewExecutor(opts ...ExecutorOption) *Executor {
 	return e
 }
 
+// WithFallback runs fn when the composed patterns fail with an error that
+// f's ShouldFallback accepts, such as an open circuit or a timeout. It is
+// the outermost layer, so it also sees errors from the health gate, rate
+// limiter, and bulkhead. A nil f uses NewFallback(FallbackConfig{}).
+func WithFallback(f *Fallback, fn FallbackFunc) ExecutorOption {
+	return func(e *Executor) {
+		if f == nil {
+			f = NewFallback(FallbackConfig{})
+		}
+		e.fallback = f
+		e.fallbackFunc = fn
+	}
+}
+
+// WithHealthGate makes the executor consult a health signal before each
+// call and fail fast with ErrDependencyUnhealthy, without attempting the
+// operation, while the reporter is unhealthy.
+//
+// Unlike a circuit breaker, which opens after observing failures of its own
+// calls, the gate acts on an external signal (e.g., the dependency's health
+// checker) and can shed load before any request fails.
+func WithHealthGate(reporter StateReporter) ExecutorOption {
+	return func(e *Executor) {
+		e.healthGate = reporter
+	}
+}
+
 // WithCircuitBreaker adds a circuit breaker to the executor.
 func WithCircuitBreaker(cb *CircuitBreaker) ExecutorOption {
 	return func(e *Executor) {
@@ -40,6 +90,15 @@ func WithRetry(r *Retry) ExecutorOption {
 	}
 }
 
+// WithHedge adds hedged requests to the executor. Each hedge runs inside
+// the retry loop and gets its own timeout. Hedging only takes effect when
+// h was configured with HedgeConfig.Idempotent.
+func WithHedge(h *Hedge) ExecutorOption {
+	return func(e *Executor) {
+		e.hedge = h
+	}
+}
+
 // WithRateLimiter adds rate limiting to the executor.
 func WithRateLimiter(rl *RateLimiter) ExecutorOption {
 	return func(e *Executor) {
@@ -54,13 +113,41 @@ func WithBulkhead(b *Bulkhead) ExecutorOption {
 	}
 }
 
-// WithTimeout adds timeout to the executor.
+// WithTimeout adds timeout to the executor. It is the innermost layer, so
+// each call to the operation, including each retry and each hedge, gets
+// its own timeout. For a budget covering the whole call, put a deadline on
+// the context passed to Execute.
 func WithTimeout(timeout time.Duration) ExecutorOption {
 	return func(e *Executor) {
 		e.timeout = NewTimeout(TimeoutConfig{Timeout: timeout})
 	}
 }
 
+// WithPerAttemptTimeout bounds each retry attempt with its own timeout,
+// applied just inside the retry loop. Unlike WithTimeout, which bounds each
+// individual call, it covers an attempt as a whole, including any hedged
+// calls it launches; a slow attempt cannot use up the time meant for the
+// attempts after it.
+func WithPerAttemptTimeout(d time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.attemptTimeout = NewTimeout(TimeoutConfig{Timeout: d})
+	}
+}
+
+// WithFailureClassifier sets one definition of failure for the whole
+// pipeline: isFailure is used as the circuit breaker's IsFailure and the
+// retry's RetryIf. For example, treating context.Canceled as a non-failure
+// keeps callers giving up from tripping the breaker or being retried.
+//
+// A classifier set on the pattern itself (CircuitBreakerConfig.IsFailure or
+// RetryConfig.RetryIf) takes precedence for that pattern. The patterns are
+// not modified, so they can be shared with other executors.
+func WithFailureClassifier(isFailure func(error) bool) ExecutorOption {
+	return func(e *Executor) {
+		e.failureClassifier = isFailure
+	}
+}
+
 // WithTimeoutConfig adds timeout with custom config to the executor.
 func WithTimeoutConfig(t *Timeout) ExecutorOption {
 	return func(e *Executor) {
@@ -71,12 +158,24 @@ func WithTimeoutConfig(t *Timeout) ExecutorOption {
 // Execute runs the operation through all configured resilience patterns.
 //
 // The execution order is:
-// 1. Rate Limiter (if configured) - limits request rate
-// 2. Bulkhead (if configured) - limits concurrency
-// 3. Circuit Breaker (if configured) - prevents cascading failures
-// 4. Retry (if configured) - retries on failure
-// 5. Timeout (if configured) - limits execution time
+// 1. Fallback (if configured) - substitutes for unavailability errors
+// 2. Health Gate (if configured) - refuses work while a dependency is unhealthy
+// 3. Rate Limiter (if configured) - limits request rate
+// 4. Bulkhead (if configured) - limits concurrency
+// 5. Circuit Breaker (if configured) - prevents cascading failures
+// 6. Retry (if configured) - retries on failure
+// 7. Per-Attempt Timeout (if configured) - limits each retry attempt
+// 8. Hedge (if configured) - races parallel attempts of slow operations
+// 9. Timeout (if configured) - limits execution time
 func (e *Executor) Execute(ctx context.Context, op func(context.Context) error) error {
+	if e.fallback != nil {
+		return e.fallback.Execute(ctx, e.execute(op), e.fallbackFunc)
+	}
+	return e.execute(op)(ctx)
+}
+
+// execute builds the chain of every pattern except the fallback.
+func (e *Executor) execute(op func(context.Context) error) func(context.Context) error {
 	// Build the execution chain from inside out
 	execute := op
 
@@ -88,19 +187,37 @@ func (e *Executor) Execute(ctx context.Context, op func(context.Context) error)
 		}
 	}
 
+	// Wrap with hedge
+	if e.hedge != nil {
+		inner := execute
+		execute = func(ctx context.Context) error {
+			return e.hedge.Execute(ctx, inner)
+		}
+	}
+
+	// Wrap each attempt with its own timeout
+	if e.attemptTimeout != nil {
+		inner := execute
+		execute = func(ctx context.Context) error {
+			return e.attemptTimeout.Execute(ctx, inner)
+		}
+	}
+
 	// Wrap with retry
 	if e.retry != nil {
 		inner := execute
+		retryIf := e.retry.retryIf(e.failureClassifier)
 		execute = func(ctx context.Context) error {
-			return e.retry.Execute(ctx, inner)
+			return e.retry.execute(ctx, inner, retryIf)
 		}
 	}
 
 	// Wrap with circuit breaker
 	if e.circuitBreaker != nil {
 		inner := execute
+		isFailure := e.circuitBreaker.isFailure(e.failureClassifier)
 		execute = func(ctx context.Context) error {
-			return e.circuitBreaker.Execute(ctx, inner)
+			return e.circuitBreaker.execute(ctx, inner, isFailure)
 		}
 	}
 
@@ -120,5 +237,19 @@ func (e *Executor) Execute(ctx context.Context, op func(context.Context) error)
 		}
 	}
 
-	return execute(ctx)
+	// Check the health gate before anything else
+	if e.healthGate != nil {
+		inner := execute
+		execute = func(ctx context.Context) error {
+			if !e.healthGate.Healthy(ctx) {
+				return ErrDependencyUnhealthy
+			}
+			return inner(ctx)
+		}
+	}
+
+	return execute
 }
+
+// Ensure HealthFunc implements StateReporter
+var _ StateReporter = HealthFunc(nil)
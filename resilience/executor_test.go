@@ -3,6 +3,7 @@ package resilience
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -25,6 +26,9 @@ func TestNewExecutor(t *testing.T) {
 	if e.timeout != nil {
 		t.Error("Default executor should not have timeout")
 	}
+	if e.healthGate != nil {
+		t.Error("Default executor should not have health gate")
+	}
 }
 
 func TestExecutor_WithOptions(t *testing.T) {
@@ -94,7 +98,7 @@ func TestExecutor_ExecuteWithTimeout(t *testing.T) {
 			time.Sleep(100 * time.Millisecond)
 			return nil
 		})
-		if err != ErrTimeout {
+		if !errors.Is(err, ErrTimeout) {
 			t.Errorf("Execute() error = %v, want ErrTimeout", err)
 		}
 	})
@@ -264,3 +268,293 @@ func TestWithTimeoutConfig(t *testing.T) {
 		t.Error("Timeout not set correctly with WithTimeoutConfig")
 	}
 }
+
+// fakeHealth is a StateReporter that can be toggled between states.
+type fakeHealth struct {
+	unhealthy atomic.Bool
+}
+
+func (f *fakeHealth) Healthy(context.Context) bool {
+	return !f.unhealthy.Load()
+}
+
+func TestExecutor_HealthGate(t *testing.T) {
+	reporter := &fakeHealth{}
+	e := NewExecutor(WithHealthGate(reporter))
+
+	var calls int
+	op := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	if err := e.Execute(context.Background(), op); err != nil {
+		t.Fatalf("Execute() error = %v while healthy", err)
+	}
+
+	reporter.unhealthy.Store(true)
+	if err := e.Execute(context.Background(), op); !errors.Is(err, ErrDependencyUnhealthy) {
+		t.Errorf("Execute() error = %v, want ErrDependencyUnhealthy", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (operation not attempted while unhealthy)", calls)
+	}
+
+	reporter.unhealthy.Store(false)
+	if err := e.Execute(context.Background(), op); err != nil {
+		t.Errorf("Execute() error = %v after recovery", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestExecutor_HealthGateBypassesOtherPatterns(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1})
+	retry := NewRetry(RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond})
+	reporter := &fakeHealth{}
+	reporter.unhealthy.Store(true)
+
+	e := NewExecutor(
+		WithHealthGate(reporter),
+		WithCircuitBreaker(cb),
+		WithRetry(retry),
+	)
+
+	for i := 0; i < 3; i++ {
+		err := e.Execute(context.Background(), func(ctx context.Context) error {
+			t.Fatal("operation should not run while unhealthy")
+			return nil
+		})
+		if !errors.Is(err, ErrDependencyUnhealthy) {
+			t.Fatalf("Execute() error = %v, want ErrDependencyUnhealthy", err)
+		}
+	}
+
+	// Gated calls are not failures observed by the breaker
+	if cb.State() != StateClosed {
+		t.Errorf("CircuitBreaker state = %v, want closed", cb.State())
+	}
+}
+
+func TestHealthFunc(t *testing.T) {
+	gate := HealthFunc(func(context.Context) bool { return false })
+	if gate.Healthy(context.Background()) {
+		t.Error("HealthFunc.Healthy() = true, want false")
+	}
+}
+
+func TestExecutor_Fallback(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Minute})
+	fallbacks := 0
+	executor := NewExecutor(
+		WithFallback(nil, func(ctx context.Context, err error) error {
+			fallbacks++
+			return nil
+		}),
+		WithCircuitBreaker(cb),
+	)
+	ctx := context.Background()
+	errBusiness := errors.New("invalid account")
+
+	// A business error trips the breaker but is not replaced
+	if err := executor.Execute(ctx, func(ctx context.Context) error { return errBusiness }); !errors.Is(err, errBusiness) {
+		t.Fatalf("Execute() error = %v, want business error", err)
+	}
+	if fallbacks != 0 {
+		t.Errorf("fallbacks = %d after business error, want 0", fallbacks)
+	}
+
+	// The open circuit is replaced by the fallback
+	if err := executor.Execute(ctx, func(ctx context.Context) error { return nil }); err != nil {
+		t.Errorf("Execute() error = %v, want fallback result", err)
+	}
+	if fallbacks != 1 {
+		t.Errorf("fallbacks = %d after open circuit, want 1", fallbacks)
+	}
+}
+
+func TestExecutor_FallbackWrapsHealthGate(t *testing.T) {
+	fb := NewFallback(FallbackConfig{
+		ShouldFallback: func(err error) bool { return errors.Is(err, ErrDependencyUnhealthy) },
+	})
+	executor := NewExecutor(
+		WithFallback(fb, func(ctx context.Context, err error) error { return nil }),
+		WithHealthGate(HealthFunc(func(context.Context) bool { return false })),
+	)
+
+	if err := executor.Execute(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Errorf("Execute() error = %v, want fallback to handle unhealthy dependency", err)
+	}
+}
+
+func TestExecutor_Hedge(t *testing.T) {
+	executor := NewExecutor(
+		WithHedge(NewHedge(HedgeConfig{Delay: 10 * time.Millisecond, Idempotent: true})),
+		WithTimeout(time.Second),
+	)
+	var calls atomic.Int32
+	cancelled := make(chan struct{})
+
+	start := time.Now()
+	if err := executor.Execute(context.Background(), slowThenFast(&calls, cancelled)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Execute() took %v, want the hedge to win before the timeout", elapsed)
+	}
+}
+
+func TestExecutor_PerAttemptTimeout(t *testing.T) {
+	const perAttempt = 30 * time.Millisecond
+	executor := NewExecutor(
+		WithRetry(NewRetry(RetryConfig{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+		})),
+		WithPerAttemptTimeout(perAttempt),
+	)
+
+	var attempts atomic.Int32
+	remaining := make(chan time.Duration, 3)
+	start := time.Now()
+	err := executor.Execute(context.Background(), func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		remaining <- time.Until(deadline)
+		if attempts.Add(1) < 3 {
+			<-ctx.Done() // Hang until this attempt's timeout fires
+			return ctx.Err()
+		}
+		return nil
+	})
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v, want third attempt to succeed", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+
+	// Each attempt starts with a fresh budget rather than the leftovers
+	close(remaining)
+	for d := range remaining {
+		if d < perAttempt/2 {
+			t.Errorf("attempt deadline in %v, want close to %v", d, perAttempt)
+		}
+	}
+	if elapsed < 2*perAttempt {
+		t.Errorf("Execute() took %v, want at least two full attempt timeouts", elapsed)
+	}
+}
+
+func TestExecutor_PerAttemptTimeoutExhausted(t *testing.T) {
+	executor := NewExecutor(
+		WithRetry(NewRetry(RetryConfig{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+		})),
+		WithPerAttemptTimeout(10*time.Millisecond),
+	)
+
+	var attempts atomic.Int32
+	err := executor.Execute(context.Background(), func(ctx context.Context) error {
+		attempts.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Execute() error = %v, want ErrTimeout", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestExecutor_FailureClassifier(t *testing.T) {
+	notCancellation := func(err error) bool {
+		return err != nil && !errors.Is(err, context.Canceled)
+	}
+
+	t.Run("cancellation does not trip breaker", func(t *testing.T) {
+		cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Minute})
+		executor := NewExecutor(
+			WithCircuitBreaker(cb),
+			WithFailureClassifier(notCancellation),
+		)
+
+		for i := 0; i < 3; i++ {
+			err := executor.Execute(context.Background(), func(ctx context.Context) error {
+				return context.Canceled
+			})
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Execute() error = %v, want context.Canceled", err)
+			}
+		}
+		if cb.State() != StateClosed {
+			t.Errorf("State() = %v, want closed", cb.State())
+		}
+
+		// Real failures still count
+		_ = executor.Execute(context.Background(), func(ctx context.Context) error {
+			return errors.New("downstream failure")
+		})
+		if cb.State() != StateOpen {
+			t.Errorf("State() = %v after failure, want open", cb.State())
+		}
+	})
+
+	t.Run("non-failure is not retried", func(t *testing.T) {
+		errIgnored := errors.New("ignored")
+		executor := NewExecutor(
+			WithRetry(NewRetry(RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond})),
+			WithFailureClassifier(func(err error) bool {
+				return err != nil && !errors.Is(err, errIgnored)
+			}),
+		)
+
+		attempts := 0
+		err := executor.Execute(context.Background(), func(ctx context.Context) error {
+			attempts++
+			return errIgnored
+		})
+		if !errors.Is(err, errIgnored) {
+			t.Errorf("Execute() error = %v, want %v", err, errIgnored)
+		}
+		if attempts != 1 {
+			t.Errorf("attempts = %d, want 1", attempts)
+		}
+	})
+
+	t.Run("pattern classifier takes precedence", func(t *testing.T) {
+		cb := NewCircuitBreaker(CircuitBreakerConfig{
+			MaxFailures:  1,
+			ResetTimeout: time.Minute,
+			IsFailure:    func(err error) bool { return err != nil },
+		})
+		executor := NewExecutor(
+			WithCircuitBreaker(cb),
+			WithFailureClassifier(notCancellation),
+		)
+
+		_ = executor.Execute(context.Background(), func(ctx context.Context) error {
+			return context.Canceled
+		})
+		if cb.State() != StateOpen {
+			t.Errorf("State() = %v, want open per the breaker's own IsFailure", cb.State())
+		}
+	})
+
+	t.Run("shared breaker is not modified", func(t *testing.T) {
+		cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Minute})
+		_ = NewExecutor(WithCircuitBreaker(cb), WithFailureClassifier(notCancellation))
+
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			return context.Canceled
+		})
+		if cb.State() != StateOpen {
+			t.Errorf("State() = %v, want open when used directly", cb.State())
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+)
+
+// FallbackFunc produces a substitute outcome after the primary operation
+// failed with err, e.g. by serving a cached or default response. Its return
+// value replaces err.
+type FallbackFunc func(ctx context.Context, err error) error
+
+// FallbackConfig configures the fallback wrapper.
+type FallbackConfig struct {
+	// ShouldFallback decides whether a primary error triggers the fallback.
+	// Errors it rejects, such as business errors, are returned unchanged.
+	// Default: errors matching ErrCircuitOpen or ErrTimeout.
+	ShouldFallback func(err error) bool
+}
+
+// Fallback runs a fallback operation when the primary fails in a way that
+// signals the dependency is unavailable rather than the request is wrong.
+type Fallback struct {
+	config FallbackConfig
+}
+
+// NewFallback creates a new fallback wrapper.
+func NewFallback(config FallbackConfig) *Fallback {
+	if config.ShouldFallback == nil {
+		config.ShouldFallback = DefaultShouldFallback
+	}
+	return &Fallback{config: config}
+}
+
+// DefaultShouldFallback reports whether err indicates an open circuit or a
+// timeout.
+func DefaultShouldFallback(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrTimeout)
+}
+
+// Execute runs primary, then runs fallback with the primary's error if
+// ShouldFallback accepts it. A nil fallback returns the primary error.
+func (f *Fallback) Execute(ctx context.Context, primary func(context.Context) error, fallback FallbackFunc) error {
+	err := primary(ctx)
+	if err == nil || fallback == nil || !f.config.ShouldFallback(err) {
+		return err
+	}
+	return fallback(ctx, err)
+}
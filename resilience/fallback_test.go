@@ -0,0 +1,80 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFallback_Execute(t *testing.T) {
+	errBusiness := errors.New("invalid account")
+
+	tests := []struct {
+		name         string
+		primaryErr   error
+		wantFallback bool
+		wantErr      error
+	}{
+		{"success", nil, false, nil},
+		{"circuit open", ErrCircuitOpen, true, nil},
+		{"timeout", ErrTimeout, true, nil},
+		{"wrapped circuit open", fmt.Errorf("call: %w", ErrCircuitOpen), true, nil},
+		{"business error", errBusiness, false, errBusiness},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFallback(FallbackConfig{})
+			var gotErr error
+			ran := false
+
+			err := fb.Execute(context.Background(),
+				func(ctx context.Context) error { return tt.primaryErr },
+				func(ctx context.Context, err error) error {
+					ran = true
+					gotErr = err
+					return nil
+				})
+
+			if ran != tt.wantFallback {
+				t.Errorf("fallback ran = %v, want %v", ran, tt.wantFallback)
+			}
+			if ran && gotErr != tt.primaryErr {
+				t.Errorf("fallback got error %v, want %v", gotErr, tt.primaryErr)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFallback_CustomPredicate(t *testing.T) {
+	fb := NewFallback(FallbackConfig{
+		ShouldFallback: func(err error) bool { return errors.Is(err, ErrBulkheadFull) },
+	})
+
+	errFallback := errors.New("fallback failed")
+	err := fb.Execute(context.Background(),
+		func(ctx context.Context) error { return ErrBulkheadFull },
+		func(ctx context.Context, err error) error { return errFallback })
+	if !errors.Is(err, errFallback) {
+		t.Errorf("Execute() error = %v, want fallback's error", err)
+	}
+
+	err = fb.Execute(context.Background(),
+		func(ctx context.Context) error { return ErrCircuitOpen },
+		func(ctx context.Context, err error) error { return nil })
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Execute() error = %v, want ErrCircuitOpen passed through", err)
+	}
+}
+
+func TestFallback_NilFallbackFunc(t *testing.T) {
+	fb := NewFallback(FallbackConfig{})
+	err := fb.Execute(context.Background(), func(ctx context.Context) error { return ErrCircuitOpen }, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Execute() error = %v, want ErrCircuitOpen", err)
+	}
+}
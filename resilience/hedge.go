@@ -0,0 +1,97 @@
+package resilience
+
+import (
+	"context"
+	"time"
+)
+
+// HedgeConfig configures hedged requests.
+type HedgeConfig struct {
+	// Delay is how long to wait for an attempt before launching the next
+	// one in parallel.
+	// Default: 100 milliseconds
+	Delay time.Duration
+
+	// MaxHedges is the number of extra attempts that may be launched.
+	// Default: 1
+	MaxHedges int
+
+	// Idempotent declares that running the operation more than once, even
+	// concurrently, is safe. Hedging is disabled unless it is set, since
+	// every hedge that reaches the server repeats its side effects.
+	// Default: false (operations run once)
+	Idempotent bool
+}
+
+// Hedge cuts tail latency by launching parallel attempts of a slow
+// operation and taking the first success.
+//
+// Hedging is not retry: if every launched attempt fails, Execute returns
+// the first error without launching the remaining hedges. Compose with
+// Retry to retry failures.
+type Hedge struct {
+	config HedgeConfig
+}
+
+// NewHedge creates a new hedge.
+func NewHedge(config HedgeConfig) *Hedge {
+	if config.Delay <= 0 {
+		config.Delay = 100 * time.Millisecond
+	}
+	if config.MaxHedges <= 0 {
+		config.MaxHedges = 1
+	}
+	return &Hedge{config: config}
+}
+
+// Execute runs op, launching another attempt each time Delay passes
+// without a result, up to MaxHedges extra attempts. The first success is
+// returned and the other attempts' contexts are cancelled. Without
+// HedgeConfig.Idempotent, op runs exactly once.
+func (h *Hedge) Execute(ctx context.Context, op func(context.Context) error) error {
+	if !h.config.Idempotent {
+		return op(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	attempts := h.config.MaxHedges + 1
+	results := make(chan error, attempts)
+	launch := func() {
+		go func() {
+			results <- op(ctx)
+		}()
+	}
+
+	launch()
+	launched, pending := 1, 1
+	timer := time.NewTimer(h.config.Delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for {
+		select {
+		case err := <-results:
+			pending--
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			if pending == 0 {
+				return firstErr
+			}
+		case <-timer.C:
+			if launched < attempts {
+				launch()
+				launched++
+				pending++
+				timer.Reset(h.config.Delay)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
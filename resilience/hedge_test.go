@@ -0,0 +1,113 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowThenFast returns an op whose first attempt blocks until cancelled and
+// whose later attempts succeed immediately.
+func slowThenFast(calls *atomic.Int32, firstCancelled chan<- struct{}) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			close(firstCancelled)
+			return ctx.Err()
+		}
+		return nil
+	}
+}
+
+func TestHedge_FastHedgeBeatsSlowAttempt(t *testing.T) {
+	h := NewHedge(HedgeConfig{Delay: 10 * time.Millisecond, Idempotent: true})
+	var calls atomic.Int32
+	cancelled := make(chan struct{})
+
+	start := time.Now()
+	if err := h.Execute(context.Background(), slowThenFast(&calls, cancelled)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Execute() took %v, want the hedge's latency", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("attempts = %d, want 2", calls.Load())
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("slow attempt was not cancelled")
+	}
+}
+
+func TestHedge_NotIdempotentRunsOnce(t *testing.T) {
+	h := NewHedge(HedgeConfig{Delay: time.Millisecond})
+	var calls atomic.Int32
+
+	err := h.Execute(context.Background(), func(ctx context.Context) error {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("attempts = %d, want 1 without Idempotent", calls.Load())
+	}
+}
+
+func TestHedge_FastSuccessLaunchesNoHedge(t *testing.T) {
+	h := NewHedge(HedgeConfig{Delay: 50 * time.Millisecond, MaxHedges: 3, Idempotent: true})
+	var calls atomic.Int32
+
+	_ = h.Execute(context.Background(), func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	time.Sleep(60 * time.Millisecond)
+
+	if calls.Load() != 1 {
+		t.Errorf("attempts = %d, want 1", calls.Load())
+	}
+}
+
+func TestHedge_AllAttemptsFail(t *testing.T) {
+	h := NewHedge(HedgeConfig{Delay: 5 * time.Millisecond, MaxHedges: 2, Idempotent: true})
+	var calls atomic.Int32
+	errFirst := errors.New("first")
+
+	err := h.Execute(context.Background(), func(ctx context.Context) error {
+		n := calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		if n == 1 {
+			return errFirst
+		}
+		return errors.New("later")
+	})
+
+	if !errors.Is(err, errFirst) {
+		t.Errorf("Execute() error = %v, want first error", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", calls.Load())
+	}
+}
+
+func TestHedge_ContextCancelled(t *testing.T) {
+	h := NewHedge(HedgeConfig{Delay: 5 * time.Millisecond, Idempotent: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := h.Execute(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Execute() error = %v, want context.DeadlineExceeded", err)
+	}
+}
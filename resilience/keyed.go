@@ -0,0 +1,151 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedCircuitBreakerConfig configures a KeyedCircuitBreaker.
+type KeyedCircuitBreakerConfig struct {
+	// Breaker configures each per-key circuit breaker. OnStateChange, if
+	// set, is shared by all keys.
+	Breaker CircuitBreakerConfig
+
+	// IdleTimeout is how long a key may go unused before its breaker is
+	// discarded. Only closed breakers are discarded, so an idle key cannot
+	// escape an open circuit by being forgotten.
+	// Default: 10 minutes
+	IdleTimeout time.Duration
+}
+
+// KeyedCircuitBreaker keeps an independent CircuitBreaker per key, such as
+// a downstream host or tenant, so one failing backend does not block
+// requests to healthy ones.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Growth: Breakers are created on first use and swept after IdleTimeout
+//     without use, bounding memory to recently active keys.
+type KeyedCircuitBreaker struct {
+	config KeyedCircuitBreakerConfig
+
+	mu        sync.Mutex
+	breakers  map[string]*keyedBreaker
+	lastSweep time.Time
+}
+
+type keyedBreaker struct {
+	cb       *CircuitBreaker
+	lastUsed time.Time
+}
+
+// NewKeyedCircuitBreaker creates a keyed circuit breaker.
+func NewKeyedCircuitBreaker(config KeyedCircuitBreakerConfig) *KeyedCircuitBreaker {
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = 10 * time.Minute
+	}
+	return &KeyedCircuitBreaker{
+		config:    config,
+		breakers:  make(map[string]*keyedBreaker),
+		lastSweep: time.Now(),
+	}
+}
+
+// Execute runs op through the circuit breaker for key, creating it if
+// needed. Returns ErrCircuitOpen without calling op if that key's circuit
+// is open.
+func (k *KeyedCircuitBreaker) Execute(ctx context.Context, key string, op func(context.Context) error) error {
+	return k.breaker(key).Execute(ctx, op)
+}
+
+// State returns the circuit state for key. Unknown keys report closed.
+func (k *KeyedCircuitBreaker) State(key string) State {
+	k.mu.Lock()
+	entry, ok := k.breakers[key]
+	k.mu.Unlock()
+
+	if !ok {
+		return StateClosed
+	}
+	return entry.cb.State()
+}
+
+// States returns the circuit state of every tracked key.
+func (k *KeyedCircuitBreaker) States() map[string]State {
+	k.mu.Lock()
+	breakers := make(map[string]*CircuitBreaker, len(k.breakers))
+	for key, entry := range k.breakers {
+		breakers[key] = entry.cb
+	}
+	k.mu.Unlock()
+
+	states := make(map[string]State, len(breakers))
+	for key, cb := range breakers {
+		states[key] = cb.State()
+	}
+	return states
+}
+
+// Len returns the number of tracked keys.
+func (k *KeyedCircuitBreaker) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.breakers)
+}
+
+// breaker returns the breaker for key, creating it on first use. It also
+// sweeps idle breakers at most once per IdleTimeout.
+func (k *KeyedCircuitBreaker) breaker(key string) *CircuitBreaker {
+	now := time.Now()
+
+	k.mu.Lock()
+	var idle map[string]*keyedBreaker
+	if now.Sub(k.lastSweep) >= k.config.IdleTimeout {
+		k.lastSweep = now
+		idle = k.idleLocked(now)
+	}
+	entry, ok := k.breakers[key]
+	if !ok {
+		entry = &keyedBreaker{cb: NewCircuitBreaker(k.config.Breaker)}
+		k.breakers[key] = entry
+	}
+	entry.lastUsed = now
+	k.mu.Unlock()
+
+	if len(idle) > 0 {
+		k.evict(idle)
+	}
+	return entry.cb
+}
+
+// idleLocked returns breakers unused for longer than IdleTimeout.
+func (k *KeyedCircuitBreaker) idleLocked(now time.Time) map[string]*keyedBreaker {
+	idle := make(map[string]*keyedBreaker)
+	for key, entry := range k.breakers {
+		if now.Sub(entry.lastUsed) >= k.config.IdleTimeout {
+			idle[key] = entry
+		}
+	}
+	return idle
+}
+
+// evict discards the idle breakers that are closed and still unused.
+// Breaker states are read without holding k.mu, since reading a state can
+// invoke OnStateChange.
+func (k *KeyedCircuitBreaker) evict(idle map[string]*keyedBreaker) {
+	for key, entry := range idle {
+		if entry.cb.State() != StateClosed {
+			delete(idle, key)
+		}
+	}
+
+	now := time.Now()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for key, entry := range idle {
+		if k.breakers[key] == entry && now.Sub(entry.lastUsed) >= k.config.IdleTimeout {
+			delete(k.breakers, key)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedCircuitBreaker_KeysAreIndependent(t *testing.T) {
+	kcb := NewKeyedCircuitBreaker(KeyedCircuitBreakerConfig{
+		Breaker: CircuitBreakerConfig{MaxFailures: 2, ResetTimeout: time.Minute},
+	})
+	ctx := context.Background()
+	testErr := errors.New("host down")
+
+	for i := 0; i < 2; i++ {
+		_ = kcb.Execute(ctx, "bad.example.com", func(ctx context.Context) error { return testErr })
+	}
+
+	err := kcb.Execute(ctx, "bad.example.com", func(ctx context.Context) error { return nil })
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("bad host Execute() error = %v, want ErrCircuitOpen", err)
+	}
+
+	called := false
+	err = kcb.Execute(ctx, "good.example.com", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Errorf("good host Execute() = %v, called = %v; want nil, true", err, called)
+	}
+
+	want := map[string]State{"bad.example.com": StateOpen, "good.example.com": StateClosed}
+	states := kcb.States()
+	if len(states) != len(want) {
+		t.Fatalf("States() = %v, want %v", states, want)
+	}
+	for key, state := range want {
+		if states[key] != state {
+			t.Errorf("States()[%q] = %v, want %v", key, states[key], state)
+		}
+		if got := kcb.State(key); got != state {
+			t.Errorf("State(%q) = %v, want %v", key, got, state)
+		}
+	}
+	if got := kcb.State("unknown"); got != StateClosed {
+		t.Errorf("State(unknown) = %v, want closed", got)
+	}
+}
+
+func TestKeyedCircuitBreaker_EvictsIdleClosedKeys(t *testing.T) {
+	kcb := NewKeyedCircuitBreaker(KeyedCircuitBreakerConfig{
+		Breaker:     CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Minute},
+		IdleTimeout: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	_ = kcb.Execute(ctx, "idle", func(ctx context.Context) error { return nil })
+	_ = kcb.Execute(ctx, "open", func(ctx context.Context) error { return errors.New("fail") })
+	if kcb.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", kcb.Len())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	_ = kcb.Execute(ctx, "active", func(ctx context.Context) error { return nil })
+
+	states := kcb.States()
+	if _, ok := states["idle"]; ok {
+		t.Error("idle closed key should be evicted")
+	}
+	if states["open"] != StateOpen {
+		t.Errorf("open key state = %v, want retained as open", states["open"])
+	}
+	if _, ok := states["active"]; !ok {
+		t.Error("active key missing")
+	}
+}
+
+func TestKeyedCircuitBreaker_Concurrent(t *testing.T) {
+	kcb := NewKeyedCircuitBreaker(KeyedCircuitBreakerConfig{})
+	ctx := context.Background()
+	keys := []string{"a", "b", "c", "d"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = kcb.Execute(ctx, keys[i%len(keys)], func(ctx context.Context) error { return nil })
+			_ = kcb.States()
+		}(i)
+	}
+	wg.Wait()
+
+	if kcb.Len() != len(keys) {
+		t.Errorf("Len() = %d, want %d", kcb.Len(), len(keys))
+	}
+}
@@ -0,0 +1,99 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+)
+
+// PerToolRateLimiterConfig configures a PerToolRateLimiter.
+type PerToolRateLimiterConfig struct {
+	// Default configures the limiter for any tool without an entry in Tools.
+	// Each such tool still gets its own bucket.
+	Default RateLimiterConfig
+
+	// Tools maps tool IDs to their own rate limits.
+	Tools map[string]RateLimiterConfig
+}
+
+// PerToolRateLimiter keeps an independent RateLimiter per tool ID, so a
+// busy or slow-rated tool cannot consume another tool's budget.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Growth: A limiter is created on first use of each tool ID and kept
+//     for the life of the PerToolRateLimiter.
+type PerToolRateLimiter struct {
+	config PerToolRateLimiterConfig
+
+	mu       sync.Mutex
+	limiters map[string]*RateLimiter
+}
+
+// NewPerToolRateLimiter creates a per-tool rate limiter. The config's Tools
+// map is copied, so later changes to it have no effect.
+func NewPerToolRateLimiter(config PerToolRateLimiterConfig) *PerToolRateLimiter {
+	tools := make(map[string]RateLimiterConfig, len(config.Tools))
+	for toolID, c := range config.Tools {
+		tools[toolID] = c
+	}
+	config.Tools = tools
+
+	return &PerToolRateLimiter{
+		config:   config,
+		limiters: make(map[string]*RateLimiter),
+	}
+}
+
+// Allow checks if a request for toolID is allowed under its rate limit.
+func (p *PerToolRateLimiter) Allow(toolID string) bool {
+	return p.Limiter(toolID).Allow()
+}
+
+// Execute runs op if toolID's rate limit allows it, waiting for a token
+// when that tool's config sets WaitOnLimit. Returns ErrRateLimitExceeded
+// otherwise.
+func (p *PerToolRateLimiter) Execute(ctx context.Context, toolID string, op func(context.Context) error) error {
+	return p.Limiter(toolID).Execute(ctx, op)
+}
+
+// Tokens returns the number of tokens available to toolID.
+func (p *PerToolRateLimiter) Tokens(toolID string) float64 {
+	return p.Limiter(toolID).Tokens()
+}
+
+// Limiter returns the RateLimiter for toolID, creating it if needed.
+func (p *PerToolRateLimiter) Limiter(toolID string) *RateLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if rl, ok := p.limiters[toolID]; ok {
+		return rl
+	}
+
+	config, ok := p.config.Tools[toolID]
+	if !ok {
+		config = p.config.Default
+	}
+	return p.create(toolID, config)
+}
+
+// LimiterWithConfig returns the RateLimiter for id, creating it from config
+// if needed. It lets callers supply limits discovered at request time, such
+// as an API key's rate limit keyed by key ID. The config is used only on
+// first use of id; an existing limiter is returned unchanged.
+func (p *PerToolRateLimiter) LimiterWithConfig(id string, config RateLimiterConfig) *RateLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if rl, ok := p.limiters[id]; ok {
+		return rl
+	}
+	return p.create(id, config)
+}
+
+// create builds and stores the limiter for id. Callers hold p.mu.
+func (p *PerToolRateLimiter) create(id string, config RateLimiterConfig) *RateLimiter {
+	rl := NewRateLimiter(config)
+	p.limiters[id] = rl
+	return rl
+}
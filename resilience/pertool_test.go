@@ -0,0 +1,138 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestPerToolRateLimiter_IndependentLimits(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Tools: map[string]RateLimiterConfig{
+			"github.search":   {Rate: 10, Burst: 2},
+			"weather.current": {Rate: 100, Burst: 20},
+		},
+	})
+
+	// Exhaust github.search
+	for i := 0; i < 2; i++ {
+		if !p.Allow("github.search") {
+			t.Fatalf("Allow(github.search) = false on attempt %d, want true", i)
+		}
+	}
+	if p.Allow("github.search") {
+		t.Error("Allow(github.search) = true after burst exhausted, want false")
+	}
+
+	// weather.current has its own, larger budget
+	for i := 0; i < 20; i++ {
+		if !p.Allow("weather.current") {
+			t.Fatalf("Allow(weather.current) = false on attempt %d, want true", i)
+		}
+	}
+}
+
+func TestPerToolRateLimiter_Default(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Default: RateLimiterConfig{Rate: 1, Burst: 1},
+	})
+
+	if !p.Allow("a") {
+		t.Error("Allow(a) = false, want true")
+	}
+	if p.Allow("a") {
+		t.Error("Allow(a) = true after burst exhausted, want false")
+	}
+
+	// Tools without overrides do not share a bucket
+	if !p.Allow("b") {
+		t.Error("Allow(b) = false, want its own default bucket")
+	}
+}
+
+func TestPerToolRateLimiter_Execute(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Tools: map[string]RateLimiterConfig{
+			"slow": {Rate: 1, Burst: 1},
+		},
+	})
+	ctx := context.Background()
+	op := func(ctx context.Context) error { return nil }
+
+	if err := p.Execute(ctx, "slow", op); err != nil {
+		t.Fatalf("Execute() error = %v, want nil", err)
+	}
+	if err := p.Execute(ctx, "slow", op); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("Execute() error = %v, want ErrRateLimitExceeded", err)
+	}
+	if err := p.Execute(ctx, "fast", op); err != nil {
+		t.Errorf("Execute(fast) error = %v, want nil", err)
+	}
+}
+
+func TestPerToolRateLimiter_Tokens(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Tools: map[string]RateLimiterConfig{
+			"github.search": {Rate: 1, Burst: 5},
+		},
+	})
+
+	if got := p.Tokens("github.search"); got < 5 {
+		t.Errorf("Tokens() = %f, want 5", got)
+	}
+	p.Allow("github.search")
+	if got := p.Tokens("github.search"); got >= 5 {
+		t.Errorf("Tokens() = %f after Allow, want < 5", got)
+	}
+}
+
+func TestPerToolRateLimiter_Concurrent(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Default: RateLimiterConfig{Rate: 1, Burst: 10},
+	})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if p.Allow("shared") {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 10 {
+		t.Errorf("allowed = %d, want 10 (one shared limiter)", allowed)
+	}
+}
+
+func TestPerToolRateLimiter_LimiterWithConfig(t *testing.T) {
+	p := NewPerToolRateLimiter(PerToolRateLimiterConfig{
+		Default: RateLimiterConfig{Rate: 100, Burst: 100},
+	})
+
+	rl := p.LimiterWithConfig("key-1", RateLimiterConfig{Rate: 1, Burst: 2})
+	for i := 0; i < 2; i++ {
+		if !rl.Allow() {
+			t.Fatalf("Allow() = false on attempt %d, want true", i)
+		}
+	}
+	if rl.Allow() {
+		t.Error("Allow() = true after burst exhausted, want false")
+	}
+
+	// The first config wins; later calls return the same limiter
+	if got := p.LimiterWithConfig("key-1", RateLimiterConfig{Rate: 100, Burst: 100}); got != rl {
+		t.Error("LimiterWithConfig() returned a new limiter for an existing ID")
+	}
+	if got := p.Limiter("key-1"); got != rl {
+		t.Error("Limiter() did not return the limiter created by LimiterWithConfig()")
+	}
+}
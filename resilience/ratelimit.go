@@ -3,6 +3,7 @@ package resilience
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,6 +24,11 @@ type RateLimiterConfig struct {
 	// MaxWait is the maximum time to wait for a token.
 	// Default: 1 second
 	MaxWait time.Duration
+
+	// OnReject is called with the number of tokens requested whenever a
+	// request is denied. It runs without the limiter's lock held, so it
+	// may call back into the limiter.
+	OnReject func(n int)
 }
 
 // RateLimiter implements a token bucket rate limiter.
@@ -32,6 +38,9 @@ type RateLimiter struct {
 	mu          sync.Mutex
 	tokens      float64
 	lastRefresh time.Time
+
+	allowed  atomic.Int64
+	rejected atomic.Int64
 }
 
 // NewRateLimiter creates a new rate limiter.
@@ -61,6 +70,13 @@ func (rl *RateLimiter) Allow() bool {
 
 // AllowN checks if n requests are allowed.
 func (rl *RateLimiter) AllowN(n int) bool {
+	ok := rl.take(n)
+	rl.record(n, ok)
+	return ok
+}
+
+// take removes n tokens if available, without touching the counters.
+func (rl *RateLimiter) take(n int) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -74,12 +90,27 @@ func (rl *RateLimiter) AllowN(n int) bool {
 	return false
 }
 
+// record counts the outcome of a request for n tokens and fires OnReject
+// on denial. Must be called without rl.mu held.
+func (rl *RateLimiter) record(n int, allowed bool) {
+	if allowed {
+		rl.allowed.Add(1)
+		return
+	}
+	rl.rejected.Add(1)
+	if rl.config.OnReject != nil {
+		rl.config.OnReject(n)
+	}
+}
+
 // Wait blocks until a token is available or context is cancelled.
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return rl.WaitN(ctx, 1)
 }
 
-// WaitN blocks until n tokens are available.
+// WaitN blocks until n tokens are available. A wait that ends in
+// ErrRateLimitExceeded counts as a rejection; one ended by the context
+// counts as neither allowed nor rejected.
 func (rl *RateLimiter) WaitN(ctx context.Context, n int) error {
 	// Check context first
 	select {
@@ -88,7 +119,8 @@ func (rl *RateLimiter) WaitN(ctx context.Context, n int) error {
 	default:
 	}
 
-	if rl.AllowN(n) {
+	if rl.take(n) {
+		rl.record(n, true)
 		return nil
 	}
 
@@ -108,11 +140,86 @@ func (rl *RateLimiter) WaitN(ctx context.Context, n int) error {
 		return ctx.Err()
 	case <-time.After(waitTime):
 		// Try again after waiting
-		if rl.AllowN(n) {
-			return nil
+		ok := rl.take(n)
+		rl.record(n, ok)
+		if !ok {
+			return ErrRateLimitExceeded
 		}
-		return ErrRateLimitExceeded
+		return nil
+	}
+}
+
+// Reservation holds tokens taken from a RateLimiter ahead of time.
+// Act after Delay has passed, or call Cancel before then to give the
+// tokens back.
+type Reservation struct {
+	limiter   *RateLimiter
+	tokens    int
+	timeToAct time.Time
+	canceled  bool // Guarded by limiter.mu
+}
+
+// Reserve takes a token now and reports when it may be used, without
+// blocking. It is shorthand for ReserveN(1).
+func (rl *RateLimiter) Reserve() (*Reservation, bool) {
+	return rl.ReserveN(1)
+}
+
+// ReserveN takes n tokens now, letting the bucket go into debt if needed,
+// and returns a Reservation whose Delay reports how long the caller must
+// wait before acting. Later callers wait behind the debt, so the configured
+// rate still holds. It returns (nil, false) when n exceeds Burst, since such
+// a request could never be satisfied.
+func (rl *RateLimiter) ReserveN(n int) (*Reservation, bool) {
+	if n > rl.config.Burst {
+		return nil, false
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refillLocked()
+
+	now := rl.lastRefresh
+	rl.tokens -= float64(n)
+
+	var wait time.Duration
+	if rl.tokens < 0 {
+		wait = time.Duration(-rl.tokens / rl.config.Rate * float64(time.Second))
 	}
+
+	return &Reservation{
+		limiter:   rl,
+		tokens:    n,
+		timeToAct: now.Add(wait),
+	}, true
+}
+
+// Delay returns how long to wait before acting on the reservation, or 0 if
+// it may be used now. The value suits a Retry-After response.
+func (r *Reservation) Delay() time.Duration {
+	return max(time.Until(r.timeToAct), 0)
+}
+
+// Cancel returns the reserved tokens to the limiter, capped at Burst, for
+// when the caller decides not to act. Once the time to act has passed the
+// tokens count as used and Cancel has no effect, as with
+// golang.org/x/time/rate. Cancel is idempotent.
+func (r *Reservation) Cancel() {
+	rl := r.limiter
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if r.canceled {
+		return
+	}
+	r.canceled = true
+
+	rl.refillLocked()
+	if !rl.lastRefresh.Before(r.timeToAct) {
+		return
+	}
+	rl.tokens = min(rl.tokens+float64(r.tokens), float64(rl.config.Burst))
 }
 
 // Execute runs the operation if allowed by rate limit.
@@ -143,7 +250,8 @@ func (rl *RateLimiter) refillLocked() {
 	}
 }
 
-// Tokens returns the current number of available tokens.
+// Tokens returns the current number of available tokens. It is negative
+// while outstanding reservations hold the bucket in debt.
 func (rl *RateLimiter) Tokens() float64 {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -151,7 +259,25 @@ func (rl *RateLimiter) Tokens() float64 {
 	return rl.tokens
 }
 
-// Reset resets the rate limiter to full capacity.
+// Metrics returns cumulative rate limiter statistics. Reservations are not
+// counted.
+func (rl *RateLimiter) Metrics() RateLimiterMetrics {
+	return RateLimiterMetrics{
+		Allowed:  rl.allowed.Load(),
+		Rejected: rl.rejected.Load(),
+	}
+}
+
+// RateLimiterMetrics contains rate limiter statistics.
+type RateLimiterMetrics struct {
+	// Allowed counts requests granted tokens by Allow, AllowN, Wait, WaitN,
+	// or Execute.
+	Allowed int64
+	// Rejected counts requests denied by those same methods.
+	Rejected int64
+}
+
+// Reset resets the rate limiter to full capacity. Metrics are not reset.
 func (rl *RateLimiter) Reset() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
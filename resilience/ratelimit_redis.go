@@ -0,0 +1,243 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FailurePolicy decides how a RedisRateLimiter answers when Redis is
+// unavailable.
+type FailurePolicy int
+
+const (
+	// FailOpen allows requests while Redis is unavailable, trading the
+	// global limit for availability.
+	FailOpen FailurePolicy = iota
+
+	// FailClosed rejects requests while Redis is unavailable, protecting
+	// the downstream service at the cost of availability.
+	FailClosed
+)
+
+// String returns the string representation of the policy.
+func (p FailurePolicy) String() string {
+	switch p {
+	case FailOpen:
+		return "fail-open"
+	case FailClosed:
+		return "fail-closed"
+	default:
+		return "unknown"
+	}
+}
+
+// RedisRateLimiterConfig configures a RedisRateLimiter.
+type RedisRateLimiterConfig struct {
+	// Client runs the limiter script. *redis.Client, *redis.ClusterClient,
+	// and *redis.Ring all qualify. Required.
+	Client redis.Scripter
+
+	// Namespace identifies the logical limiter. Limiters with the same
+	// Namespace share one rate, wherever they run; the state lives in the
+	// Redis key "ratelimit:<Namespace>".
+	// Default: "default"
+	Namespace string
+
+	// Rate is the number of operations allowed per second, across all
+	// replicas.
+	// Default: 100
+	Rate float64
+
+	// Burst is the maximum burst size.
+	// Default: 10
+	Burst int
+
+	// WaitOnLimit makes Execute wait for a token instead of returning error.
+	// Default: false
+	WaitOnLimit bool
+
+	// MaxWait is the maximum time to wait for a token.
+	// Default: 1 second
+	MaxWait time.Duration
+
+	// FailurePolicy decides whether requests are allowed or rejected when
+	// the Redis call fails.
+	// Default: FailOpen
+	FailurePolicy FailurePolicy
+
+	// OnError, if set, is called with each Redis error before the
+	// FailurePolicy is applied.
+	OnError func(err error)
+}
+
+// gcraScript implements the generic cell rate algorithm (GCRA), a token
+// bucket stored as a single "theoretical arrival time" (TAT) in
+// microseconds. It uses the Redis clock so replicas with skewed clocks
+// agree.
+//
+// KEYS[1]: limiter key
+// ARGV[1]: emission interval per token, in microseconds
+// ARGV[2]: burst
+// ARGV[3]: tokens requested
+//
+// Returns {allowed (0 or 1), retry after in microseconds}.
+var gcraScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+  tat = now
+end
+
+local new_tat = tat + n * interval
+local allow_at = new_tat - burst * interval
+if allow_at > now then
+  return {0, math.ceil(allow_at - now)}
+end
+
+local ttl = math.max(math.ceil((new_tat - now) / 1000), 1)
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', ttl)
+return {1, 0}
+`)
+
+// RedisRateLimiter is a token bucket rate limiter whose state lives in
+// Redis, so the rate holds across every replica sharing a Namespace. Each
+// call is one atomic script execution.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use within and across processes.
+//   - Availability: When Redis fails, FailurePolicy decides the outcome.
+type RedisRateLimiter struct {
+	config   RedisRateLimiterConfig
+	key      string
+	interval float64 // Microseconds per token
+}
+
+// NewRedisRateLimiter creates a Redis-backed rate limiter.
+// Returns ErrNilClient if config.Client is nil.
+func NewRedisRateLimiter(config RedisRateLimiterConfig) (*RedisRateLimiter, error) {
+	if config.Client == nil {
+		return nil, ErrNilClient
+	}
+
+	// Apply defaults
+	if config.Namespace == "" {
+		config.Namespace = "default"
+	}
+	if config.Rate <= 0 {
+		config.Rate = 100
+	}
+	if config.Burst <= 0 {
+		config.Burst = 10
+	}
+	if config.MaxWait <= 0 {
+		config.MaxWait = time.Second
+	}
+
+	return &RedisRateLimiter{
+		config:   config,
+		key:      "ratelimit:" + config.Namespace,
+		interval: float64(time.Second/time.Microsecond) / config.Rate,
+	}, nil
+}
+
+// Allow checks if a request is allowed under the rate limit.
+func (rl *RedisRateLimiter) Allow(ctx context.Context) bool {
+	return rl.AllowN(ctx, 1)
+}
+
+// AllowN checks if n requests are allowed.
+func (rl *RedisRateLimiter) AllowN(ctx context.Context, n int) bool {
+	ok, _ := rl.take(ctx, n)
+	return ok
+}
+
+// Wait blocks until a token is available or context is cancelled.
+func (rl *RedisRateLimiter) Wait(ctx context.Context) error {
+	return rl.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens are available. Returns ErrRateLimitExceeded
+// if they cannot be had within MaxWait, immediately when n exceeds Burst.
+func (rl *RedisRateLimiter) WaitN(ctx context.Context, n int) error {
+	// More than Burst tokens can never be granted at once
+	if n > rl.config.Burst {
+		return ErrRateLimitExceeded
+	}
+
+	deadline := time.Now().Add(rl.config.MaxWait)
+
+	for {
+		// Check context first
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		ok, retryAfter := rl.take(ctx, n)
+		if ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if time.Now().Add(retryAfter).After(deadline) {
+			return ErrRateLimitExceeded
+		}
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Execute runs the operation if allowed by rate limit.
+func (rl *RedisRateLimiter) Execute(ctx context.Context, op func(context.Context) error) error {
+	if rl.config.WaitOnLimit {
+		if err := rl.Wait(ctx); err != nil {
+			return err
+		}
+	} else if !rl.Allow(ctx) {
+		return ErrRateLimitExceeded
+	}
+
+	return op(ctx)
+}
+
+// take runs the GCRA script for n tokens and reports whether they were
+// granted and, if not, how long until they would be. Redis errors are
+// resolved by the FailurePolicy; a fail-closed rejection reports MaxWait
+// as its retry delay. A cancelled or expired ctx is always a rejection,
+// never a Redis outage to fail open on.
+func (rl *RedisRateLimiter) take(ctx context.Context, n int) (bool, time.Duration) {
+	res, err := gcraScript.Run(ctx, rl.config.Client, []string{rl.key}, rl.interval, rl.config.Burst, n).Int64Slice()
+	if err == nil && len(res) != 2 {
+		err = fmt.Errorf("resilience: unexpected rate limit reply %v", res)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, 0
+		}
+		if rl.config.OnError != nil {
+			rl.config.OnError(err)
+		}
+		if rl.config.FailurePolicy == FailClosed {
+			return false, rl.config.MaxWait
+		}
+		return true, 0
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Microsecond
+}
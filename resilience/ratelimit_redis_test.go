@@ -0,0 +1,310 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis starts a miniredis server with its clock frozen and returns
+// a client for it.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	m := miniredis.RunT(t)
+	m.SetTime(time.Unix(1_700_000_000, 0))
+	client := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return m, client
+}
+
+func newTestRedisRateLimiter(t *testing.T, config RedisRateLimiterConfig) *RedisRateLimiter {
+	t.Helper()
+	rl, err := NewRedisRateLimiter(config)
+	if err != nil {
+		t.Fatalf("NewRedisRateLimiter() error = %v", err)
+	}
+	return rl
+}
+
+func TestNewRedisRateLimiter_NilClient(t *testing.T) {
+	rl, err := NewRedisRateLimiter(RedisRateLimiterConfig{})
+	if !errors.Is(err, ErrNilClient) {
+		t.Errorf("NewRedisRateLimiter() error = %v, want ErrNilClient", err)
+	}
+	if rl != nil {
+		t.Error("NewRedisRateLimiter() returned non-nil limiter with error")
+	}
+}
+
+func TestNewRedisRateLimiter_Defaults(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{Client: client})
+
+	if rl.config.Rate != 100 {
+		t.Errorf("Rate = %f, want 100", rl.config.Rate)
+	}
+	if rl.config.Burst != 10 {
+		t.Errorf("Burst = %d, want 10", rl.config.Burst)
+	}
+	if rl.config.MaxWait != time.Second {
+		t.Errorf("MaxWait = %v, want 1s", rl.config.MaxWait)
+	}
+	if rl.key != "ratelimit:default" {
+		t.Errorf("key = %q, want ratelimit:default", rl.key)
+	}
+	if rl.config.FailurePolicy != FailOpen {
+		t.Errorf("FailurePolicy = %v, want FailOpen", rl.config.FailurePolicy)
+	}
+}
+
+func TestRedisRateLimiter_AllowBurstThenRefill(t *testing.T) {
+	m, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+		Client:    client,
+		Namespace: "github.search",
+		Rate:      10,
+		Burst:     3,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if !rl.Allow(ctx) {
+			t.Fatalf("Allow() = false on attempt %d, want true", i)
+		}
+	}
+	if rl.Allow(ctx) {
+		t.Fatal("Allow() = true after burst exhausted, want false")
+	}
+
+	// One token every 100ms
+	m.SetTime(time.Unix(1_700_000_000, 0).Add(100 * time.Millisecond))
+	if !rl.Allow(ctx) {
+		t.Error("Allow() = false after refill, want true")
+	}
+	if rl.Allow(ctx) {
+		t.Error("Allow() = true beyond refilled token, want false")
+	}
+}
+
+func TestRedisRateLimiter_AllowN(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{Client: client, Rate: 1, Burst: 5})
+	ctx := context.Background()
+
+	if !rl.AllowN(ctx, 3) {
+		t.Error("AllowN(3) = false, want true")
+	}
+	if rl.AllowN(ctx, 3) {
+		t.Error("AllowN(3) = true with 2 tokens left, want false")
+	}
+	if !rl.AllowN(ctx, 2) {
+		t.Error("AllowN(2) = false, want true")
+	}
+}
+
+func TestRedisRateLimiter_SharedAcrossReplicas(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx := context.Background()
+
+	// Three replicas of the same logical limiter
+	var replicas []*RedisRateLimiter
+	for i := 0; i < 3; i++ {
+		replicas = append(replicas, newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+			Client:    client,
+			Namespace: "shared",
+			Rate:      1,
+			Burst:     5,
+		}))
+	}
+
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		for _, rl := range replicas {
+			if rl.Allow(ctx) {
+				allowed++
+			}
+		}
+	}
+	if allowed != 5 {
+		t.Errorf("allowed = %d across replicas, want 5 (one global burst)", allowed)
+	}
+
+	// A different namespace has its own bucket
+	other := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+		Client:    client,
+		Namespace: "other",
+		Rate:      1,
+		Burst:     5,
+	})
+	if !other.Allow(ctx) {
+		t.Error("Allow() = false for separate namespace, want true")
+	}
+}
+
+func TestRedisRateLimiter_Wait(t *testing.T) {
+	m := miniredis.RunT(t) // real clock
+	client := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	defer client.Close()
+
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{Client: client, Rate: 100, Burst: 1})
+	ctx := context.Background()
+
+	rl.Allow(ctx)
+
+	start := time.Now()
+	if err := rl.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v, want nil", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Wait() took %v, want about 10ms", elapsed)
+	}
+}
+
+func TestRedisRateLimiter_WaitExceedsMaxWait(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+		Client:  client,
+		Rate:    1,
+		Burst:   1,
+		MaxWait: 50 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	rl.Allow(ctx)
+
+	start := time.Now()
+	if err := rl.Wait(ctx); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("Wait() error = %v, want ErrRateLimitExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("Wait() took %v, want to fail without sleeping past MaxWait", elapsed)
+	}
+}
+
+func TestRedisRateLimiter_WaitContextCancellation(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{Client: client, Rate: 1, Burst: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := rl.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRedisRateLimiter_Execute(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{Client: client, Rate: 1, Burst: 1})
+	ctx := context.Background()
+
+	called := 0
+	op := func(ctx context.Context) error {
+		called++
+		return nil
+	}
+
+	if err := rl.Execute(ctx, op); err != nil {
+		t.Errorf("Execute() error = %v, want nil", err)
+	}
+	if err := rl.Execute(ctx, op); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("Execute() error = %v, want ErrRateLimitExceeded", err)
+	}
+	if called != 1 {
+		t.Errorf("op called %d times, want 1", called)
+	}
+}
+
+func TestRedisRateLimiter_FailurePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FailurePolicy
+		want   bool
+	}{
+		{"fail open allows", FailOpen, true},
+		{"fail closed rejects", FailClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestRedis(t)
+			var gotErr error
+			rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+				Client:        client,
+				FailurePolicy: tt.policy,
+				OnError:       func(err error) { gotErr = err },
+			})
+
+			m.SetError("LOADING Redis is loading the dataset in memory")
+
+			if got := rl.Allow(context.Background()); got != tt.want {
+				t.Errorf("Allow() = %v with Redis unavailable, want %v", got, tt.want)
+			}
+			if gotErr == nil {
+				t.Error("OnError not called for Redis failure")
+			}
+		})
+	}
+}
+
+func TestRedisRateLimiter_CancelledContextDoesNotFailOpen(t *testing.T) {
+	_, client := newTestRedis(t)
+	var gotErr error
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+		Client:        client,
+		FailurePolicy: FailOpen,
+		OnError:       func(err error) { gotErr = err },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if rl.Allow(ctx) {
+		t.Error("Allow() = true with cancelled context, want false")
+	}
+	if err := rl.Execute(ctx, func(context.Context) error { return nil }); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("Execute() error = %v, want ErrRateLimitExceeded", err)
+	}
+	if gotErr != nil {
+		t.Errorf("OnError called with %v, want no call for a cancelled context", gotErr)
+	}
+}
+
+func TestRedisRateLimiter_WaitNExceedsBurst(t *testing.T) {
+	_, client := newTestRedis(t)
+	rl := newTestRedisRateLimiter(t, RedisRateLimiterConfig{
+		Client:  client,
+		Rate:    100,
+		Burst:   5,
+		MaxWait: time.Second,
+	})
+
+	start := time.Now()
+	if err := rl.WaitN(context.Background(), 6); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("WaitN(6) error = %v, want ErrRateLimitExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("WaitN(6) took %v, want an immediate rejection", elapsed)
+	}
+}
+
+func TestFailurePolicy_String(t *testing.T) {
+	tests := []struct {
+		policy FailurePolicy
+		want   string
+	}{
+		{FailOpen, "fail-open"},
+		{FailClosed, "fail-closed"},
+		{FailurePolicy(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.String(); got != tt.want {
+			t.Errorf("FailurePolicy(%d).String() = %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
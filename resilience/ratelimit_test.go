@@ -263,3 +263,200 @@ func TestRateLimiter_Concurrent(t *testing.T) {
 		t.Errorf("Concurrent allowed = %d, want ~100", allowed)
 	}
 }
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{
+		Rate:  10, // one token per 100ms
+		Burst: 2,
+	})
+
+	// Burst tokens are available immediately
+	for i := 0; i < 2; i++ {
+		r, ok := rl.Reserve()
+		if !ok {
+			t.Fatalf("Reserve() ok = false on attempt %d, want true", i)
+		}
+		if d := r.Delay(); d != 0 {
+			t.Errorf("Delay() = %v on attempt %d, want 0", d, i)
+		}
+	}
+
+	// Each further reservation waits one more token interval
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		r, ok := rl.Reserve()
+		if !ok {
+			t.Fatalf("Reserve() ok = false, want true")
+		}
+		d := r.Delay()
+		if d > want || d < want-20*time.Millisecond {
+			t.Errorf("reservation %d: Delay() = %v, want ~%v", i, d, want)
+		}
+	}
+
+	// Reservations put the bucket in debt, so Allow is denied
+	if rl.Allow() {
+		t.Error("Allow() = true with outstanding reservations, want false")
+	}
+}
+
+func TestRateLimiter_ReserveN_ExceedsBurst(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 10, Burst: 2})
+
+	if r, ok := rl.ReserveN(3); ok || r != nil {
+		t.Errorf("ReserveN(3) = (%v, %v), want (nil, false)", r, ok)
+	}
+	if tokens := rl.Tokens(); tokens < 2 {
+		t.Errorf("Tokens() = %f after rejected reservation, want 2", tokens)
+	}
+}
+
+func TestReservation_Cancel(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{
+		Rate:  1, // slow refill so the test observes only the cancellation
+		Burst: 3,
+	})
+
+	rl.AllowN(3)
+	r, _ := rl.ReserveN(3)
+	if r.Delay() == 0 {
+		t.Fatal("Delay() = 0 for a reservation in debt, want > 0")
+	}
+
+	r.Cancel()
+	if tokens := rl.Tokens(); tokens < -0.5 {
+		t.Errorf("Tokens() = %f after Cancel(), want the debt restored", tokens)
+	}
+
+	// A second Cancel must not restore the tokens again
+	r.Cancel()
+	if tokens := rl.Tokens(); tokens > 0.5 {
+		t.Errorf("Tokens() = %f after repeated Cancel(), want about 0", tokens)
+	}
+}
+
+func TestReservation_CancelAfterTimeToAct(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 1, Burst: 1})
+
+	r, _ := rl.Reserve()
+	if r.Delay() != 0 {
+		t.Fatalf("Delay() = %v, want 0", r.Delay())
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// The reservation was usable, so its token counts as spent
+	r.Cancel()
+	if rl.Allow() {
+		t.Error("Allow() = true after a late Cancel(), want false")
+	}
+}
+
+func TestReservation_CancelCapsAtBurst(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 10, Burst: 2})
+
+	rl.AllowN(2)
+	r, _ := rl.Reserve() // in debt, so Cancel still restores
+	rl.Reset()
+	r.Cancel()
+
+	if tokens := rl.Tokens(); tokens > 2 {
+		t.Errorf("Tokens() = %f, want at most Burst (2)", tokens)
+	}
+}
+
+func TestRateLimiter_Metrics(t *testing.T) {
+	var mu sync.Mutex
+	var rejectedTokens []int
+	rl := NewRateLimiter(RateLimiterConfig{
+		Rate:  1, // slow refill so only the burst is allowed
+		Burst: 10,
+		OnReject: func(n int) {
+			mu.Lock()
+			rejectedTokens = append(rejectedTokens, n)
+			mu.Unlock()
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = rl.Execute(context.Background(), func(ctx context.Context) error { return nil })
+		}()
+	}
+	wg.Wait()
+
+	m := rl.Metrics()
+	if m.Allowed+m.Rejected != 50 {
+		t.Errorf("Allowed + Rejected = %d, want 50", m.Allowed+m.Rejected)
+	}
+	if m.Allowed != 10 {
+		t.Errorf("Allowed = %d, want 10", m.Allowed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if int64(len(rejectedTokens)) != m.Rejected {
+		t.Errorf("OnReject calls = %d, want %d", len(rejectedTokens), m.Rejected)
+	}
+	for _, n := range rejectedTokens {
+		if n != 1 {
+			t.Errorf("OnReject(%d), want 1", n)
+		}
+	}
+}
+
+func TestRateLimiter_OnRejectOnlyOnDenial(t *testing.T) {
+	calls := 0
+	rl := NewRateLimiter(RateLimiterConfig{
+		Rate:     1,
+		Burst:    3,
+		OnReject: func(n int) { calls++ },
+	})
+
+	if !rl.AllowN(3) {
+		t.Fatal("AllowN(3) = false, want true")
+	}
+	if calls != 0 {
+		t.Errorf("OnReject calls = %d after allowed request, want 0", calls)
+	}
+
+	if rl.AllowN(2) {
+		t.Fatal("AllowN(2) = true when empty, want false")
+	}
+	if calls != 1 {
+		t.Errorf("OnReject calls = %d after denial, want 1", calls)
+	}
+}
+
+func TestRateLimiter_OnRejectMayReenter(t *testing.T) {
+	var rl *RateLimiter
+	var tokens float64
+	rl = NewRateLimiter(RateLimiterConfig{
+		Rate:  1,
+		Burst: 1,
+		OnReject: func(n int) {
+			// Would deadlock if called with the lock held
+			tokens = rl.Tokens()
+		},
+	})
+
+	rl.Allow()
+	rl.Allow()
+
+	if tokens >= 1 {
+		t.Errorf("Tokens() from OnReject = %f, want < 1", tokens)
+	}
+}
+
+func TestRateLimiter_MetricsNilCallback(t *testing.T) {
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 1, Burst: 1})
+
+	rl.Allow()
+	rl.Allow()
+
+	m := rl.Metrics()
+	if m.Allowed != 1 || m.Rejected != 1 {
+		t.Errorf("Metrics() = %+v, want 1 allowed, 1 rejected", m)
+	}
+}
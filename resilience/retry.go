@@ -2,8 +2,12 @@ package resilience
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
 	"math/rand/v2"
+	"net"
+	"syscall"
 	"time"
 )
 
@@ -17,6 +21,14 @@ const (
 	BackoffLinear
 	// BackoffConstant uses the same delay for all retries.
 	BackoffConstant
+	// BackoffFullJitter picks a delay uniformly between zero and the
+	// exponential backoff ("full jitter"). It spreads concurrent retries
+	// the most, at the cost of sometimes retrying almost immediately.
+	BackoffFullJitter
+	// BackoffDecorrelatedJitter picks a delay uniformly between
+	// InitialDelay and three times the previous delay ("decorrelated
+	// jitter"), so each client's schedule drifts independently.
+	BackoffDecorrelatedJitter
 )
 
 // RetryConfig configures the retry behavior.
@@ -42,20 +54,64 @@ type RetryConfig struct {
 	Strategy BackoffStrategy
 
 	// Jitter adds randomness to delays to prevent thundering herd.
+	// Ignored by BackoffFullJitter and BackoffDecorrelatedJitter, which
+	// are randomized by definition.
 	// Default: true
 	Jitter bool
 
-	// RetryIf determines if an error should trigger a retry.
+	// RetryIf determines if an error should trigger a retry. When set, it
+	// takes precedence over an Executor's WithFailureClassifier.
 	// Default: all non-nil errors trigger retry.
 	RetryIf func(err error) bool
 
+	// RetryableErrors, when non-empty, restricts retries to errors matching
+	// one of these values via errors.Is. RetryIf is still consulted for
+	// matching errors.
+	// Default: nil (no restriction)
+	RetryableErrors []error
+
+	// Idempotent declares that repeating the operation is safe even if a
+	// previous attempt reached the server. When false and SafeToRetry is
+	// set, only errors for which SafeToRetry returns true are retried.
+	// Default: false
+	Idempotent bool
+
+	// SafeToRetry reports whether err guarantees the request never reached
+	// the server (e.g., a connection-establishment failure), making a retry
+	// safe for non-idempotent operations. IsDialError is a suitable default
+	// for network clients. Ignored when Idempotent is true.
+	// Default: nil (no restriction, for backward compatibility)
+	SafeToRetry func(err error) bool
+
+	// RetryContextErrors allows context.Canceled and context.DeadlineExceeded
+	// returned by the operation to be retried (subject to RetryIf).
+	// Default: false (context errors are returned immediately)
+	RetryContextErrors bool
+
+	// RetryAfter extracts a server-requested delay (e.g., from a 429 or 503
+	// Retry-After header) from err. When it returns (d, true), the next
+	// retry waits d instead of the computed backoff, without jitter and
+	// still capped at MaxDelay. RetryAfterFromError is a suitable
+	// implementation.
+	// Default: nil (always use computed backoff)
+	RetryAfter func(err error) (time.Duration, bool)
+
+	// Budget limits retries to a fraction of requests, shared across every
+	// Retry using it. When the budget is exhausted, Execute stops retrying
+	// and returns an error wrapping both ErrRetryBudgetExhausted and the
+	// last attempt's error.
+	// Default: nil (unlimited)
+	Budget *RetryBudget
+
 	// OnRetry is called before each retry attempt.
 	OnRetry func(attempt int, err error, delay time.Duration)
 }
 
 // Retry implements retry with backoff.
 type Retry struct {
-	config RetryConfig
+	config        RetryConfig
+	customRetryIf bool                // RetryIf was set by the caller
+	int64N        func(n int64) int64 // Random source for jitter; replaced in tests
 }
 
 // NewRetry creates a new retry handler.
@@ -73,16 +129,39 @@ func NewRetry(config RetryConfig) *Retry {
 	if config.Multiplier <= 0 {
 		config.Multiplier = 2.0
 	}
+	customRetryIf := config.RetryIf != nil
 	if config.RetryIf == nil {
 		config.RetryIf = func(err error) bool { return err != nil }
 	}
 
-	return &Retry{config: config}
+	// #nosec G404 -- jitter is non-cryptographic timing variance.
+	return &Retry{config: config, customRetryIf: customRetryIf, int64N: rand.Int64N}
 }
 
 // Execute runs the operation with retry logic.
 func (r *Retry) Execute(ctx context.Context, op func(context.Context) error) error {
+	return r.execute(ctx, op, r.config.RetryIf)
+}
+
+// retryIf returns the classifier for an Executor with the given
+// executor-level classifier: RetryIf if set explicitly, else classifier,
+// else the default.
+func (r *Retry) retryIf(classifier func(error) bool) func(error) bool {
+	if classifier != nil && !r.customRetryIf {
+		return classifier
+	}
+	return r.config.RetryIf
+}
+
+// execute runs op with retry logic, using retryIf in place of
+// RetryConfig.RetryIf.
+func (r *Retry) execute(ctx context.Context, op func(context.Context) error, retryIf func(error) bool) error {
 	var lastErr error
+	var delay time.Duration
+
+	if r.config.Budget != nil {
+		r.config.Budget.recordRequest()
+	}
 
 	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
 		err := op(ctx)
@@ -93,8 +172,13 @@ func (r *Retry) Execute(ctx context.Context, op func(context.Context) error) err
 
 		lastErr = err
 
+		// Retrying a cancelled or expired operation is pointless
+		if !r.config.RetryContextErrors && isContextError(err) {
+			return err
+		}
+
 		// Check if we should retry
-		if !r.config.RetryIf(err) {
+		if !r.shouldRetry(err, retryIf) {
 			return err
 		}
 
@@ -103,8 +187,18 @@ func (r *Retry) Execute(ctx context.Context, op func(context.Context) error) err
 			break
 		}
 
-		// Calculate delay
-		delay := r.calculateDelay(attempt)
+		// Fail fast rather than amplify load during a broad outage
+		if r.config.Budget != nil && !r.config.Budget.tryRetry() {
+			return fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err)
+		}
+
+		// Calculate delay, preferring a delay requested by the server
+		delay = r.nextDelay(attempt, delay)
+		if r.config.RetryAfter != nil {
+			if d, ok := r.config.RetryAfter(err); ok && d >= 0 {
+				delay = min(d, r.config.MaxDelay)
+			}
+		}
 
 		// Callback before retry
 		if r.config.OnRetry != nil {
@@ -123,10 +217,121 @@ func (r *Retry) Execute(ctx context.Context, op func(context.Context) error) err
 	return lastErr
 }
 
+// shouldRetry applies error classification and idempotency rules.
+func (r *Retry) shouldRetry(err error, retryIf func(error) bool) bool {
+	if len(r.config.RetryableErrors) > 0 && !matchesAny(err, r.config.RetryableErrors) {
+		return false
+	}
+	if !retryIf(err) {
+		return false
+	}
+	if !r.config.Idempotent && r.config.SafeToRetry != nil && !r.config.SafeToRetry(err) {
+		return false
+	}
+	return true
+}
+
+func matchesAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDialError reports whether err occurred while establishing a connection,
+// before any request bytes were sent. It recognizes dial failures, DNS
+// resolution errors, and refused connections.
+//
+// Use it as RetryConfig.SafeToRetry for non-idempotent network calls:
+// a refused dial is safe to retry, while a read timeout after the request
+// was written is not.
+func IsDialError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// RetryAfterError wraps an error with a delay the server asked callers to
+// wait before retrying, such as a parsed Retry-After header.
+type RetryAfterError struct {
+	Err   error
+	Delay time.Duration
+}
+
+// Error returns the wrapped error's message.
+func (e *RetryAfterError) Error() string {
+	if e.Err == nil {
+		return "resilience: retry after " + e.Delay.String()
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter returns the requested delay.
+func (e *RetryAfterError) RetryAfter() time.Duration {
+	return e.Delay
+}
+
+// RetryAfterFromError finds an error in err's chain with a
+// RetryAfter() time.Duration method, such as *RetryAfterError, and returns
+// its delay. Use it as RetryConfig.RetryAfter.
+func RetryAfterFromError(err error) (time.Duration, bool) {
+	var ra interface{ RetryAfter() time.Duration }
+	if errors.As(err, &ra) {
+		return ra.RetryAfter(), true
+	}
+	return 0, false
+}
+
+// isContextError reports whether err is a context cancellation or deadline.
+// A per-attempt ErrTimeout also matches context.DeadlineExceeded but is a
+// failure of that attempt, not of the caller's context, so it is excluded.
+func isContextError(err error) bool {
+	if errors.Is(err, ErrTimeout) {
+		return false
+	}
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// nextDelay returns the delay before the retry following attempt, given
+// the previous delay (zero before the first retry).
+func (r *Retry) nextDelay(attempt int, prev time.Duration) time.Duration {
+	if r.config.Strategy == BackoffDecorrelatedJitter && prev > 0 {
+		return r.decorrelatedDelay(prev)
+	}
+	return r.calculateDelay(attempt)
+}
+
 func (r *Retry) calculateDelay(attempt int) time.Duration {
 	var delay time.Duration
 
 	switch r.config.Strategy {
+	case BackoffFullJitter:
+		multiplier := math.Pow(r.config.Multiplier, float64(attempt-1))
+		ceiling := min(time.Duration(float64(r.config.InitialDelay)*multiplier), r.config.MaxDelay)
+		return r.randomBetween(0, ceiling)
+
+	case BackoffDecorrelatedJitter:
+		return r.decorrelatedDelay(r.config.InitialDelay)
+
 	case BackoffConstant:
 		delay = r.config.InitialDelay
 
@@ -146,14 +351,27 @@ func (r *Retry) calculateDelay(attempt int) time.Duration {
 	// Add jitter if enabled
 	if r.config.Jitter && delay > 0 {
 		// Add up to 25% jitter
-		// #nosec G404 -- jitter is non-cryptographic timing variance.
-		jitter := time.Duration(rand.Int64N(int64(delay / 4)))
-		delay = delay + jitter
+		delay += r.randomBetween(0, delay/4)
 	}
 
 	return delay
 }
 
+// decorrelatedDelay returns a delay between InitialDelay and three times
+// prev, capped at MaxDelay.
+func (r *Retry) decorrelatedDelay(prev time.Duration) time.Duration {
+	return min(r.randomBetween(r.config.InitialDelay, prev*3), r.config.MaxDelay)
+}
+
+// randomBetween returns a uniformly random duration in [lo, hi), or lo if
+// the range is empty.
+func (r *Retry) randomBetween(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(r.int64N(int64(hi-lo)))
+}
+
 // Config returns the retry configuration.
 func (r *Retry) Config() RetryConfig {
 	return r.config
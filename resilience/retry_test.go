@@ -3,6 +3,12 @@ package resilience
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -116,6 +122,94 @@ func TestRetry_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestRetry_ContextErrorNotRetried(t *testing.T) {
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := r.Execute(ctx, func(ctx context.Context) error {
+		attempts++
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetry_ContextErrorWrappedNotRetried(t *testing.T) {
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+	})
+
+	attempts := 0
+	err := r.Execute(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return fmt.Errorf("downstream: %w", context.DeadlineExceeded)
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Execute() error = %v, want context.DeadlineExceeded", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetry_PerAttemptTimeoutRetried(t *testing.T) {
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: time.Millisecond,
+	})
+	timeout := NewTimeout(TimeoutConfig{Timeout: 5 * time.Millisecond})
+
+	var attempts atomic.Int32
+	err := r.Execute(context.Background(), func(ctx context.Context) error {
+		return timeout.Execute(ctx, func(ctx context.Context) error {
+			attempts.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	})
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Execute() error = %v, want ErrTimeout", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3 (timeouts are retried)", got)
+	}
+}
+
+func TestRetry_RetryContextErrors(t *testing.T) {
+	r := NewRetry(RetryConfig{
+		MaxAttempts:        3,
+		InitialDelay:       time.Millisecond,
+		RetryContextErrors: true,
+	})
+
+	attempts := 0
+	err := r.Execute(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return context.DeadlineExceeded
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Execute() error = %v, want context.DeadlineExceeded", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
 func TestRetry_RetryIf(t *testing.T) {
 	retryableErr := errors.New("retryable")
 	nonRetryableErr := errors.New("non-retryable")
@@ -159,6 +253,103 @@ func TestRetry_RetryIf(t *testing.T) {
 	})
 }
 
+func TestRetry_RetryableErrors(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	r := NewRetry(RetryConfig{
+		MaxAttempts:     3,
+		InitialDelay:    time.Millisecond,
+		RetryableErrors: []error{errTransient},
+	})
+
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{"listed error", errTransient, 3},
+		{"wrapped listed error", fmt.Errorf("call: %w", errTransient), 3},
+		{"unlisted error", errPermanent, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			_ = r.Execute(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return tt.err
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetry_NonIdempotent(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("i/o timeout")}
+
+	tests := []struct {
+		name         string
+		idempotent   bool
+		err          error
+		wantAttempts int
+	}{
+		{"pre-send failure retried", false, dialErr, 3},
+		{"post-send failure not retried", false, readErr, 1},
+		{"idempotent ignores SafeToRetry", true, readErr, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRetry(RetryConfig{
+				MaxAttempts:  3,
+				InitialDelay: time.Millisecond,
+				Idempotent:   tt.idempotent,
+				SafeToRetry:  IsDialError,
+			})
+
+			attempts := 0
+			err := r.Execute(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return tt.err
+			})
+			if err != tt.err {
+				t.Errorf("Execute() error = %v, want %v", err, tt.err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestIsDialError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"dial op", &net.OpError{Op: "dial", Err: errors.New("refused")}, true},
+		{"wrapped dial op", fmt.Errorf("get: %w", &net.OpError{Op: "dial", Err: errors.New("x")}), true},
+		{"dns", &net.DNSError{Err: "no such host", Name: "example.invalid"}, true},
+		{"connection refused", syscall.ECONNREFUSED, true},
+		{"read op", &net.OpError{Op: "read", Err: errors.New("timeout")}, false},
+		{"plain", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDialError(tt.err); got != tt.want {
+				t.Errorf("IsDialError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRetry_OnRetry(t *testing.T) {
 	var callbacks []struct {
 		attempt int
@@ -190,6 +381,79 @@ func TestRetry_OnRetry(t *testing.T) {
 	}
 }
 
+func TestRetry_RetryAfter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var delays []time.Duration
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 10 * time.Millisecond,
+		RetryAfter:   RetryAfterFromError,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			delays = append(delays, delay)
+			// Stop before actually sleeping for the requested delay
+			cancel()
+		},
+	})
+
+	throttled := &RetryAfterError{Err: errors.New("429 too many requests"), Delay: 2 * time.Second}
+	err := r.Execute(ctx, func(ctx context.Context) error {
+		return fmt.Errorf("call failed: %w", throttled)
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want context.Canceled", err)
+	}
+	if len(delays) != 1 || delays[0] != 2*time.Second {
+		t.Errorf("delays = %v, want [2s] (not exponential backoff)", delays)
+	}
+}
+
+func TestRetry_RetryAfterCappedByMaxDelay(t *testing.T) {
+	var delays []time.Duration
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		RetryAfter:   RetryAfterFromError,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			delays = append(delays, delay)
+		},
+	})
+
+	throttled := &RetryAfterError{Err: errors.New("429 too many requests"), Delay: time.Hour}
+	_ = r.Execute(context.Background(), func(ctx context.Context) error {
+		return throttled
+	})
+
+	want := []time.Duration{5 * time.Millisecond, 5 * time.Millisecond}
+	if !slices.Equal(delays, want) {
+		t.Errorf("delays = %v, want %v (capped at MaxDelay)", delays, want)
+	}
+}
+
+func TestRetry_RetryAfterFallsBackToBackoff(t *testing.T) {
+	var delays []time.Duration
+	r := NewRetry(RetryConfig{
+		MaxAttempts:  2,
+		InitialDelay: 5 * time.Millisecond,
+		Strategy:     BackoffConstant,
+		RetryAfter:   RetryAfterFromError,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			delays = append(delays, delay)
+		},
+	})
+
+	_ = r.Execute(context.Background(), func(ctx context.Context) error {
+		return errors.New("no hint")
+	})
+
+	if len(delays) != 1 || delays[0] != 5*time.Millisecond {
+		t.Errorf("delays = %v, want [5ms]", delays)
+	}
+}
+
 func TestRetry_BackoffStrategies(t *testing.T) {
 	t.Run("exponential", func(t *testing.T) {
 		r := NewRetry(RetryConfig{
@@ -265,3 +529,106 @@ func TestRetry_Config(t *testing.T) {
 		t.Errorf("Config().MaxAttempts = %d, want 5", config.MaxAttempts)
 	}
 }
+
+// seededRetry returns a Retry whose jitter comes from a fixed seed.
+func seededRetry(config RetryConfig) *Retry {
+	r := NewRetry(config)
+	r.int64N = rand.New(rand.NewPCG(1, 2)).Int64N
+	return r
+}
+
+// meanDuration returns the average of delays.
+func meanDuration(delays []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range delays {
+		sum += d
+	}
+	return sum / time.Duration(len(delays))
+}
+
+func TestRetry_JitterStrategyDistributions(t *testing.T) {
+	const samples = 10000
+	base := 10 * time.Millisecond
+
+	t.Run("full jitter", func(t *testing.T) {
+		r := seededRetry(RetryConfig{InitialDelay: base, Strategy: BackoffFullJitter})
+
+		// Attempt 4: uniform over [0, 10ms * 2^3)
+		ceiling := 80 * time.Millisecond
+		delays := make([]time.Duration, samples)
+		belowBase := 0
+		for i := range delays {
+			delays[i] = r.calculateDelay(4)
+			if delays[i] < 0 || delays[i] >= ceiling {
+				t.Fatalf("delay = %v, want in [0, %v)", delays[i], ceiling)
+			}
+			if delays[i] < base {
+				belowBase++
+			}
+		}
+
+		if mean := meanDuration(delays); mean < 38*time.Millisecond || mean > 42*time.Millisecond {
+			t.Errorf("mean = %v, want ≈40ms", mean)
+		}
+		// 1/8 of a uniform [0, 80ms) falls below 10ms
+		if frac := float64(belowBase) / samples; frac < 0.10 || frac > 0.15 {
+			t.Errorf("fraction below InitialDelay = %.3f, want ≈0.125", frac)
+		}
+	})
+
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		r := seededRetry(RetryConfig{InitialDelay: base, Strategy: BackoffDecorrelatedJitter})
+
+		// From prev = 40ms: uniform over [10ms, 120ms)
+		delays := make([]time.Duration, samples)
+		for i := range delays {
+			delays[i] = r.nextDelay(3, 40*time.Millisecond)
+			if delays[i] < base || delays[i] >= 120*time.Millisecond {
+				t.Fatalf("delay = %v, want in [10ms, 120ms)", delays[i])
+			}
+		}
+
+		if mean := meanDuration(delays); mean < 63*time.Millisecond || mean > 67*time.Millisecond {
+			t.Errorf("mean = %v, want ≈65ms", mean)
+		}
+	})
+
+	t.Run("deterministic with fixed seed", func(t *testing.T) {
+		config := RetryConfig{InitialDelay: base, Strategy: BackoffFullJitter}
+		a, b := seededRetry(config), seededRetry(config)
+		for i := 0; i < 100; i++ {
+			if da, db := a.calculateDelay(3), b.calculateDelay(3); da != db {
+				t.Fatalf("sample %d: %v != %v with the same seed", i, da, db)
+			}
+		}
+	})
+}
+
+func TestRetry_DecorrelatedJitterSchedule(t *testing.T) {
+	var delays []time.Duration
+	r := seededRetry(RetryConfig{
+		MaxAttempts:  8,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     20 * time.Millisecond,
+		Strategy:     BackoffDecorrelatedJitter,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			delays = append(delays, delay)
+		},
+	})
+
+	_ = r.Execute(context.Background(), func(ctx context.Context) error {
+		return errors.New("fail")
+	})
+
+	if len(delays) != 7 {
+		t.Fatalf("retries = %d, want 7", len(delays))
+	}
+	prev := time.Millisecond
+	for i, d := range delays {
+		upper := min(3*prev, 20*time.Millisecond)
+		if d < time.Millisecond || d > upper {
+			t.Errorf("delay %d = %v, want in [1ms, %v]", i, d, upper)
+		}
+		prev = d
+	}
+}
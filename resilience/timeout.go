@@ -2,6 +2,9 @@ package resilience
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,6 +18,10 @@ type TimeoutConfig struct {
 // Timeout wraps operations with a timeout.
 type Timeout struct {
 	config TimeoutConfig
+
+	timeouts      atomic.Int64
+	cancellations atomic.Int64
+	completed     atomic.Int64
 }
 
 // NewTimeout creates a new timeout wrapper.
@@ -27,8 +34,20 @@ func NewTimeout(config TimeoutConfig) *Timeout {
 	return &Timeout{config: config}
 }
 
-// Execute runs the operation with a timeout.
+// Execute runs the operation with a timeout. The effective deadline is the
+// earlier of the configured timeout and any deadline already on ctx, so a
+// caller's tighter deadline is honored and never extended.
+//
+// When the effective deadline passes, Execute returns an error matching
+// both ErrTimeout and context.DeadlineExceeded, whichever deadline fired.
+// Cancellation of ctx returns context.Canceled.
 func (t *Timeout) Execute(ctx context.Context, op func(context.Context) error) error {
+	err := t.execute(ctx, op)
+	t.record(err)
+	return err
+}
+
+func (t *Timeout) execute(ctx context.Context, op func(context.Context) error) error {
 	ctx, cancel := context.WithTimeout(ctx, t.config.Timeout)
 	defer cancel()
 
@@ -40,15 +59,58 @@ func (t *Timeout) Execute(ctx context.Context, op func(context.Context) error) e
 
 	select {
 	case err := <-done:
+		// An operation that returned because the deadline fired timed out
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == context.DeadlineExceeded {
+			return errTimeoutExceeded
+		}
 		return err
 	case <-ctx.Done():
 		if ctx.Err() == context.DeadlineExceeded {
-			return ErrTimeout
+			return errTimeoutExceeded
 		}
 		return ctx.Err()
 	}
 }
 
+// errTimeoutExceeded is returned when the effective deadline fires.
+var errTimeoutExceeded = fmt.Errorf("%w: %w", ErrTimeout, context.DeadlineExceeded)
+
+// record classifies the outcome of one Execute call.
+func (t *Timeout) record(err error) {
+	switch {
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		t.timeouts.Add(1)
+	case errors.Is(err, context.Canceled):
+		t.cancellations.Add(1)
+	default:
+		t.completed.Add(1)
+	}
+}
+
+// Metrics returns current timeout metrics.
+func (t *Timeout) Metrics() TimeoutMetrics {
+	return TimeoutMetrics{
+		TotalTimeouts:      t.timeouts.Load(),
+		TotalCancellations: t.cancellations.Load(),
+		TotalCompleted:     t.completed.Load(),
+	}
+}
+
+// TimeoutMetrics contains timeout statistics.
+//
+// A rising TotalTimeouts suggests the configured timeout is too tight or the
+// dependency is slow; a rising TotalCancellations means callers are giving up
+// before the timeout fires.
+type TimeoutMetrics struct {
+	// TotalTimeouts counts calls that hit a deadline.
+	TotalTimeouts int64
+	// TotalCancellations counts calls whose context was cancelled upstream.
+	TotalCancellations int64
+	// TotalCompleted counts calls where the operation returned on its own,
+	// with or without an error.
+	TotalCompleted int64
+}
+
 // Config returns the timeout configuration.
 func (t *Timeout) Config() TimeoutConfig {
 	return t.config
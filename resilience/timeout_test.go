@@ -59,11 +59,58 @@ func TestTimeout_ExecuteTimeout(t *testing.T) {
 		return nil
 	})
 
-	if err != ErrTimeout {
+	if !errors.Is(err, ErrTimeout) {
 		t.Errorf("Execute() error = %v, want ErrTimeout", err)
 	}
 }
 
+func TestTimeout_EffectiveDeadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     time.Duration
+		parent         time.Duration
+		wantDeadlineIn time.Duration
+	}{
+		{"parent deadline shorter", time.Second, 30 * time.Millisecond, 30 * time.Millisecond},
+		{"configured timeout shorter", 30 * time.Millisecond, time.Second, 30 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := NewTimeout(TimeoutConfig{Timeout: tt.configured})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.parent)
+			defer cancel()
+
+			start := time.Now()
+			remainingCh := make(chan time.Duration, 1)
+			err := timeout.Execute(ctx, func(ctx context.Context) error {
+				deadline, ok := ctx.Deadline()
+				if !ok {
+					t.Error("operation context has no deadline")
+				}
+				remainingCh <- time.Until(deadline)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			elapsed := time.Since(start)
+
+			if remaining := <-remainingCh; remaining > tt.wantDeadlineIn {
+				t.Errorf("operation deadline in %v, want at most %v", remaining, tt.wantDeadlineIn)
+			}
+			if elapsed > tt.wantDeadlineIn+200*time.Millisecond {
+				t.Errorf("Execute() took %v, want about %v", elapsed, tt.wantDeadlineIn)
+			}
+			if !errors.Is(err, ErrTimeout) {
+				t.Errorf("Execute() error = %v, want ErrTimeout", err)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Execute() error = %v, want it to wrap context.DeadlineExceeded", err)
+			}
+		})
+	}
+}
+
 func TestTimeout_ExecuteContextCancelled(t *testing.T) {
 	timeout := NewTimeout(TimeoutConfig{
 		Timeout: time.Second,
@@ -100,7 +147,7 @@ func TestTimeout_OperationRespectsCancelledContext(t *testing.T) {
 		}
 	})
 
-	if err != ErrTimeout {
+	if !errors.Is(err, ErrTimeout) {
 		t.Errorf("Execute() error = %v, want ErrTimeout", err)
 	}
 
@@ -115,6 +162,54 @@ func TestTimeout_OperationRespectsCancelledContext(t *testing.T) {
 	}
 }
 
+func TestTimeout_Metrics(t *testing.T) {
+	timeout := NewTimeout(TimeoutConfig{Timeout: 20 * time.Millisecond})
+
+	// Completed: success and an operation error
+	_ = timeout.Execute(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+	_ = timeout.Execute(context.Background(), func(ctx context.Context) error {
+		return errors.New("op failed")
+	})
+
+	// Timeout: our deadline fires
+	err := timeout.Execute(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Execute() error = %v, want ErrTimeout", err)
+	}
+
+	// Cancellation: caller gives up first
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+	err = timeout.Execute(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Execute() error = %v, want context.Canceled", err)
+	}
+
+	m := timeout.Metrics()
+	if m.TotalCompleted != 2 {
+		t.Errorf("TotalCompleted = %d, want 2", m.TotalCompleted)
+	}
+	if m.TotalTimeouts != 1 {
+		t.Errorf("TotalTimeouts = %d, want 1", m.TotalTimeouts)
+	}
+	if m.TotalCancellations != 1 {
+		t.Errorf("TotalCancellations = %d, want 1", m.TotalCancellations)
+	}
+}
+
 func TestTimeout_Config(t *testing.T) {
 	timeout := NewTimeout(TimeoutConfig{
 		Timeout: 5 * time.Second,
@@ -141,7 +236,7 @@ func TestExecuteWithTimeout(t *testing.T) {
 			time.Sleep(100 * time.Millisecond)
 			return nil
 		})
-		if err != ErrTimeout {
+		if !errors.Is(err, ErrTimeout) {
 			t.Errorf("ExecuteWithTimeout() error = %v, want ErrTimeout", err)
 		}
 	})